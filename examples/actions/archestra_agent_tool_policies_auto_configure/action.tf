@@ -0,0 +1,9 @@
+# Externals (declare elsewhere): archestra_mcp_server_installation.filesystem.
+#
+# Re-run the LLM policy analysis over every filesystem tool on demand:
+#   terraform apply -invoke=action.archestra_agent_tool_policies_auto_configure.filesystem
+action "archestra_agent_tool_policies_auto_configure" "filesystem" {
+  config {
+    tool_ids = toset([for t in archestra_mcp_server_installation.filesystem.tools : t.id])
+  }
+}
@@ -0,0 +1,26 @@
+# Externals (declare elsewhere): archestra_llm_provider_api_key.openai.
+
+locals {
+  embedding_model = "text-embedding-3-small"
+}
+
+action "archestra_embedding_connection_check" "openai" {
+  config {
+    embedding_chat_api_key_id = archestra_llm_provider_api_key.openai.id
+    embedding_model           = local.embedding_model
+  }
+}
+
+# Check the key can reach the model before switching knowledge bases to it.
+# A failed check fails the apply before the settings change lands.
+resource "archestra_organization_settings" "this" {
+  embedding_chat_api_key_id = archestra_llm_provider_api_key.openai.id
+  embedding_model           = local.embedding_model
+
+  lifecycle {
+    action_trigger {
+      events  = [before_create, before_update]
+      actions = [action.archestra_embedding_connection_check.openai]
+    }
+  }
+}
@@ -0,0 +1,16 @@
+action "archestra_llm_models_sync" "all" {}
+
+# Pull the model list of a newly added provider key right away instead of
+# waiting for the backend's periodic sync.
+resource "archestra_llm_provider_api_key" "anthropic" {
+  name         = "Anthropic"
+  llm_provider = "anthropic"
+  api_key      = var.anthropic_api_key # Declare: variable "anthropic_api_key" { sensitive = true }
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create]
+      actions = [action.archestra_llm_models_sync.all]
+    }
+  }
+}
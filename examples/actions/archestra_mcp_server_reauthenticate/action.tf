@@ -0,0 +1,13 @@
+# Variables (declare in your variables.tf): github_pat (string, sensitive, ephemeral).
+# Externals (declare elsewhere): archestra_mcp_server_installation.github.
+#
+# Swap in a fresh personal access token after the old one expired:
+#   terraform apply -invoke=action.archestra_mcp_server_reauthenticate.github
+action "archestra_mcp_server_reauthenticate" "github" {
+  config {
+    mcp_server_id = archestra_mcp_server_installation.github.id
+    environment_values = {
+      GITHUB_PERSONAL_ACCESS_TOKEN = var.github_pat
+    }
+  }
+}
@@ -0,0 +1,23 @@
+# Externals (declare elsewhere): archestra_mcp_registry_catalog_item.filesystem,
+# archestra_mcp_server_installation.filesystem.
+
+action "archestra_mcp_server_reinstall" "filesystem" {
+  config {
+    mcp_server_id = archestra_mcp_server_installation.filesystem.id
+  }
+}
+
+# Redeploy the installation whenever the catalog item's image changes. The
+# trigger lives on a terraform_data rather than the catalog item itself,
+# because the action depends on the installation, which depends on the
+# catalog item.
+resource "terraform_data" "filesystem_image" {
+  input = archestra_mcp_registry_catalog_item.filesystem.local_config.docker_image
+
+  lifecycle {
+    action_trigger {
+      events  = [after_update]
+      actions = [action.archestra_mcp_server_reinstall.filesystem]
+    }
+  }
+}
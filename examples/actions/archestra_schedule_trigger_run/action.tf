@@ -0,0 +1,10 @@
+# Variables (declare in your variables.tf): nightly_report_trigger_id (string).
+#
+# Run a schedule trigger outside its cron schedule:
+#   terraform apply -invoke=action.archestra_schedule_trigger_run.nightly_report
+action "archestra_schedule_trigger_run" "nightly_report" {
+  config {
+    trigger_id = var.nightly_report_trigger_id
+    timeout    = "20m"
+  }
+}
@@ -0,0 +1,9 @@
+# Variables (declare in your variables.tf): ci_token_id (string).
+#
+# Revoke a leaked token by rotating it, then copy the new value from the UI:
+#   terraform apply -invoke=action.archestra_token_rotate.ci
+action "archestra_token_rotate" "ci" {
+  config {
+    token_id = var.ci_token_id
+  }
+}
@@ -181,6 +181,8 @@ resource "archestra_optimization_rule" "support_short_prompts" {
   conditions = [
     { max_length = 500 }
   ]
+
+  depends_on = [archestra_llm_provider_api_key.ollama_vault]
 }
 
 resource "archestra_optimization_rule" "support_batch_no_tools" {
@@ -193,6 +195,8 @@ resource "archestra_optimization_rule" "support_batch_no_tools" {
   conditions = [
     { has_tools = false }
   ]
+
+  depends_on = [archestra_llm_provider_api_key.ollama_vault]
 }
 
 resource "archestra_optimization_rule" "support_batch_short_no_tools" {
@@ -205,6 +209,8 @@ resource "archestra_optimization_rule" "support_batch_short_no_tools" {
   conditions = [
     { max_length = 1000, has_tools = false }
   ]
+
+  depends_on = [archestra_llm_provider_api_key.ollama_vault]
 }
 
 # --- Data sources & outputs ------------------------------------------------
@@ -0,0 +1,14 @@
+# Look up the inbound address of an agent managed in another configuration,
+# e.g. to configure a forwarding rule in your mail provider.
+variable "support_agent_id" {
+  type = string
+}
+
+data "archestra_agent_email_address" "support" {
+  agent_id = var.support_agent_id
+}
+
+output "support_agent_address" {
+  # Null until the organization has an incoming-email provider configured.
+  value = data.archestra_agent_email_address.support.email_address
+}
@@ -0,0 +1,23 @@
+# Alarm when any token-cost limit on a team passes 80% of its budget.
+variable "team_id" {
+  type = string
+}
+
+data "archestra_limits" "team" {
+  entity_type = "team"
+  entity_id   = var.team_id
+  limit_type  = "token_cost"
+}
+
+check "team_token_budget" {
+  assert {
+    condition = alltrue([
+      for l in data.archestra_limits.team.limits : coalesce(l.utilization_percent, 0) < 80
+    ])
+    error_message = "A token_cost limit on the team is above 80% usage."
+  }
+}
+
+output "team_limit_headroom" {
+  value = { for l in data.archestra_limits.team.limits : l.id => l.remaining }
+}
@@ -0,0 +1,31 @@
+# Externals (declare elsewhere): archestra_mcp_server_installation.shell,
+# archestra_tool_policy_set.shell.
+
+locals {
+  shell_run = archestra_mcp_server_installation.shell.tool_id_by_name["shell__run"]
+}
+
+# What the live policies do with a destructive command.
+data "archestra_tool_policy_evaluation" "rm_rf" {
+  tool_id   = local.shell_run
+  arguments = jsonencode({ command = "rm -rf /" })
+}
+
+check "rm_rf_is_blocked" {
+  assert {
+    condition     = data.archestra_tool_policy_evaluation.rm_rf.blocked
+    error_message = "rm -rf is not blocked by the shell tool's invocation policies."
+  }
+}
+
+# The same question against rules that are still in review, before apply.
+data "archestra_tool_policy_evaluation" "rm_rf_proposed" {
+  tool_id        = local.shell_run
+  arguments      = jsonencode({ command = "rm -rf /" })
+  policies       = archestra_tool_policy_set.shell.invocation_policies
+  default_action = archestra_tool_policy_set.shell.default_invocation_action
+}
+
+output "rm_rf_proposed_action" {
+  value = data.archestra_tool_policy_evaluation.rm_rf_proposed.action
+}
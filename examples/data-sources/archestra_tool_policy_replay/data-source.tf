@@ -0,0 +1,19 @@
+# Externals (declare elsewhere): archestra_mcp_server_installation.shell,
+# archestra_tool_policy_set.shell.
+
+# Replay the last 500 shell calls through the rules under review.
+data "archestra_tool_policy_replay" "shell" {
+  tool_id        = archestra_mcp_server_installation.shell.tool_id_by_name["shell__run"]
+  policies       = archestra_tool_policy_set.shell.invocation_policies
+  default_action = archestra_tool_policy_set.shell.default_invocation_action
+  max_calls      = 500
+}
+
+output "shell_policy_blast_radius" {
+  value = {
+    replayed                = data.archestra_tool_policy_replay.shell.replayed_count
+    newly_blocked           = data.archestra_tool_policy_replay.shell.newly_blocked_count
+    newly_requires_approval = data.archestra_tool_policy_replay.shell.newly_requires_approval_count
+    examples                = [for e in data.archestra_tool_policy_replay.shell.examples : e.arguments if e.changed]
+  }
+}
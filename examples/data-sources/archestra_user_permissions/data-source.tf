@@ -0,0 +1,22 @@
+# Branch a shared module on what the running identity can do. Team admins
+# get the team-scoped resources; organization-wide ones are skipped rather
+# than failing at plan with a missing-permission error.
+data "archestra_user_permissions" "current" {}
+
+resource "archestra_organization_settings" "this" {
+  count = data.archestra_user_permissions.current.is_org_admin ? 1 : 0
+
+  app_name = "Acme Assistant"
+}
+
+# Fine-grained checks read the permission map directly.
+locals {
+  can_create_agents = contains(
+    try(data.archestra_user_permissions.current.permissions["agent"], []),
+    "create",
+  )
+}
+
+output "can_create_agents" {
+  value = local.can_create_agents
+}
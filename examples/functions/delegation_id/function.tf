@@ -0,0 +1,10 @@
+# Adopt an existing delegation without hand-assembling the composite ID.
+import {
+  to = archestra_agent_delegation.triage_to_billing
+  id = provider::archestra::delegation_id(archestra_agent.triage.id, archestra_agent.billing.id)
+}
+
+resource "archestra_agent_delegation" "triage_to_billing" {
+  agent_id        = archestra_agent.triage.id
+  target_agent_id = archestra_agent.billing.id
+}
@@ -0,0 +1,15 @@
+# Query the agents API for everything labelled team=ml in prod or staging.
+# Renders "env:prod|staging;team:ml".
+locals {
+  prod_ml_filter = provider::archestra::label_filter({
+    team = "ml"
+    env  = "prod|staging"
+  })
+}
+
+data "http" "prod_ml_agents" {
+  url = "${var.archestra_base_url}/api/agents?labels=${urlencode(local.prod_ml_filter)}"
+  request_headers = {
+    Authorization = var.archestra_api_key
+  }
+}
@@ -0,0 +1,9 @@
+# Validate a schedule at plan time and feed the normalized form onward.
+locals {
+  weekday_mornings = provider::archestra::parse_cron("0 9 * * MON-FRI", "Europe/Berlin")
+}
+
+output "weekday_mornings" {
+  # { expression = "0 9 * * 1-5", timezone = "Europe/Berlin", hour = [9], day_of_week = [1, 2, 3, 4, 5], ... }
+  value = local.weekday_mornings
+}
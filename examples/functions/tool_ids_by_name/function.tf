@@ -0,0 +1,10 @@
+# Assign a hand-picked subset of an installation's tools. A tool the server
+# no longer advertises fails the plan instead of silently dropping out.
+resource "archestra_agent_tool_batch" "filesystem_read_only" {
+  agent_id      = archestra_agent.support.id
+  mcp_server_id = archestra_mcp_server_installation.filesystem.id
+  tool_ids = toset(provider::archestra::tool_ids_by_name(
+    archestra_mcp_server_installation.filesystem.tools,
+    ["filesystem__read_file", "filesystem__list_directory"],
+  ))
+}
@@ -0,0 +1,12 @@
+# Enumerate org-scoped agents labelled team=support. Run with
+# `terraform query -generate-config-out=generated.tf` to get import blocks
+# and configuration for every match.
+list "archestra_agent" "support" {
+  provider         = archestra
+  include_resource = true
+
+  config {
+    scope  = "org"
+    labels = { team = "support" }
+  }
+}
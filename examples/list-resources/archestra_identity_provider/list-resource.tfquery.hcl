@@ -0,0 +1,4 @@
+list "archestra_identity_provider" "all" {
+  provider         = archestra
+  include_resource = true
+}
@@ -0,0 +1,9 @@
+list "archestra_limit" "team_token_cost" {
+  provider         = archestra
+  include_resource = true
+
+  config {
+    entity_type = "team"
+    limit_type  = "token_cost"
+  }
+}
@@ -0,0 +1,12 @@
+list "archestra_llm_proxy" "all" {
+  provider         = archestra
+  include_resource = true
+}
+
+list "archestra_llm_proxy" "production" {
+  provider = archestra
+
+  config {
+    labels = { env = "production" }
+  }
+}
@@ -0,0 +1,8 @@
+list "archestra_mcp_gateway" "team_gateways" {
+  provider         = archestra
+  include_resource = true
+
+  config {
+    scope = "team"
+  }
+}
@@ -0,0 +1,10 @@
+# The catalog endpoint has no query parameters; filters are applied
+# client-side.
+list "archestra_mcp_registry_catalog_item" "org" {
+  provider         = archestra
+  include_resource = true
+
+  config {
+    scope = "org"
+  }
+}
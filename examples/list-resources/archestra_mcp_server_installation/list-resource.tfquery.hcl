@@ -0,0 +1,10 @@
+# Listed installations take the backend display_name as `name`.
+list "archestra_mcp_server_installation" "github" {
+  provider         = archestra
+  include_resource = true
+
+  config {
+    catalog_id = "11111111-1111-1111-1111-111111111111"
+    scope      = "org"
+  }
+}
@@ -0,0 +1,8 @@
+list "archestra_optimization_rule" "anthropic" {
+  provider         = archestra
+  include_resource = true
+
+  config {
+    llm_provider = "anthropic"
+  }
+}
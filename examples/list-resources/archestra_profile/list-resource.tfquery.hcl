@@ -0,0 +1,8 @@
+list "archestra_profile" "legacy_profiles" {
+  provider         = archestra
+  include_resource = true
+
+  config {
+    scope = "org"
+  }
+}
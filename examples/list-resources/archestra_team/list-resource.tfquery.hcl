@@ -0,0 +1,8 @@
+list "archestra_team" "platform" {
+  provider         = archestra
+  include_resource = true
+
+  config {
+    name = "platform"
+  }
+}
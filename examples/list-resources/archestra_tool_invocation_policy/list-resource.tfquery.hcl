@@ -0,0 +1,8 @@
+list "archestra_tool_invocation_policy" "blocked" {
+  provider         = archestra
+  include_resource = true
+
+  config {
+    action = "block_always"
+  }
+}
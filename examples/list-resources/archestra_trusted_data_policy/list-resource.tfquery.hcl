@@ -0,0 +1,8 @@
+list "archestra_trusted_data_policy" "for_tool" {
+  provider         = archestra
+  include_resource = true
+
+  config {
+    tool_id = "22222222-2222-2222-2222-222222222222"
+  }
+}
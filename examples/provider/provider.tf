@@ -15,4 +15,10 @@ provider "archestra" {
   # don't commit secrets to source.
   #   export ARCHESTRA_BASE_URL="https://archestra.your-company.example"
   #   export ARCHESTRA_API_KEY="arch_..."   # mint via Settings → API Keys
+
+  # Merged into the labels of every agent, LLM proxy, MCP gateway, and
+  # catalog item this configuration manages.
+  default_labels = {
+    managed-by = "terraform"
+  }
 }
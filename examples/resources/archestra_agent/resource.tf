@@ -1,9 +1,11 @@
-# Externals (declare elsewhere): archestra_team.engineering, archestra_llm_provider_api_key.vault_backed.
+# Externals (declare elsewhere): archestra_team.engineering, archestra_llm_provider_api_key.openai,
+# archestra_llm_provider_api_key.vault_backed.
 
 # NOTE: `llm_model` is the model_id you'd pass to the upstream LLM API
 # (e.g. "gpt-4o" for OpenAI, "claude-sonnet-4-5" for Anthropic). The
 # platform auto-discovers models from your `archestra_llm_provider_api_key`,
-# so the corresponding key resource must exist for the chosen provider.
+# so the corresponding key resource must exist for the chosen provider, and
+# `llm_api_key_id` names the key to call `llm_model` with.
 # Use `archestra_llm_model` only when you need to override pricing or
 # per-model settings on a discovered model.
 
@@ -17,6 +19,8 @@ resource "archestra_agent" "support" {
   llm_model     = "gpt-4o"
   scope         = "org"
 
+  llm_api_key_id = archestra_llm_provider_api_key.openai.id
+
   suggested_prompts = [
     {
       summary_title = "Refund a charge"
@@ -44,8 +48,7 @@ resource "archestra_agent" "engineering_qa" {
   scope         = "team"
   teams         = [archestra_team.engineering.id]
 
-  # Wire a specific provider key (e.g. an Anthropic key from BYOS Vault) instead
-  # of relying on the org-wide default.
+  # The Anthropic key to call the model with, here one backed by BYOS Vault.
   llm_api_key_id = archestra_llm_provider_api_key.vault_backed.id
 }
 
@@ -57,9 +60,17 @@ resource "archestra_agent" "intake" {
   system_prompt = "Triage incoming customer emails into the right team."
   llm_model     = "gpt-4o-mini"
 
+  llm_api_key_id = archestra_llm_provider_api_key.openai.id
+
   incoming_email_enabled        = true
   incoming_email_security_mode  = "internal"
   incoming_email_allowed_domain = "acme.com"
 
   consider_context_untrusted = true # Treat email content as untrusted by default.
 }
+
+# The inbound address is computed once email is enabled; point mail routing
+# at it from the same configuration.
+output "support_intake_address" {
+  value = archestra_agent.intake.incoming_email_address
+}
@@ -0,0 +1,7 @@
+import {
+  to = archestra_agent_tool_batch.example
+  identity = {
+    agent_id      = "00000000-0000-0000-0000-000000000000"
+    mcp_server_id = "11111111-1111-1111-1111-111111111111"
+  }
+}
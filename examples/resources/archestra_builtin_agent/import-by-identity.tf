@@ -0,0 +1,6 @@
+import {
+  to = archestra_builtin_agent.example
+  identity = {
+    id = "00000000-0000-0000-0000-000000000000"
+  }
+}
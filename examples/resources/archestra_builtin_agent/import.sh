@@ -0,0 +1,3 @@
+# By ID, or by built-in agent name with the `name:` prefix.
+terraform import archestra_builtin_agent.example 00000000-0000-0000-0000-000000000000
+terraform import archestra_builtin_agent.example name:dual-llm-main-agent
@@ -1 +1,4 @@
+# By ID, or by provider ID (the slug in the SSO callback URL) with the
+# `provider_id:` prefix.
 terraform import archestra_identity_provider.example 00000000-0000-0000-0000-000000000000
+terraform import archestra_identity_provider.example provider_id:okta
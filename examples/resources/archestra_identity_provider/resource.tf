@@ -15,6 +15,9 @@ resource "archestra_identity_provider" "oidc" {
     discovery_endpoint = "https://keycloak.example.com/realms/main/.well-known/openid-configuration"
     client_id          = "archestra"
     client_secret      = var.oidc_client_secret # Declare: variable "oidc_client_secret" { sensitive = true }
+    # Or keep it out of state (Terraform 1.11+):
+    #   client_secret_wo         = var.oidc_client_secret
+    #   client_secret_wo_version = 1
     scopes             = ["openid", "email", "profile"]
     pkce               = false
 
@@ -46,3 +46,9 @@ resource "archestra_limit" "claude_family_budget" {
     "claude-haiku-4-5",
   ]
 }
+
+# Usage since the last cleanup, refreshed on every plan. Only token_cost
+# limits report usage; these are null on call limits.
+output "claude_family_budget_utilization" {
+  value = archestra_limit.claude_family_budget.utilization_percent
+}
@@ -0,0 +1,6 @@
+import {
+  to = archestra_llm_provider_api_key.example
+  identity = {
+    id = "00000000-0000-0000-0000-000000000000"
+  }
+}
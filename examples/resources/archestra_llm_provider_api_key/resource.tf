@@ -34,6 +34,16 @@ resource "archestra_llm_provider_api_key" "inline" {
   is_organization_default = true
 }
 
+# Write-only key — DB MODE ONLY, Terraform 1.11+. Same as `inline`, but the
+# key never reaches plan or state. It is sent on create and again only when
+# `api_key_wo_version` changes — bump the version after rotating the secret.
+resource "archestra_llm_provider_api_key" "write_only" {
+  name               = "Production OpenAI Key (write-only)"
+  api_key_wo         = var.openai_api_key
+  api_key_wo_version = 1
+  llm_provider       = "openai"
+}
+
 # Vault-backed key — REQUIRED in BYOS mode, accepted in either mode.
 #
 # `vault_secret_path` is illustrative — replace with a real KV v2 path you
@@ -1,8 +1,10 @@
-# Externals (declare elsewhere): archestra_llm_provider_api_key.inline, archestra_team.engineering, archestra_identity_provider.oidc.
+# Externals (declare elsewhere): archestra_llm_provider_api_key.inline, archestra_llm_provider_api_key.anthropic,
+# archestra_team.engineering, archestra_identity_provider.oidc.
 
 # NOTE: `llm_model` is the model_id from the upstream LLM API. The platform
 # auto-discovers models from `archestra_llm_provider_api_key`, so the key
-# resource for the chosen provider must exist. `archestra_llm_model` is
+# resource for the chosen provider must exist, and `llm_api_key_id` names
+# the key to call `llm_model` with. `archestra_llm_model` is
 # only for overriding pricing / per-model settings on a discovered model.
 
 # Org-wide LLM proxy — fronts an upstream model so apps can hit Archestra
@@ -13,6 +15,8 @@ resource "archestra_llm_proxy" "shared" {
   description = "Shared org-wide proxy for OpenAI traffic."
   llm_model   = "gpt-4o"
 
+  llm_api_key_id = archestra_llm_provider_api_key.inline.id
+
   passthrough_headers = ["x-correlation-id", "x-tenant-id"]
 
   labels = [
@@ -29,7 +33,6 @@ resource "archestra_llm_proxy" "authenticated" {
   llm_model            = "gpt-4o"
   identity_provider_id = archestra_identity_provider.oidc.id
 
-  # Use a dedicated provider key so this proxy's spend is attributable.
   llm_api_key_id = archestra_llm_provider_api_key.inline.id
 }
 
@@ -40,4 +43,6 @@ resource "archestra_llm_proxy" "engineering" {
   llm_model   = "claude-sonnet-4-5"
   scope       = "team"
   teams       = [archestra_team.engineering.id]
+
+  llm_api_key_id = archestra_llm_provider_api_key.anthropic.id
 }
@@ -1 +1,3 @@
+# By ID, or by exact name with the `name:` prefix.
 terraform import archestra_mcp_gateway.example 00000000-0000-0000-0000-000000000000
+terraform import archestra_mcp_gateway.example name:engineering-gateway
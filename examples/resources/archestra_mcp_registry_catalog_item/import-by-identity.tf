@@ -0,0 +1,6 @@
+import {
+  to = archestra_mcp_registry_catalog_item.example
+  identity = {
+    id = "00000000-0000-0000-0000-000000000000"
+  }
+}
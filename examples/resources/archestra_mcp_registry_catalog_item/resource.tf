@@ -199,6 +199,8 @@ resource "archestra_mcp_registry_catalog_item" "remote_oauth_advanced" {
 
 # Local MCP server pulling from a private registry using inline credentials
 # (alternative to referencing a pre-existing Kubernetes secret by name).
+# `password_wo` (Terraform 1.11+) keeps the password out of state; bump
+# `password_wo_version` to send a rotated one.
 resource "archestra_mcp_registry_catalog_item" "private_registry" {
   name        = "private-registry-mcp-server"
   description = "MCP server whose image is pulled from a private registry using inline credentials"
@@ -208,11 +210,12 @@ resource "archestra_mcp_registry_catalog_item" "private_registry" {
     docker_image = "registry.example.com/team/mcp-server:1.0.0"
     image_pull_secrets = [
       {
-        source   = "credentials"
-        server   = "registry.example.com"
-        username = var.registry_username
-        password = var.registry_password
-        email    = "devops@example.com"
+        source              = "credentials"
+        server              = "registry.example.com"
+        username            = var.registry_username
+        password_wo         = var.registry_password
+        password_wo_version = 1
+        email               = "devops@example.com"
       }
     ]
   }
@@ -0,0 +1,10 @@
+# Identity import carries only the UUID, so `name` is taken from the
+# backend's display name. When that differs from the name in your HCL
+# (local installs get an owner/team suffix), import with the composite
+# `<uuid>:<name>` ID below instead.
+import {
+  to = archestra_mcp_server_installation.example
+  identity = {
+    id = "00000000-0000-0000-0000-000000000000"
+  }
+}
@@ -28,6 +28,13 @@ resource "archestra_mcp_registry_catalog_item" "filesystem" {
 resource "archestra_mcp_server_installation" "filesystem" {
   name       = "filesystem"
   catalog_id = archestra_mcp_registry_catalog_item.filesystem.id
+
+  # Waiting for tool discovery gives up after 5 minutes by default (with a
+  # warning; tools then appear on the next refresh). Raise it for large
+  # images on slow nodes.
+  timeouts {
+    create = "15m"
+  }
 }
 
 # Install with auth fields supplied — the catalog item declared `auth_fields`
@@ -58,6 +65,16 @@ resource "archestra_mcp_server_installation" "github" {
   access_token = var.github_pat # Secret — pass via TF_VAR_github_pat or a vault data source.
 }
 
+# Same install with the token kept out of state (Terraform 1.11+). The
+# installation cannot be updated in place, so bumping
+# `access_token_wo_version` reinstalls it with the new token.
+resource "archestra_mcp_server_installation" "github_write_only" {
+  name                    = "github-write-only"
+  catalog_id              = archestra_mcp_registry_catalog_item.github.id
+  access_token_wo         = var.github_pat
+  access_token_wo_version = 1
+}
+
 # Catalog item that exposes `user_config` so installs can pass per-team
 # values. The map keys here are the field names the installer must
 # supply via `user_config_values` on the install below.
@@ -1,5 +1,10 @@
 # Variables (declare in your variables.tf): organization_id (string).
 # Externals (declare elsewhere): archestra_team.support, archestra_agent.support.
+#
+# Each rule's `llm_provider` needs an `archestra_llm_provider_api_key`, and
+# `target_model` must be one of that provider's synced models; plan fails
+# otherwise. When the key is created in the same configuration, list it in the
+# rule's `depends_on`.
 
 # Org-wide rule — anything under 500 tokens is cheap enough that gpt-4o-mini
 # handles it. The rule fires when ALL `conditions` blocks match (logical AND
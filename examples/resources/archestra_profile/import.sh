@@ -0,0 +1,4 @@
+# By ID, or by exact name with the `name:` prefix. Only agents of type
+# `profile` are accepted.
+terraform import archestra_profile.example 00000000-0000-0000-0000-000000000000
+terraform import archestra_profile.example name:default-profile
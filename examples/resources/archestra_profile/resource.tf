@@ -0,0 +1,22 @@
+# Externals (declare elsewhere): archestra_team.support.
+
+# Legacy profile still serving LLM proxy and MCP clients. Adopt it with an
+# `import` block, then migrate clients to an archestra_llm_proxy /
+# archestra_mcp_gateway pair at your own pace.
+resource "archestra_profile" "legacy" {
+  name        = "default-profile"
+  description = "Pre-split profile kept for existing clients."
+
+  passthrough_headers = ["x-correlation-id"]
+
+  labels = [
+    { key = "migration", value = "pending" },
+  ]
+}
+
+# Team-scoped profile — only members of the listed teams see it.
+resource "archestra_profile" "support" {
+  name  = "support-profile"
+  scope = "team"
+  teams = [archestra_team.support.id]
+}
@@ -1 +1,3 @@
+# By ID, or by exact team name with the `team:` prefix.
 terraform import archestra_team.example 00000000-0000-0000-0000-000000000000
+terraform import archestra_team.example team:platform
@@ -0,0 +1,7 @@
+import {
+  to = archestra_team_external_group.example
+  identity = {
+    team_id           = "00000000-0000-0000-0000-000000000000"
+    external_group_id = "engineering"
+  }
+}
@@ -0,0 +1,6 @@
+import {
+  to = archestra_tool_invocation_policy_default.example
+  identity = {
+    action = "block_always"
+  }
+}
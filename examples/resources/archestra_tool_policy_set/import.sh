@@ -0,0 +1,3 @@
+# Import by the comma-separated tool UUIDs the set should own. Rules and
+# defaults are read from the backend on the next refresh.
+terraform import archestra_tool_policy_set.example 3f2a9c4e-1b7d-4e8a-9c6f-0a1b2c3d4e5f,7c8d9e0f-2a3b-4c5d-8e6f-1a2b3c4d5e6f
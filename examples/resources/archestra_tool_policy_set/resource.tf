@@ -0,0 +1,38 @@
+# Externals (declare elsewhere): archestra_mcp_server_installation.filesystem.
+
+locals {
+  filesystem_tools = archestra_mcp_server_installation.filesystem.tool_id_by_name
+}
+
+# Every invocation and trusted-data rule of the filesystem tools, in one
+# place. Rules added to these tools in the UI show up as a diff on the next
+# plan and are deleted on apply.
+resource "archestra_tool_policy_set" "filesystem" {
+  tool_ids = toset([for t in archestra_mcp_server_installation.filesystem.tools : t.id])
+
+  default_invocation_action   = "block_when_context_is_untrusted"
+  default_trusted_data_action = "mark_as_untrusted"
+
+  invocation_policies = [
+    {
+      tool_id    = local.filesystem_tools["filesystem__write_file"]
+      conditions = [{ key = "path", operator = "startsWith", value = "/etc/" }]
+      action     = "block_always"
+      reason     = "Block writes to system configuration directories"
+    },
+    {
+      tool_id    = local.filesystem_tools["filesystem__read_file"]
+      conditions = [{ key = "path", operator = "startsWith", value = "/tmp/" }]
+      action     = "allow_when_context_is_untrusted"
+    },
+  ]
+
+  trusted_data_policies = [
+    {
+      tool_id     = local.filesystem_tools["filesystem__read_file"]
+      description = "Files under the shared docs tree are vetted"
+      conditions  = [{ key = "path", operator = "startsWith", value = "/srv/docs/" }]
+      action      = "mark_as_trusted"
+    },
+  ]
+}
@@ -0,0 +1,6 @@
+import {
+  to = archestra_trusted_data_policy_default.example
+  identity = {
+    action = "mark_as_trusted"
+  }
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.ActionWithConfigure = &AgentToolPoliciesAutoConfigureAction{}
+
+func NewAgentToolPoliciesAutoConfigureAction() action.Action {
+	return &AgentToolPoliciesAutoConfigureAction{}
+}
+
+// AgentToolPoliciesAutoConfigureAction is the re-runnable counterpart of
+// archestra_tool_policy_auto_config: it runs the LLM policy analysis each
+// time it is invoked and keeps nothing in state.
+type AgentToolPoliciesAutoConfigureAction struct {
+	client *client.ClientWithResponses
+}
+
+type AgentToolPoliciesAutoConfigureActionModel struct {
+	ToolIDs types.Set `tfsdk:"tool_ids"`
+}
+
+func (a *AgentToolPoliciesAutoConfigureAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_tool_policies_auto_configure"
+}
+
+func (a *AgentToolPoliciesAutoConfigureAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs the LLM-driven policy auto-configuration over a set of tools, writing a default invocation and trusted-data policy for each. " +
+			"Each tool's outcome and reasoning are streamed as progress; the action fails if any tool could not be configured.\n\n" +
+			"Unlike `archestra_tool_policy_auto_config`, nothing is kept in state, so the analysis can be re-run on demand (e.g. after an MCP server upgrade changed tool descriptions) without replacing a resource. " +
+			"Every invocation spends LLM tokens.",
+		Attributes: map[string]schema.Attribute{
+			"tool_ids": schema.SetAttribute{
+				MarkdownDescription: "Bare tool UUIDs to analyse.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(uuidRegexp, "tool IDs must be UUIDs")),
+				},
+			},
+		},
+	}
+}
+
+func (a *AgentToolPoliciesAutoConfigureAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if providerData := configureAction(req, resp); providerData != nil {
+		a.client = providerData.Client
+	}
+}
+
+func (a *AgentToolPoliciesAutoConfigureAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data AgentToolPoliciesAutoConfigureActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	toolIDs := parseUUIDSet(ctx, data.ToolIDs, &resp.Diagnostics, "tool_ids")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	progress(resp, "Analysing %d tool(s); this calls an LLM per tool", len(toolIDs))
+	apiResp, err := a.client.AutoConfigureAgentToolPoliciesWithResponse(ctx, client.AutoConfigureAgentToolPoliciesJSONRequestBody{
+		ToolIds: toolIDs,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to auto-configure tool policies: %s", err))
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError("Unexpected API Response",
+			fmt.Sprintf("Auto-configure tool policies returned status %d: %s", apiResp.StatusCode(), string(apiResp.Body)))
+		return
+	}
+
+	var failed []string
+	for _, r := range apiResp.JSON200.Results {
+		switch {
+		case r.Success && r.Config != nil:
+			progress(resp, "%s: invocation %s, trusted data %s (%s)",
+				r.ToolId, r.Config.ToolInvocationAction, r.Config.TrustedDataAction, r.Config.Reasoning)
+		case r.Success:
+			progress(resp, "%s: configured", r.ToolId)
+		default:
+			msg := "no error message"
+			if r.Error != nil {
+				msg = *r.Error
+			}
+			failed = append(failed, fmt.Sprintf("%s: %s", r.ToolId, msg))
+		}
+	}
+	if len(failed) > 0 {
+		resp.Diagnostics.AddError("Tool Policy Auto-Configuration Failed",
+			fmt.Sprintf("%d of %d tool(s) could not be configured:\n  %s",
+				len(failed), len(apiResp.JSON200.Results), strings.Join(failed, "\n  ")))
+	}
+}
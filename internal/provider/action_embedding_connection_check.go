@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.ActionWithConfigure = &EmbeddingConnectionCheckAction{}
+
+func NewEmbeddingConnectionCheckAction() action.Action {
+	return &EmbeddingConnectionCheckAction{}
+}
+
+// EmbeddingConnectionCheckAction asks the backend to embed a probe string
+// with a given API key and model, the check the knowledge settings page
+// runs before saving.
+type EmbeddingConnectionCheckAction struct {
+	client *client.ClientWithResponses
+}
+
+type EmbeddingConnectionCheckActionModel struct {
+	EmbeddingChatAPIKeyID types.String `tfsdk:"embedding_chat_api_key_id"`
+	EmbeddingModel        types.String `tfsdk:"embedding_model"`
+}
+
+func (a *EmbeddingConnectionCheckAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	// Named _check rather than after the operation (TestEmbeddingConnection)
+	// so the Go file does not end in _test.go.
+	resp.TypeName = req.ProviderTypeName + "_embedding_connection_check"
+}
+
+func (a *EmbeddingConnectionCheckAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks that an LLM provider API key can reach an embedding model, the **Test connection** button of the knowledge settings. " +
+			"Fails with the provider's error when it cannot. Trigger it before `archestra_organization_settings` changes `embedding_model` to catch a bad key or model name before knowledge bases start re-embedding.",
+		Attributes: map[string]schema.Attribute{
+			"embedding_chat_api_key_id": schema.StringAttribute{
+				MarkdownDescription: "LLM provider API key to embed with. Pass `archestra_llm_provider_api_key.<n>.id`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegexp, "embedding_chat_api_key_id must be a UUID"),
+				},
+			},
+			"embedding_model": schema.StringAttribute{
+				MarkdownDescription: "Embedding model to call, e.g. `text-embedding-3-small`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (a *EmbeddingConnectionCheckAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if providerData := configureAction(req, resp); providerData != nil {
+		a.client = providerData.Client
+	}
+}
+
+func (a *EmbeddingConnectionCheckAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data EmbeddingConnectionCheckActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keyID, ok := actionUUID(data.EmbeddingChatAPIKeyID, "embedding_chat_api_key_id", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	model := data.EmbeddingModel.ValueString()
+	progress(resp, "Embedding a probe with %q", model)
+	apiResp, err := a.client.TestEmbeddingConnectionWithResponse(ctx, client.TestEmbeddingConnectionJSONRequestBody{
+		EmbeddingChatApiKeyId: keyID,
+		EmbeddingModel:        model,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to test embedding connection: %s", err))
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError("Unexpected API Response",
+			fmt.Sprintf("Test embedding connection returned status %d: %s", apiResp.StatusCode(), string(apiResp.Body)))
+		return
+	}
+	if !apiResp.JSON200.Success {
+		msg := "the provider rejected the request without an error message"
+		if apiResp.JSON200.Error != nil {
+			msg = *apiResp.JSON200.Error
+		}
+		resp.Diagnostics.AddAttributeError(path.Root("embedding_model"), "Embedding Connection Failed",
+			fmt.Sprintf("Embedding with %q failed: %s", model, msg))
+		return
+	}
+	progress(resp, "Embedding with %q succeeded", model)
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+)
+
+var _ action.ActionWithConfigure = &LlmModelsSyncAction{}
+
+func NewLlmModelsSyncAction() action.Action {
+	return &LlmModelsSyncAction{}
+}
+
+// LlmModelsSyncAction refreshes the model catalog from every configured LLM
+// provider API key.
+type LlmModelsSyncAction struct {
+	client *client.ClientWithResponses
+}
+
+func (a *LlmModelsSyncAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_llm_models_sync"
+}
+
+func (a *LlmModelsSyncAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Re-fetches the model list of every LLM provider API key, the **Sync models** button of the LLM settings. " +
+			"Trigger it after creating an `archestra_llm_provider_api_key` so that `archestra_llm_model` can resolve models the provider added since the last sync.",
+	}
+}
+
+func (a *LlmModelsSyncAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if providerData := configureAction(req, resp); providerData != nil {
+		a.client = providerData.Client
+	}
+}
+
+func (a *LlmModelsSyncAction) Invoke(ctx context.Context, _ action.InvokeRequest, resp *action.InvokeResponse) {
+	progress(resp, "Syncing models from LLM provider API keys")
+	apiResp, err := a.client.SyncLlmModelsWithResponse(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to sync LLM models: %s", err))
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError("Unexpected API Response",
+			fmt.Sprintf("Sync LLM models returned status %d: %s", apiResp.StatusCode(), string(apiResp.Body)))
+		return
+	}
+	if !apiResp.JSON200.Success {
+		resp.Diagnostics.AddError("LLM Model Sync Failed", "The backend reported that the model sync did not succeed; check the backend logs for the failing provider.")
+		return
+	}
+	progress(resp, "LLM models synced")
+}
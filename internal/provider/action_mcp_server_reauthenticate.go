@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.ActionWithConfigure = &McpServerReauthenticateAction{}
+
+func NewMcpServerReauthenticateAction() action.Action {
+	return &McpServerReauthenticateAction{}
+}
+
+// McpServerReauthenticateAction replaces an installed MCP server's
+// credentials, e.g. after an expired token or a failed OAuth refresh.
+type McpServerReauthenticateAction struct {
+	client *client.ClientWithResponses
+}
+
+type McpServerReauthenticateActionModel struct {
+	McpServerID       types.String `tfsdk:"mcp_server_id"`
+	AccessToken       types.String `tfsdk:"access_token"`
+	SecretID          types.String `tfsdk:"secret_id"`
+	EnvironmentValues types.Map    `tfsdk:"environment_values"`
+	UserConfigValues  types.Map    `tfsdk:"user_config_values"`
+	IsByosVault       types.Bool   `tfsdk:"is_byos_vault"`
+	Timeout           types.String `tfsdk:"timeout"`
+}
+
+func (a *McpServerReauthenticateAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mcp_server_reauthenticate"
+}
+
+func (a *McpServerReauthenticateAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Replaces the credentials of an MCP server installation, e.g. after a token expired or an OAuth refresh failed. " +
+			"Local servers restart with the new credentials and the action waits until the installation reports `success`; remote servers return immediately.\n\n" +
+			"The credential attributes are write-only, so they accept ephemeral values such as an `ephemeral` secret-store read.",
+		Attributes: map[string]schema.Attribute{
+			"mcp_server_id": schema.StringAttribute{
+				MarkdownDescription: "MCP server installation to reauthenticate. Pass `archestra_mcp_server_installation.<n>.id`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegexp, "mcp_server_id must be a UUID"),
+				},
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "New bearer token for servers authenticated with a static token.",
+				Optional:            true,
+				WriteOnly:           true,
+			},
+			"secret_id": schema.StringAttribute{
+				MarkdownDescription: "Existing secret to use instead of `access_token`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegexp, "secret_id must be a UUID"),
+					stringvalidator.ConflictsWith(path.MatchRoot("access_token")),
+				},
+			},
+			"environment_values": schema.MapAttribute{
+				MarkdownDescription: "Environment variable values carrying credentials, replacing the current ones.",
+				Optional:            true,
+				WriteOnly:           true,
+				ElementType:         types.StringType,
+			},
+			"user_config_values": schema.MapAttribute{
+				MarkdownDescription: "User config values carrying credentials, replacing the current ones.",
+				Optional:            true,
+				WriteOnly:           true,
+				ElementType:         types.StringType,
+			},
+			"is_byos_vault": schema.BoolAttribute{
+				MarkdownDescription: "Whether the values are references into a bring-your-own-secrets Vault.",
+				Optional:            true,
+			},
+			"timeout": actionTimeoutAttribute("the server to restart", mcpServerInstallTimeout),
+		},
+	}
+}
+
+func (a *McpServerReauthenticateAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if providerData := configureAction(req, resp); providerData != nil {
+		a.client = providerData.Client
+	}
+}
+
+func (a *McpServerReauthenticateAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data McpServerReauthenticateActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := actionTimeoutContext(ctx, data.Timeout, mcpServerInstallTimeout)
+	defer cancel()
+
+	body := client.ReauthenticateMcpServerJSONRequestBody{
+		AccessToken:       data.AccessToken.ValueStringPointer(),
+		EnvironmentValues: optionalStringMap(ctx, data.EnvironmentValues, &resp.Diagnostics),
+		UserConfigValues:  optionalStringMap(ctx, data.UserConfigValues, &resp.Diagnostics),
+		IsByosVault:       data.IsByosVault.ValueBoolPointer(),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.SecretID.IsNull() {
+		secretID, ok := actionUUID(data.SecretID, "secret_id", &resp.Diagnostics)
+		if !ok {
+			return
+		}
+		body.SecretId = &secretID
+	}
+
+	serverID, ok := actionUUID(data.McpServerID, "mcp_server_id", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	apiResp, err := a.client.ReauthenticateMcpServerWithResponse(ctx, serverID, body)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to reauthenticate MCP server: %s", err))
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError("Unexpected API Response",
+			fmt.Sprintf("Reauthenticate MCP server returned status %d: %s", apiResp.StatusCode(), string(apiResp.Body)))
+		return
+	}
+	progress(resp, "Updated credentials of MCP server %q", apiResp.JSON200.Name)
+	if apiResp.JSON200.ServerType != "local" {
+		return
+	}
+	waitForInstallation(ctx, a.client, serverID, apiResp.JSON200.Name, resp)
+}
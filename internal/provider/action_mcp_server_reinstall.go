@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.ActionWithConfigure = &McpServerReinstallAction{}
+
+func NewMcpServerReinstallAction() action.Action {
+	return &McpServerReinstallAction{}
+}
+
+// McpServerReinstallAction redeploys an installed MCP server, optionally
+// with new environment or user-config values, and waits for the local
+// deployment to come back.
+type McpServerReinstallAction struct {
+	client *client.ClientWithResponses
+}
+
+type McpServerReinstallActionModel struct {
+	McpServerID       types.String `tfsdk:"mcp_server_id"`
+	EnvironmentValues types.Map    `tfsdk:"environment_values"`
+	UserConfigValues  types.Map    `tfsdk:"user_config_values"`
+	ServiceAccount    types.String `tfsdk:"service_account"`
+	IsByosVault       types.Bool   `tfsdk:"is_byos_vault"`
+	Timeout           types.String `tfsdk:"timeout"`
+}
+
+func (a *McpServerReinstallAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mcp_server_reinstall"
+}
+
+func (a *McpServerReinstallAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reinstalls an MCP server installation, e.g. after its catalog item changed (the UI's **Reinstall required** badge) or to pick up a new image. " +
+			"Local servers are redeployed and the action waits until the installation reports `success`, streaming each status change; remote servers return immediately.",
+		Attributes: map[string]schema.Attribute{
+			"mcp_server_id": schema.StringAttribute{
+				MarkdownDescription: "MCP server installation to reinstall. Pass `archestra_mcp_server_installation.<n>.id`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegexp, "mcp_server_id must be a UUID"),
+				},
+			},
+			"environment_values": schema.MapAttribute{
+				MarkdownDescription: "Environment variable values to install with, replacing the current ones.",
+				Optional:            true,
+				WriteOnly:           true,
+				ElementType:         types.StringType,
+			},
+			"user_config_values": schema.MapAttribute{
+				MarkdownDescription: "User config values to install with, replacing the current ones.",
+				Optional:            true,
+				WriteOnly:           true,
+				ElementType:         types.StringType,
+			},
+			"service_account": schema.StringAttribute{
+				MarkdownDescription: "Kubernetes service account for the redeployed server.",
+				Optional:            true,
+			},
+			"is_byos_vault": schema.BoolAttribute{
+				MarkdownDescription: "Whether the values are references into a bring-your-own-secrets Vault.",
+				Optional:            true,
+			},
+			"timeout": actionTimeoutAttribute("the installation to finish", mcpServerInstallTimeout),
+		},
+	}
+}
+
+func (a *McpServerReinstallAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if providerData := configureAction(req, resp); providerData != nil {
+		a.client = providerData.Client
+	}
+}
+
+func (a *McpServerReinstallAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data McpServerReinstallActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := actionTimeoutContext(ctx, data.Timeout, mcpServerInstallTimeout)
+	defer cancel()
+
+	body := client.ReinstallMcpServerJSONRequestBody{
+		EnvironmentValues: optionalStringMap(ctx, data.EnvironmentValues, &resp.Diagnostics),
+		UserConfigValues:  optionalStringMap(ctx, data.UserConfigValues, &resp.Diagnostics),
+		ServiceAccount:    data.ServiceAccount.ValueStringPointer(),
+		IsByosVault:       data.IsByosVault.ValueBoolPointer(),
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	serverID, ok := actionUUID(data.McpServerID, "mcp_server_id", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	apiResp, err := a.client.ReinstallMcpServerWithResponse(ctx, serverID, body)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to reinstall MCP server: %s", err))
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError("Unexpected API Response",
+			fmt.Sprintf("Reinstall MCP server returned status %d: %s", apiResp.StatusCode(), string(apiResp.Body)))
+		return
+	}
+	progress(resp, "Reinstalling MCP server %q", apiResp.JSON200.Name)
+	if apiResp.JSON200.ServerType != "local" {
+		return
+	}
+	waitForInstallation(ctx, a.client, serverID, apiResp.JSON200.Name, resp)
+}
+
+// waitForInstallation polls a local MCP server's installation status until
+// it reaches success or error.
+func waitForInstallation(ctx context.Context, c *client.ClientWithResponses, serverID uuid.UUID, name string, resp *action.InvokeResponse) {
+	pollUntilSettled(ctx, resp, fmt.Sprintf("Installation of %q", name), func(ctx context.Context) (string, bool, error) {
+		r, err := c.GetMcpServerInstallationStatusWithResponse(ctx, serverID)
+		if err != nil {
+			return "", false, err
+		}
+		if r.JSON200 == nil {
+			return "", false, fmt.Errorf("status %d: %s", r.StatusCode(), string(r.Body))
+		}
+		status := string(r.JSON200.LocalInstallationStatus)
+		switch decideInstallStatus(status) {
+		case installStatusDone:
+			return status, true, nil
+		case installStatusFailed:
+			msg := "MCP server installation failed on the backend."
+			if r.JSON200.LocalInstallationError != nil {
+				msg = *r.JSON200.LocalInstallationError
+			}
+			return status, false, fmt.Errorf("%s", msg)
+		default:
+			return status, false, nil
+		}
+	})
+}
+
+// optionalStringMap converts an optional map attribute to the pointer the
+// generated client expects, nil when unset.
+func optionalStringMap(ctx context.Context, m types.Map, diags *diag.Diagnostics) *map[string]string {
+	if m.IsNull() || m.IsUnknown() {
+		return nil
+	}
+	out := map[string]string{}
+	diags.Append(m.ElementsAs(ctx, &out, false)...)
+	return &out
+}
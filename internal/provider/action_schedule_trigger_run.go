@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.ActionWithConfigure = &ScheduleTriggerRunAction{}
+
+func NewScheduleTriggerRunAction() action.Action {
+	return &ScheduleTriggerRunAction{}
+}
+
+// ScheduleTriggerRunAction runs a schedule trigger immediately, outside its
+// cron schedule, and waits for the run to finish.
+type ScheduleTriggerRunAction struct {
+	client *client.ClientWithResponses
+}
+
+type ScheduleTriggerRunActionModel struct {
+	TriggerID types.String `tfsdk:"trigger_id"`
+	Timeout   types.String `tfsdk:"timeout"`
+}
+
+const scheduleTriggerRunTimeout = 10 * time.Minute
+
+func (a *ScheduleTriggerRunAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schedule_trigger_run"
+}
+
+func (a *ScheduleTriggerRunAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Runs a schedule trigger now, the equivalent of **Run now** in the UI, and waits for the run to finish. " +
+			"The run's status is streamed while it is in progress; a failed run fails the action with the run's error.",
+		Attributes: map[string]schema.Attribute{
+			"trigger_id": schema.StringAttribute{
+				MarkdownDescription: "Schedule trigger to run.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegexp, "trigger_id must be a UUID"),
+				},
+			},
+			"timeout": actionTimeoutAttribute("the run to finish", scheduleTriggerRunTimeout),
+		},
+	}
+}
+
+func (a *ScheduleTriggerRunAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if providerData := configureAction(req, resp); providerData != nil {
+		a.client = providerData.Client
+	}
+}
+
+func (a *ScheduleTriggerRunAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data ScheduleTriggerRunActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	triggerID, ok := actionUUID(data.TriggerID, "trigger_id", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	ctx, cancel := actionTimeoutContext(ctx, data.Timeout, scheduleTriggerRunTimeout)
+	defer cancel()
+
+	runResp, err := a.client.RunScheduleTriggerNowWithResponse(ctx, triggerID)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to run schedule trigger: %s", err))
+		return
+	}
+	if runResp.JSON200 == nil {
+		resp.Diagnostics.AddError("Unexpected API Response",
+			fmt.Sprintf("Run schedule trigger returned status %d: %s", runResp.StatusCode(), string(runResp.Body)))
+		return
+	}
+	runID := runResp.JSON200.Id
+	progress(resp, "Started run %s of schedule trigger %s", runID, triggerID)
+
+	pollUntilSettled(ctx, resp, fmt.Sprintf("Run %s", runID), func(ctx context.Context) (string, bool, error) {
+		r, err := a.client.GetScheduleTriggerRunWithResponse(ctx, triggerID, runID)
+		if err != nil {
+			return "", false, err
+		}
+		if r.JSON200 == nil {
+			return "", false, fmt.Errorf("status %d: %s", r.StatusCode(), string(r.Body))
+		}
+		status := string(r.JSON200.Status)
+		switch status {
+		case "success":
+			return status, true, nil
+		case "failed":
+			msg := "the run failed without an error message"
+			if r.JSON200.Error != nil {
+				msg = *r.JSON200.Error
+			}
+			return status, false, fmt.Errorf("%s", msg)
+		default: // running
+			return status, false, nil
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Actions wrap the backend's one-shot operations (run a schedule trigger,
+// reinstall an MCP server, rotate a token, ...) that have no declarative
+// home. They run on `terraform apply -invoke=action.<type>.<name>` or from a
+// resource's `lifecycle { action_trigger { ... } }`, and report what they
+// are doing through progress messages. Operations the backend finishes
+// asynchronously are polled until they settle, bounded by the action's
+// `timeout`.
+
+// actionPollInterval is how often asynchronous operations are polled.
+const actionPollInterval = 2 * time.Second
+
+// configureAction extracts the provider data every action needs.
+func configureAction(req action.ConfigureRequest, resp *action.ConfigureResponse) *ArchestraProviderData {
+	if req.ProviderData == nil {
+		return nil
+	}
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return nil
+	}
+	return providerData
+}
+
+// progress streams a message to the Terraform UI.
+func progress(resp *action.InvokeResponse, format string, args ...any) {
+	if resp.SendProgress == nil {
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf(format, args...)})
+}
+
+// actionTimeoutAttribute is the `timeout` attribute of actions that wait for
+// the backend.
+func actionTimeoutAttribute(waitsFor string, def time.Duration) schema.StringAttribute {
+	// "5m0s" reads as "5m" in the docs.
+	defStr := def.String()
+	if strings.HasSuffix(defStr, "m0s") {
+		defStr = strings.TrimSuffix(defStr, "0s")
+	}
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("How long to wait for %s, as a Go duration such as `30s` or `15m`. Defaults to `%s`.", waitsFor, defStr),
+		Optional:            true,
+		Validators:          []validator.String{durationValidator{}},
+	}
+}
+
+// actionTimeoutContext bounds ctx by the configured `timeout`, or def when
+// it is unset.
+func actionTimeoutContext(ctx context.Context, timeout types.String, def time.Duration) (context.Context, context.CancelFunc) {
+	d := def
+	if !timeout.IsNull() && !timeout.IsUnknown() {
+		// Validated by durationValidator at plan time.
+		d, _ = time.ParseDuration(timeout.ValueString())
+	}
+	return context.WithTimeout(ctx, d)
+}
+
+// actionUUID parses the UUID in attribute attr of an action's config. The
+// schemas don't validate UUID syntax, so a malformed one is reported
+// against its attribute rather than sent to the backend.
+func actionUUID(value types.String, attr string, diags *diag.Diagnostics) (uuid.UUID, bool) {
+	id, err := uuid.Parse(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root(attr), "Invalid "+attr, fmt.Sprintf("Could not parse %s as UUID: %s", attr, err))
+		return uuid.UUID{}, false
+	}
+	return id, true
+}
+
+// pollUntilSettled calls check every actionPollInterval until it reports
+// done or fails, or ctx expires. check returns the status it observed;
+// each change of status is streamed as progress.
+func pollUntilSettled(ctx context.Context, resp *action.InvokeResponse, what string, check func(context.Context) (status string, done bool, err error)) {
+	start := time.Now()
+	last := ""
+	for {
+		status, done, err := check(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(fmt.Sprintf("%s failed", what), err.Error())
+			return
+		}
+		if status != last {
+			progress(resp, "%s: %s (%s elapsed)", what, status, time.Since(start).Round(time.Second))
+			last = status
+		}
+		if done {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("%s did not finish", what),
+				fmt.Sprintf("Still %q after %s; raise `timeout` to wait longer. The operation keeps running on the backend.", last, time.Since(start).Round(time.Second)),
+			)
+			return
+		case <-time.After(actionPollInterval):
+		}
+	}
+}
+
+// durationValidator accepts Go duration strings greater than zero.
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive Go duration such as `30s` or `15m`"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	d, err := time.ParseDuration(req.ConfigValue.ValueString())
+	if err != nil || d <= 0 {
+		resp.Diagnostics.Append(diag.NewAttributeErrorDiagnostic(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("%q: %s.", req.ConfigValue.ValueString(), v.Description(ctx)),
+		))
+	}
+}
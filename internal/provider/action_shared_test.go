@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestDurationValidator(t *testing.T) {
+	tests := []struct {
+		value   types.String
+		wantErr bool
+	}{
+		{types.StringValue("30s"), false},
+		{types.StringValue("1h30m"), false},
+		{types.StringNull(), false},
+		{types.StringUnknown(), false},
+		{types.StringValue("0s"), true},
+		{types.StringValue("-5m"), true},
+		{types.StringValue("10"), true},
+		{types.StringValue("ten minutes"), true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.value.String(), func(t *testing.T) {
+			resp := &validator.StringResponse{}
+			durationValidator{}.ValidateString(t.Context(), validator.StringRequest{
+				Path:        path.Root("timeout"),
+				ConfigValue: tc.value,
+			}, resp)
+			if got := resp.Diagnostics.HasError(); got != tc.wantErr {
+				t.Errorf("HasError() = %v, want %v: %v", got, tc.wantErr, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestPollUntilSettled(t *testing.T) {
+	t.Run("streams status until done", func(t *testing.T) {
+		var messages []string
+		resp := &action.InvokeResponse{SendProgress: func(e action.InvokeProgressEvent) {
+			messages = append(messages, e.Message)
+		}}
+		pollUntilSettled(t.Context(), resp, "Run", func(context.Context) (string, bool, error) {
+			return "success", true, nil
+		})
+		if resp.Diagnostics.HasError() {
+			t.Fatal(resp.Diagnostics)
+		}
+		if len(messages) != 1 || !strings.HasPrefix(messages[0], "Run: success") {
+			t.Errorf("progress = %q", messages)
+		}
+	})
+
+	t.Run("check error fails", func(t *testing.T) {
+		resp := &action.InvokeResponse{}
+		pollUntilSettled(t.Context(), resp, "Run", func(context.Context) (string, bool, error) {
+			return "failed", false, errors.New("boom")
+		})
+		if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Detail() != "boom" {
+			t.Errorf("diagnostics = %v", resp.Diagnostics)
+		}
+	})
+
+	t.Run("expired context reports last status", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+		resp := &action.InvokeResponse{}
+		pollUntilSettled(ctx, resp, "Run", func(context.Context) (string, bool, error) {
+			return "running", false, nil
+		})
+		if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), `Still "running"`) {
+			t.Errorf("diagnostics = %v", resp.Diagnostics)
+		}
+	})
+}
+
+const (
+	actionTriggerID = "44444444-4444-4444-8444-444444444444"
+	actionRunID     = "55555555-5555-4555-8555-555555555555"
+	actionTokenID   = "66666666-6666-4666-8666-666666666666"
+)
+
+// TestActionInvoke invokes actions through the provider server against a
+// fake backend keyed by "METHOD path", the way `terraform apply -invoke`
+// drives them.
+func TestActionInvoke(t *testing.T) {
+	tests := []struct {
+		name         string
+		actionType   string
+		config       string
+		backend      map[string]string
+		wantProgress []string
+		wantErr      string
+	}{
+		{
+			name:       "schedule trigger run succeeds",
+			actionType: "archestra_schedule_trigger_run",
+			config:     `{"trigger_id":"` + actionTriggerID + `","timeout":null}`,
+			backend: map[string]string{
+				"POST /api/schedule-triggers/" + actionTriggerID + "/run-now":            `{"id":"` + actionRunID + `","status":"running"}`,
+				"GET /api/schedule-triggers/" + actionTriggerID + "/runs/" + actionRunID: `{"id":"` + actionRunID + `","status":"success"}`,
+			},
+			wantProgress: []string{"Started run " + actionRunID, "Run " + actionRunID + ": success"},
+		},
+		{
+			name:       "schedule trigger run fails",
+			actionType: "archestra_schedule_trigger_run",
+			config:     `{"trigger_id":"` + actionTriggerID + `","timeout":null}`,
+			backend: map[string]string{
+				"POST /api/schedule-triggers/" + actionTriggerID + "/run-now":            `{"id":"` + actionRunID + `","status":"running"}`,
+				"GET /api/schedule-triggers/" + actionTriggerID + "/runs/" + actionRunID: `{"id":"` + actionRunID + `","status":"failed","error":"agent not found"}`,
+			},
+			wantErr: "agent not found",
+		},
+		{
+			name:       "token rotation never reports the secret",
+			actionType: "archestra_token_rotate",
+			config:     `{"token_id":"` + actionTokenID + `"}`,
+			backend: map[string]string{
+				"POST /api/tokens/" + actionTokenID + "/rotate": `{"id":"` + actionTokenID + `","name":"ci","tokenStart":"arch_ab","value":"arch_abSECRET"}`,
+			},
+			wantProgress: []string{`Rotated token "ci"; the new value starts with arch_ab`},
+		},
+		{
+			name:       "embedding check reports the provider error",
+			actionType: "archestra_embedding_connection_check",
+			config:     `{"embedding_chat_api_key_id":"` + actionTokenID + `","embedding_model":"text-embedding-3-small"}`,
+			backend: map[string]string{
+				"POST /api/organization/knowledge-settings/test-embedding": `{"success":false,"error":"model not found"}`,
+			},
+			wantErr: "model not found",
+		},
+		{
+			name:       "auto-configure lists failed tools",
+			actionType: "archestra_agent_tool_policies_auto_configure",
+			config:     `{"tool_ids":["` + moveToolA + `","` + moveToolB + `"]}`,
+			backend: map[string]string{
+				"POST /api/agent-tools/auto-configure-policies": `{"success":false,"results":[` +
+					`{"toolId":"` + moveToolA + `","success":true,"config":{"reasoning":"read-only","toolInvocationAction":"allow_when_context_is_untrusted","trustedDataAction":"mark_as_trusted"}},` +
+					`{"toolId":"` + moveToolB + `","success":false,"error":"rate limited"}]}`,
+			},
+			wantProgress: []string{moveToolA + ": invocation allow_when_context_is_untrusted, trusted data mark_as_trusted (read-only)"},
+			wantErr:      moveToolB + ": rate limited",
+		},
+		{
+			name:       "malformed token ID is a diagnostic",
+			actionType: "archestra_token_rotate",
+			config:     `{"token_id":"not-a-uuid"}`,
+			wantErr:    "Invalid token_id",
+		},
+		{
+			name:       "malformed tool ID is a diagnostic",
+			actionType: "archestra_agent_tool_policies_auto_configure",
+			config:     `{"tool_ids":["` + moveToolA + `","not-a-uuid"]}`,
+			wantErr:    "Invalid UUID in tool_ids",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, ok := tc.backend[r.Method+" "+r.URL.Path]
+				if !ok {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(body))
+			}))
+			t.Cleanup(server.Close)
+			ps := newConfiguredProviderServer(t, server.URL)
+			ctx := t.Context()
+
+			schemaResp, err := ps.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			configType := schemaResp.ActionSchemas[tc.actionType].Schema.ValueType()
+			configValue, err := (&tfprotov6.RawState{JSON: []byte(tc.config)}).Unmarshal(configType)
+			if err != nil {
+				t.Fatalf("config does not match the schema: %v", err)
+			}
+			config, err := tfprotov6.NewDynamicValue(configType, configValue)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Actions are served through a separate interface until
+			// terraform-plugin-go folds it into ProviderServer.
+			stream, err := ps.(tfprotov6.ProviderServerWithActions).InvokeAction(ctx, &tfprotov6.InvokeActionRequest{ActionType: tc.actionType, Config: &config})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var progress []string
+			var diags []*tfprotov6.Diagnostic
+			for event := range stream.Events {
+				switch e := event.Type.(type) {
+				case tfprotov6.ProgressInvokeActionEventType:
+					progress = append(progress, e.Message)
+				case tfprotov6.CompletedInvokeActionEventType:
+					diags = e.Diagnostics
+				}
+			}
+
+			all := strings.Join(progress, "\n")
+			if strings.Contains(all, "SECRET") {
+				t.Errorf("progress leaked a secret: %q", progress)
+			}
+			for _, want := range tc.wantProgress {
+				if !strings.Contains(all, want) {
+					t.Errorf("progress %q does not contain %q", progress, want)
+				}
+			}
+			if tc.wantErr == "" {
+				failOnDiagnostics(t, "InvokeAction", diags)
+				return
+			}
+			for _, d := range diags {
+				if d.Severity == tfprotov6.DiagnosticSeverityError && strings.Contains(d.Summary+d.Detail, tc.wantErr) {
+					return
+				}
+			}
+			t.Errorf("expected an error containing %q, got %v", tc.wantErr, diags)
+		})
+	}
+}
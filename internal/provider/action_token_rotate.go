@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.ActionWithConfigure = &TokenRotateAction{}
+
+func NewTokenRotateAction() action.Action {
+	return &TokenRotateAction{}
+}
+
+// TokenRotateAction replaces a team or organization token's value,
+// invalidating the old one.
+type TokenRotateAction struct {
+	client *client.ClientWithResponses
+}
+
+type TokenRotateActionModel struct {
+	TokenID types.String `tfsdk:"token_id"`
+}
+
+func (a *TokenRotateAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_token_rotate"
+}
+
+func (a *TokenRotateAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Rotates a team or organization token. The old value stops working immediately. " +
+			"Actions cannot return values, so the new secret is discarded; only its prefix is reported. " +
+			"Use this to revoke a leaked token, then copy the new value from the UI.",
+		Attributes: map[string]schema.Attribute{
+			"token_id": schema.StringAttribute{
+				MarkdownDescription: "Token to rotate.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegexp, "token_id must be a UUID"),
+				},
+			},
+		},
+	}
+}
+
+func (a *TokenRotateAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if providerData := configureAction(req, resp); providerData != nil {
+		a.client = providerData.Client
+	}
+}
+
+func (a *TokenRotateAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data TokenRotateActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenID, ok := actionUUID(data.TokenID, "token_id", &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	apiResp, err := a.client.RotateTokenWithResponse(ctx, tokenID)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to rotate token: %s", err))
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError("Unexpected API Response",
+			fmt.Sprintf("Rotate token returned status %d: %s", apiResp.StatusCode(), string(apiResp.Body)))
+		return
+	}
+	progress(resp, "Rotated token %q; the new value starts with %s", apiResp.JSON200.Name, apiResp.JSON200.TokenStart)
+}
@@ -1,11 +1,23 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"time"
 
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
@@ -17,6 +29,11 @@ type agentAPIResponse struct {
 	Id                         openapi_types.UUID  `json:"id"`
 	Name                       string              `json:"name"`
 	AgentType                  string              `json:"agentType"`
+	AuthorId                   *string             `json:"authorId"`
+	AuthorName                 *string             `json:"authorName"`
+	Slug                       *string             `json:"slug"`
+	CreatedAt                  time.Time           `json:"createdAt"`
+	UpdatedAt                  time.Time           `json:"updatedAt"`
 	Description                *string             `json:"description"`
 	Icon                       *string             `json:"icon"`
 	SystemPrompt               *string             `json:"systemPrompt"`
@@ -37,12 +54,7 @@ type agentAPIResponse struct {
 		Id   string `json:"id"`
 		Name string `json:"name"`
 	} `json:"teams"`
-	Labels []struct {
-		Key     string              `json:"key"`
-		KeyId   *openapi_types.UUID `json:"keyId,omitempty"`
-		Value   string              `json:"value"`
-		ValueId *openapi_types.UUID `json:"valueId,omitempty"`
-	} `json:"labels"`
+	Labels           []agentAPILabel `json:"labels"`
 	SuggestedPrompts []struct {
 		Prompt       string `json:"prompt"`
 		SummaryTitle string `json:"summaryTitle"`
@@ -50,6 +62,139 @@ type agentAPIResponse struct {
 	BuiltInAgentConfig *json.RawMessage `json:"builtInAgentConfig"`
 }
 
+// agentAPILabel is one entry of the agent family's `labels` array.
+type agentAPILabel struct {
+	Key     string              `json:"key"`
+	KeyId   *openapi_types.UUID `json:"keyId,omitempty"`
+	Value   string              `json:"value"`
+	ValueId *openapi_types.UUID `json:"valueId,omitempty"`
+}
+
+// agentScopeValidators require `teams` on team-scoped members of the agent
+// family: the backend rejects a team-scoped agent without a team. `teams`
+// on other scopes is left to the backend, as it always has been.
+func agentScopeValidators() []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		requiredWhen("teams", whenOneOf("scope", "team")),
+	}
+}
+
+// agentLLMValidators apply to the types that call an LLM (agents and LLM
+// proxies): a pinned `llm_model` must name the `llm_api_key_id` to call it
+// with.
+func agentLLMValidators() []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		requiredWhen("llm_api_key_id", whenSet("llm_model")),
+	}
+}
+
+// agentIncomingEmailValidators apply to agents, the only type that can be
+// invoked by email.
+func agentIncomingEmailValidators() []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		onlyWhen("incoming_email_allowed_domain", whenTrue("incoming_email_enabled")),
+		requiredWhen("incoming_email_allowed_domain", whenOneOf("incoming_email_security_mode", "internal")),
+	}
+}
+
+// agentNameImport resolves `name:<name>` import IDs among the agents of one
+// agentType. Names are unique per organization only by convention, so the
+// shared import helper reports duplicates rather than picking one.
+func agentNameImport(apiClient *client.ClientWithResponses, agentType client.GetAllAgentsParamsAgentType, noun string) naturalKeyImport {
+	return naturalKeyImport{
+		Prefix: "name",
+		Noun:   noun,
+		Lookup: func(ctx context.Context, name string) ([]string, error) {
+			apiResp, err := apiClient.GetAllAgentsWithResponse(ctx, &client.GetAllAgentsParams{AgentType: &agentType})
+			if err != nil {
+				return nil, err
+			}
+			if apiResp.JSON200 == nil {
+				return nil, fmt.Errorf("GetAllAgents: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+			}
+			var ids []string
+			for _, a := range *apiResp.JSON200 {
+				if a.Name == name {
+					ids = append(ids, a.Id.String())
+				}
+			}
+			return ids, nil
+		},
+	}
+}
+
+// AgentListResourceModel is the `list` block shared by the agent, LLM proxy
+// and MCP gateway list resources. agentType is implied by the resource type.
+type AgentListResourceModel struct {
+	Name   types.String      `tfsdk:"name"`
+	Scope  types.String      `tfsdk:"scope"`
+	Labels map[string]string `tfsdk:"labels"`
+}
+
+func agentListSchema(plural string) listschema.Schema {
+	return listschema.Schema{
+		MarkdownDescription: fmt.Sprintf("Lists %s visible to the provider's API key.", plural),
+		Attributes: map[string]listschema.Attribute{
+			"name": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list objects whose name contains this string.",
+			},
+			"scope": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list objects with this scope: `personal`, `team` or `org`. Built-in agents are managed with `archestra_builtin_agent` and never listed here.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("personal", "team", "org"),
+				},
+			},
+			"labels": listschema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only list objects carrying every one of these labels. Keys and values must not contain `:` or `;`.",
+				Validators:          []validator.Map{labelFilterValidator()},
+			},
+		},
+	}
+}
+
+// listAgents pages through GetAgents for one agentType with the list
+// block's filters applied server-side.
+func listAgents(ctx context.Context, apiClient *client.ClientWithResponses, agentType client.GetAgentsParamsAgentType, cfg AgentListResourceModel) ([]listedObject, error) {
+	params := client.GetAgentsParams{AgentType: &agentType, Name: optionalFilter(cfg.Name)}
+	if scope := optionalFilter(cfg.Scope); scope != nil {
+		s := client.GetAgentsParamsScope(*scope)
+		params.Scope = &s
+	}
+	if len(cfg.Labels) > 0 {
+		labels := labelFilter(cfg.Labels)
+		params.Labels = &labels
+	}
+
+	var objects []listedObject
+	limit, offset := 100, 0
+	params.Limit, params.Offset = &limit, &offset
+	for {
+		apiResp, err := apiClient.GetAgentsWithResponse(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+		if apiResp.JSON200 == nil {
+			return nil, fmt.Errorf("GetAgents: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+		}
+		for _, a := range apiResp.JSON200.Data {
+			// Built-in rows belong to archestra_builtin_agent; an import
+			// block for this resource type would adopt the wrong resource.
+			if a.BuiltIn != nil && *a.BuiltIn {
+				continue
+			}
+			objects = append(objects, listedObject{ID: a.Id.String(), DisplayName: a.Name})
+		}
+		if !apiResp.JSON200.Pagination.HasNext {
+			return objects, nil
+		}
+		offset += limit
+	}
+}
+
 // parseAgentResponse decodes a raw API response body into the shared agent
 // shape. Returns nil and a diagnostic on failure.
 func parseAgentResponse(body []byte, diags *diag.Diagnostics) *agentAPIResponse {
@@ -61,6 +206,304 @@ func parseAgentResponse(body []byte, diags *diag.Diagnostics) *agentAPIResponse
 	return &out
 }
 
+// agentTypeResources maps each agentType to the resource that manages it.
+// The four share one table, so an ID alone does not say which resource it
+// belongs to.
+var agentTypeResources = map[string]string{
+	"agent":       "archestra_agent",
+	"llm_proxy":   "archestra_llm_proxy",
+	"mcp_gateway": "archestra_mcp_gateway",
+	"profile":     "archestra_profile",
+}
+
+// checkAgentType rejects an agent-table row whose agentType belongs to a
+// different resource, e.g. a profile ID imported into archestra_mcp_gateway.
+// Without it the wrong resource would adopt the row and its next apply would
+// rewrite the fields it does not model.
+func checkAgentType(got *agentAPIResponse, want string, diags *diag.Diagnostics) bool {
+	if got.AgentType == want {
+		return true
+	}
+	owner, ok := agentTypeResources[got.AgentType]
+	if !ok {
+		owner = "a different resource"
+	}
+	diags.AddError("Wrong Resource Type",
+		fmt.Sprintf("%s is a %q, not a %q; manage it with %s instead.", got.Id, got.AgentType, want, owner))
+	return false
+}
+
+// AgentCommonModel holds the attributes every agent-family resource models.
+// Each resource model embeds it, and flattenAgentCommon fills it from any
+// agent response.
+type AgentCommonModel struct {
+	ID                       types.String      `tfsdk:"id"`
+	Name                     types.String      `tfsdk:"name"`
+	Description              types.String      `tfsdk:"description"`
+	Icon                     types.String      `tfsdk:"icon"`
+	ConsiderContextUntrusted types.Bool        `tfsdk:"consider_context_untrusted"`
+	IsDefault                types.Bool        `tfsdk:"is_default"`
+	Scope                    types.String      `tfsdk:"scope"`
+	Teams                    types.List        `tfsdk:"teams"`
+	Labels                   []AgentLabelModel `tfsdk:"labels"`
+	EffectiveLabels          types.Map         `tfsdk:"effective_labels"`
+
+	AgentAuditModel
+}
+
+// flattenAgentCommon copies the fields every agent type shares. Labels the
+// provider applied as defaults stay out of `labels`; see ownAgentLabels.
+func flattenAgentCommon(ctx context.Context, data *AgentCommonModel, got *agentAPIResponse, defaultLabels map[string]string, diags *diag.Diagnostics) {
+	data.AgentAuditModel = flattenAgentAudit(got)
+
+	data.ID = types.StringValue(got.Id.String())
+	data.Name = types.StringValue(got.Name)
+	optionalStringFromAPI(&data.Description, got.Description)
+	optionalStringFromAPI(&data.Icon, got.Icon)
+
+	data.ConsiderContextUntrusted = types.BoolValue(got.ConsiderContextUntrusted)
+	data.IsDefault = types.BoolValue(got.IsDefault)
+	data.Scope = types.StringValue(got.Scope)
+	data.Teams = teamsListFromAPI(ctx, data.Teams, got.Teams, diags)
+
+	data.Labels = flattenAgentLabels(data.Labels, ownAgentLabels(data.Labels, got.Labels, defaultLabels))
+	data.EffectiveLabels = agentEffectiveLabels(ctx, got.Labels, diags)
+}
+
+// passthroughHeadersFromAPI updates the `passthrough_headers` list of the
+// types that front an endpoint (LLM proxies, MCP gateways, profiles).
+func passthroughHeadersFromAPI(ctx context.Context, target *types.List, headers *[]string, diags *diag.Diagnostics) {
+	if headers != nil {
+		list, d := types.ListValueFrom(ctx, types.StringType, *headers)
+		diags.Append(d...)
+		*target = list
+	} else if !target.IsNull() {
+		*target = types.ListNull(types.StringType)
+	}
+}
+
+// agentKind is what sets one agent-family resource apart from the others.
+// All four live in the `/api/agents` table, so their CRUD differs only in
+// the agentType stamped on create and checked on read, the attributes
+// merge-patched, and how a response is flattened. M is the resource model.
+type agentKind[M any] struct {
+	agentType string
+	noun      string
+	attrSpec  []AttrSpec
+	// flatten copies a decoded response onto data after every successful
+	// create, read and update. body is the raw response, for the fields
+	// agentAPIResponse keeps as raw JSON.
+	flatten func(ctx context.Context, data *M, got *agentAPIResponse, body []byte, diags *diag.Diagnostics)
+	// deleteForbidden, if set, is reported instead of the generic error
+	// when the backend refuses a delete with 403.
+	deleteForbidden diag.Diagnostic
+}
+
+func (k agentKind[M]) typeName() string { return agentTypeResources[k.agentType] }
+
+// modifyPlan runs the plan-time checks the agent types share. None has an
+// RBAC resource of its own; the backend gates them all as agents.
+func (k agentKind[M]) modifyPlan(ctx context.Context, providerData *ArchestraProviderData, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, providerData, permissionRule{Resource: "agent"}, req, resp)
+	planOrganization(ctx, providerData, req, resp)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var labels types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planEffectiveLabels(ctx, providerData, labels, resp)
+}
+
+func (k agentKind[M]) create(ctx context.Context, apiClient *client.ClientWithResponses, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Run merge-patch with prior=null Object: every non-null plan attribute
+	// emits, which is exactly Create semantics.
+	priorNull := tftypes.NewValue(req.Plan.Schema.Type().TerraformType(ctx), nil)
+	patch := MergePatch(ctx, req.Plan.Raw, priorNull, k.attrSpec, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// agent_type isn't surfaced as a TF attribute (each resource is
+	// type-specific by construction); set it on the wire so the backend
+	// stores the right discriminator.
+	patch["agentType"] = k.agentType
+	LogPatch(ctx, k.typeName()+" Create", patch, k.attrSpec)
+
+	body, err := json.Marshal(patch)
+	if err != nil {
+		resp.Diagnostics.AddError("Marshal Error", fmt.Sprintf("unable to marshal merge patch: %s", err))
+		return
+	}
+
+	apiResp, err := apiClient.CreateAgentWithBodyWithResponse(ctx, "application/json", bytes.NewReader(body))
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create %s: %s", k.noun, err))
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected API Response",
+			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
+		)
+		return
+	}
+
+	var data M
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	k.flattenBody(ctx, &data, apiResp.Body, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func (k agentKind[M]) read(ctx context.Context, providerData *ArchestraProviderData, apiClient *client.ClientWithResponses, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data M
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkOrganization(ctx, providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
+	id, ok := k.stateID(ctx, req.State, &resp.Diagnostics)
+	if !ok {
+		if !resp.Diagnostics.HasError() {
+			resp.State.RemoveResource(ctx)
+		}
+		return
+	}
+
+	apiResp, err := apiClient.GetAgentWithResponse(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read %s, got error: %s", k.noun, err))
+		return
+	}
+	if apiResp.JSON404 != nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected API Response",
+			fmt.Sprintf("Expected 200 OK, got status %d", apiResp.StatusCode()),
+		)
+		return
+	}
+
+	got := parseAgentResponse(apiResp.Body, &resp.Diagnostics)
+	if got == nil || !checkAgentType(got, k.agentType, &resp.Diagnostics) {
+		return
+	}
+	k.flatten(ctx, &data, got, apiResp.Body, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func (k agentKind[M]) update(ctx context.Context, apiClient *client.ClientWithResponses, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	id, ok := k.stateID(ctx, req.State, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	patch := MergePatch(ctx, req.Plan.Raw, req.State.Raw, k.attrSpec, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	LogPatch(ctx, k.typeName()+" Update", patch, k.attrSpec)
+
+	body, err := json.Marshal(patch)
+	if err != nil {
+		resp.Diagnostics.AddError("Marshal Error", fmt.Sprintf("unable to marshal merge patch: %s", err))
+		return
+	}
+
+	apiResp, err := apiClient.UpdateAgentWithBodyWithResponse(ctx, id, "application/json", bytes.NewReader(body))
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update %s: %s", k.noun, err))
+		return
+	}
+	if IsNotFound(apiResp) {
+		resp.Diagnostics.AddError(
+			"Resource Deleted Outside Terraform",
+			"The resource was deleted on the backend between refresh and apply. "+
+				"Re-run `terraform apply` — the next refresh drops it from state and the plan recreates it.",
+		)
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected API Response",
+			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
+		)
+		return
+	}
+
+	var data M
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	k.flattenBody(ctx, &data, apiResp.Body, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func (k agentKind[M]) delete(ctx context.Context, apiClient *client.ClientWithResponses, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	id, ok := k.stateID(ctx, req.State, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	apiResp, err := apiClient.DeleteAgentWithResponse(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete %s, got error: %s", k.noun, err))
+		return
+	}
+	if apiResp.StatusCode() == 403 && k.deleteForbidden != nil {
+		resp.Diagnostics.Append(k.deleteForbidden)
+		return
+	}
+	if apiResp.JSON200 == nil && apiResp.JSON404 == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected API Response",
+			fmt.Sprintf("Expected 200 OK or 404 Not Found, got status %d", apiResp.StatusCode()),
+		)
+	}
+}
+
+// stateID parses the `id` held in state. It reports false without a
+// diagnostic when state has no ID yet.
+func (k agentKind[M]) stateID(ctx context.Context, state tfsdk.State, diags *diag.Diagnostics) (uuid.UUID, bool) {
+	var raw types.String
+	diags.Append(state.GetAttribute(ctx, path.Root("id"), &raw)...)
+	if diags.HasError() || raw.ValueString() == "" {
+		return uuid.UUID{}, false
+	}
+	id, err := uuid.Parse(raw.ValueString())
+	if err != nil {
+		diags.AddError("Invalid ID", fmt.Sprintf("Unable to parse %s ID: %s", k.noun, err))
+		return uuid.UUID{}, false
+	}
+	return id, true
+}
+
+func (k agentKind[M]) flattenBody(ctx context.Context, data *M, body []byte, diags *diag.Diagnostics) {
+	if got := parseAgentResponse(body, diags); got != nil {
+		k.flatten(ctx, data, got, body, diags)
+	}
+}
+
 // AgentLabelModel describes a label key/value pair on an agent (any type).
 type AgentLabelModel struct {
 	Key   types.String `tfsdk:"key"`
@@ -88,12 +531,7 @@ type BuiltInAgentConfigModel struct {
 // a nil prior (HCL omits the block) collapses to a typed-null set, while a
 // non-nil empty prior (HCL writes `labels = []`) preserves the explicit
 // empty so refresh doesn't churn null↔[] forever.
-func flattenAgentLabels(prior []AgentLabelModel, apiLabels []struct {
-	Key     string              `json:"key"`
-	KeyId   *openapi_types.UUID `json:"keyId,omitempty"`
-	Value   string              `json:"value"`
-	ValueId *openapi_types.UUID `json:"valueId,omitempty"`
-}) []AgentLabelModel {
+func flattenAgentLabels(prior []AgentLabelModel, apiLabels []agentAPILabel) []AgentLabelModel {
 	if len(apiLabels) == 0 {
 		if prior != nil {
 			return []AgentLabelModel{}
@@ -1,18 +1,15 @@
 package provider
 
 import (
+	"encoding/json"
 	"testing"
 
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-type apiLabel = struct {
-	Key     string              `json:"key"`
-	KeyId   *openapi_types.UUID `json:"keyId,omitempty"`
-	Value   string              `json:"value"`
-	ValueId *openapi_types.UUID `json:"valueId,omitempty"`
-}
+type apiLabel = agentAPILabel
 
 func TestFlattenAgentLabels(t *testing.T) {
 	t.Parallel()
@@ -81,3 +78,26 @@ func TestFlattenAgentLabels(t *testing.T) {
 		})
 	}
 }
+
+// TestListAgentsSkipsBuiltIn checks that the agent list resources leave
+// built-in agents to archestra_builtin_agent.
+func TestListAgentsSkipsBuiltIn(t *testing.T) {
+	server := newFixtureBackend(t, map[string]json.RawMessage{
+		"/api/agents": json.RawMessage(`{"data":[` +
+			`{"id":"` + moveAgentID + `","name":"Policy Configuration","agentType":"agent","builtIn":true},` +
+			`{"id":"11111111-1111-4111-8111-111111111111","name":"support","agentType":"agent","builtIn":false}` +
+			`],"pagination":{"hasNext":false}}`),
+	})
+	apiClient, err := client.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objects, err := listAgents(t.Context(), apiClient, client.GetAgentsParamsAgentTypeAgent, AgentListResourceModel{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 1 || objects[0].DisplayName != "support" {
+		t.Errorf("listed %+v, want only support", objects)
+	}
+}
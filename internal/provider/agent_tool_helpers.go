@@ -12,6 +12,7 @@ var agentToolAttrSpec = []AttrSpec{
 	{TFName: "tool_id", Kind: Synthetic},
 	{TFName: "mcp_server_id", JSONName: "mcpServerId", Kind: Scalar},
 	{TFName: "credential_resolution_mode", JSONName: "credentialResolutionMode", Kind: Scalar},
+	{TFName: "timeouts", Kind: Synthetic},
 }
 
 func (r *AgentToolResource) AttrSpecs() []AttrSpec { return agentToolAttrSpec }
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Audit metadata (`created_at`, `updated_at`, and on the agent family
+// `author_id`, `author_name` and `slug`) is Computed-only: it never appears
+// in a merge patch and never causes a diff of its own. Values that cannot
+// change keep their state through UseStateForUnknown; `updated_at` is left
+// unknown on every update, since every update moves it, and `slug` only
+// when the name it is derived from changes.
+
+func createdAtSchemaAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Creation time (RFC 3339, UTC).",
+		PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+}
+
+func updatedAtSchemaAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed:            true,
+		MarkdownDescription: "Time of the last change (RFC 3339, UTC).",
+	}
+}
+
+// agentAuditSchemaAttributes returns the audit attributes shared by the agent
+// family, keyed by attribute name, for merging into the resource schema.
+func agentAuditSchemaAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"author_id": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "ID of the user who created the object. Null for objects created by the system.",
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		},
+		"author_name": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Name of the user who created the object.",
+			PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		},
+		"slug": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "URL slug generated from the name, e.g. for building chat URLs.",
+			PlanModifiers:       []planmodifier.String{UseStateUnlessChangedString(path.Root("name"))},
+		},
+		"created_at": createdAtSchemaAttribute(),
+		"updated_at": updatedAtSchemaAttribute(),
+	}
+}
+
+// withAttributes adds extra to attrs and returns attrs, so shared attribute
+// sets can be spliced into a schema literal.
+func withAttributes(attrs map[string]schema.Attribute, extra map[string]schema.Attribute) map[string]schema.Attribute {
+	for name, a := range extra {
+		attrs[name] = a
+	}
+	return attrs
+}
+
+// AgentAuditModel carries the agent family's audit attributes; embed it in
+// the resource model.
+type AgentAuditModel struct {
+	AuthorID   types.String `tfsdk:"author_id"`
+	AuthorName types.String `tfsdk:"author_name"`
+	Slug       types.String `tfsdk:"slug"`
+	CreatedAt  types.String `tfsdk:"created_at"`
+	UpdatedAt  types.String `tfsdk:"updated_at"`
+}
+
+// flattenAgentAudit copies the audit fields of an agent response.
+func flattenAgentAudit(resp *agentAPIResponse) AgentAuditModel {
+	return AgentAuditModel{
+		AuthorID:   types.StringPointerValue(resp.AuthorId),
+		AuthorName: types.StringPointerValue(resp.AuthorName),
+		Slug:       types.StringPointerValue(resp.Slug),
+		CreatedAt:  timestampValue(resp.CreatedAt),
+		UpdatedAt:  timestampValue(resp.UpdatedAt),
+	}
+}
+
+// timestampValue renders an API timestamp in one fixed format, so state does
+// not depend on how a given endpoint serialized it.
+func timestampValue(t time.Time) types.String {
+	if t.IsZero() {
+		return types.StringNull()
+	}
+	return types.StringValue(t.UTC().Format(time.RFC3339))
+}
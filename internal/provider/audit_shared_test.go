@@ -0,0 +1,22 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimestampValue(t *testing.T) {
+	t.Parallel()
+
+	if got := timestampValue(time.Time{}); !got.IsNull() {
+		t.Errorf("zero time: got %s, want null", got)
+	}
+	// An offset timestamp and its UTC equivalent must render the same, or a
+	// refresh against an endpoint that serializes differently shows drift.
+	offset := time.Date(2026, 9, 2, 10, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	if got, want := timestampValue(offset), types.StringValue("2026-09-02T08:30:00Z"); !got.Equal(want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -1,11 +1,14 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -46,10 +49,10 @@ var catalogItemAttrSpec = []AttrSpec{
 	{TFName: "deployment_spec_yaml", JSONName: "deploymentSpecYaml", Kind: Scalar},
 	{TFName: "scope", JSONName: "scope", Kind: Scalar},
 	{TFName: "teams", JSONName: "teams", Kind: List},
-	{TFName: "labels", JSONName: "labels", Kind: List, Children: []AttrSpec{
-		{TFName: "key", JSONName: "key", Kind: Scalar},
-		{TFName: "value", JSONName: "value", Kind: Scalar},
-	}},
+	// `labels` merges with the provider's default_labels; effective_labels
+	// carries the wire field (see labels_shared.go).
+	{TFName: "labels", JSONName: "labels", Kind: Synthetic},
+	{TFName: "effective_labels", JSONName: "labels", Kind: Map, Encoder: encodeEffectiveLabels},
 	{TFName: "auth_fields", JSONName: "authFields", Kind: List, Children: []AttrSpec{
 		{TFName: "name", JSONName: "name", Kind: Scalar},
 		{TFName: "label", JSONName: "label", Kind: Scalar},
@@ -114,6 +117,8 @@ var catalogItemAttrSpec = []AttrSpec{
 			{TFName: "server", JSONName: "server", Kind: Scalar},
 			{TFName: "username", JSONName: "username", Kind: Scalar},
 			{TFName: "password", JSONName: "password", Kind: Scalar, Sensitive: true},
+			{TFName: "password_wo", JSONName: "password", Kind: Scalar, Sensitive: true, WriteOnlyVersion: "password_wo_version"},
+			{TFName: "password_wo_version", Kind: Synthetic},
 			{TFName: "email", JSONName: "email", Kind: Scalar},
 		}},
 	}},
@@ -132,7 +137,14 @@ var catalogItemAttrSpec = []AttrSpec{
 		{TFName: "prompt_on_installation", JSONName: "promptOnInstallation", Kind: Scalar},
 	}},
 
-	{TFName: "remote_config", Kind: Synthetic},
+	// Children only declare the write-only OAuth secret for injectWriteOnly;
+	// finalizeRemoteConfigInPatch builds the wire fields.
+	{TFName: "remote_config", Kind: Synthetic, Children: []AttrSpec{
+		{TFName: "oauth_config", Kind: Synthetic, Children: []AttrSpec{
+			{TFName: "client_secret_wo", JSONName: "client_secret", Kind: Scalar, Sensitive: true, WriteOnlyVersion: "client_secret_wo_version"},
+		}},
+	}},
+	{TFName: "timeouts", Kind: Synthetic},
 }
 
 // stringFromJSONScalar reports whether the JSON bytes are a quoted string and
@@ -435,6 +447,7 @@ func encodeOAuthConfig(oc, url tftypes.Value, serverName string) (map[string]any
 	}{
 		{"client_id", "client_id"},
 		{"client_secret", "client_secret"},
+		{"client_secret_wo", "client_secret"},
 		{"redirect_uris", "redirect_uris"},
 		{"scopes", "scopes"},
 		{"default_scopes", "default_scopes"},
@@ -485,3 +498,54 @@ func extractObjectField(v tftypes.Value, name string) (tftypes.Value, bool) {
 	sub, ok := fields[name]
 	return sub, ok
 }
+
+// Schema version 1 is the set-of-objects `local_config.environment`. Version
+// 0 states may still hold the earlier `environment` map with its sibling
+// `mounted_env_keys` set, which had no way to express a variable type, so
+// every entry upgrades as plain_text.
+func (r *MCPServerRegistryResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(upgradeCatalogItemEnvironmentV0)
+}
+
+func upgradeCatalogItemEnvironmentV0(state map[string]any) error {
+	localConfig, ok := state["local_config"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	mountedKeys, _ := localConfig["mounted_env_keys"].([]any)
+	delete(localConfig, "mounted_env_keys")
+	env, ok := localConfig["environment"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	mounted := make(map[string]bool, len(mountedKeys))
+	for _, k := range mountedKeys {
+		key, ok := k.(string)
+		if !ok {
+			return fmt.Errorf("local_config.mounted_env_keys: unexpected element %v", k)
+		}
+		mounted[key] = true
+	}
+
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	entries := make([]any, 0, len(keys))
+	for _, k := range keys {
+		entry := map[string]any{
+			"key":                    k,
+			"type":                   "plain_text",
+			"value":                  env[k],
+			"prompt_on_installation": false,
+		}
+		if mounted[k] {
+			entry["mounted"] = true
+		}
+		entries = append(entries, entry)
+	}
+	localConfig["environment"] = entries
+	return nil
+}
@@ -0,0 +1,223 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Cross-attribute rules ("teams is required when scope = team") are declared
+// as resource.ConfigValidators built from the primitives below, so a
+// resource's rules read as a list rather than as a hand-written
+// ValidateConfig. They run at validate time, before any API call.
+//
+// Unknown values never fail a rule: an unknown condition attribute means the
+// rule cannot be decided yet, and an unknown target is treated as set.
+// Terraform validates again once the values are known.
+
+// configCondition is a predicate over one root attribute. holds only sees
+// known values; a null value is passed through so conditions can decide
+// what unset means.
+type configCondition struct {
+	attr  string
+	desc  string
+	holds func(attr.Value) bool
+}
+
+// whenOneOf holds when the string attribute equals one of values.
+func whenOneOf(name string, values ...string) configCondition {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	desc := fmt.Sprintf("%s = %s", name, quoted[0])
+	if len(values) > 1 {
+		desc = fmt.Sprintf("%s is one of %s", name, strings.Join(quoted, ", "))
+	}
+	return configCondition{attr: name, desc: desc, holds: func(v attr.Value) bool {
+		s, ok := v.(types.String)
+		if !ok || s.IsNull() {
+			return false
+		}
+		for _, want := range values {
+			if s.ValueString() == want {
+				return true
+			}
+		}
+		return false
+	}}
+}
+
+// whenTrue holds when the bool attribute is true; unset counts as false.
+func whenTrue(name string) configCondition {
+	return configCondition{attr: name, desc: name + " = true", holds: func(v attr.Value) bool {
+		b, ok := v.(types.Bool)
+		return ok && b.ValueBool()
+	}}
+}
+
+// whenSet holds when the attribute is set to a non-empty value.
+func whenSet(name string) configCondition {
+	return configCondition{attr: name, desc: name + " is set", holds: configValueSet}
+}
+
+// evaluate reports whether the condition holds, and false for decided when
+// the attribute is unknown.
+func (c configCondition) evaluate(ctx context.Context, config tfsdk.Config) (holds, decided bool) {
+	v, ok := configRootValue(ctx, config, c.attr)
+	if !ok || v.IsUnknown() {
+		return false, false
+	}
+	return c.holds(v), true
+}
+
+// configValueSet treats empty strings and empty collections like null: the
+// backend does.
+func configValueSet(v attr.Value) bool {
+	if v.IsNull() {
+		return false
+	}
+	switch v := v.(type) {
+	case types.String:
+		return v.ValueString() != ""
+	case types.List:
+		return len(v.Elements()) > 0
+	case types.Set:
+		return len(v.Elements()) > 0
+	case types.Map:
+		return len(v.Elements()) > 0
+	}
+	return true
+}
+
+// requiredWhen requires target to be set whenever the condition holds.
+func requiredWhen(target string, when configCondition) resource.ConfigValidator {
+	return requiredWhenValidator{target: target, when: when}
+}
+
+type requiredWhenValidator struct {
+	target string
+	when   configCondition
+}
+
+func (v requiredWhenValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("%s must be set when %s", v.target, v.when.desc)
+}
+
+func (v requiredWhenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v requiredWhenValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if holds, decided := v.when.evaluate(ctx, req.Config); !holds || !decided {
+		return
+	}
+	t, ok := configRootValue(ctx, req.Config, v.target)
+	if !ok || t.IsUnknown() || configValueSet(t) {
+		return
+	}
+	if t.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root(v.target), "Missing Required Attribute", v.Description(ctx))
+		return
+	}
+	resp.Diagnostics.AddAttributeError(path.Root(v.target), "Invalid Attribute Value",
+		fmt.Sprintf("%s must not be empty when %s", v.target, v.when.desc))
+}
+
+// onlyWhen rejects target unless the condition holds.
+func onlyWhen(target string, when configCondition) resource.ConfigValidator {
+	return onlyWhenValidator{target: target, when: when}
+}
+
+type onlyWhenValidator struct {
+	target string
+	when   configCondition
+}
+
+func (v onlyWhenValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("%s may only be set when %s", v.target, v.when.desc)
+}
+
+func (v onlyWhenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v onlyWhenValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	t, ok := configRootValue(ctx, req.Config, v.target)
+	if !ok || t.IsUnknown() || !configValueSet(t) {
+		return
+	}
+	if holds, decided := v.when.evaluate(ctx, req.Config); holds || !decided {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(path.Root(v.target), "Invalid Attribute Combination", v.Description(ctx))
+}
+
+// exactlyOneOfInElements requires every element of a list of objects to set
+// exactly one of attrs, for wire unions the schema flattens into optional
+// siblings.
+func exactlyOneOfInElements(list string, attrs ...string) resource.ConfigValidator {
+	return exactlyOneOfInElementsValidator{list: list, attrs: attrs}
+}
+
+type exactlyOneOfInElementsValidator struct {
+	list  string
+	attrs []string
+}
+
+func (v exactlyOneOfInElementsValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("each %s element must set exactly one of %s", v.list, strings.Join(v.attrs, ", "))
+}
+
+func (v exactlyOneOfInElementsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v exactlyOneOfInElementsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	lv, ok := configRootValue(ctx, req.Config, v.list)
+	if !ok || lv.IsNull() || lv.IsUnknown() {
+		return
+	}
+	list, ok := lv.(types.List)
+	if !ok {
+		return
+	}
+	for i, elem := range list.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		set, unknown := 0, false
+		for _, name := range v.attrs {
+			a, ok := obj.Attributes()[name]
+			switch {
+			case !ok:
+			case a.IsUnknown():
+				unknown = true
+			case !a.IsNull():
+				set++
+			}
+		}
+		if unknown || set == 1 {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(path.Root(v.list).AtListIndex(i), "Invalid Attribute Combination",
+			fmt.Sprintf("%s[%d] sets %d of %s; set exactly one.", v.list, i, set, strings.Join(v.attrs, ", ")))
+	}
+}
+
+// configRootValue reads a root attribute, reporting false when the schema
+// has no such attribute.
+func configRootValue(ctx context.Context, config tfsdk.Config, name string) (attr.Value, bool) {
+	var v attr.Value
+	if diags := config.GetAttribute(ctx, path.Root(name), &v); diags.HasError() || v == nil {
+		return nil, false
+	}
+	return v, true
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const validatorTeamID = "77777777-7777-4777-8777-777777777777"
+
+// TestConfigValidators runs resource configurations through
+// ValidateResourceConfig, the RPC behind `terraform validate` and the first
+// step of every plan, without a backend. Attributes missing from config are
+// null; "unknown" marks a value that is not known until apply.
+func TestConfigValidators(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		config       string
+		unknown      []string
+		wantErr      string
+	}{
+		// Agent family: scope and teams.
+		{name: "team scope with teams", resourceType: "archestra_agent", config: `{"name":"a","scope":"team","teams":["` + validatorTeamID + `"]}`},
+		{name: "team scope without teams", resourceType: "archestra_agent", config: `{"name":"a","scope":"team"}`, wantErr: `teams must be set when scope = "team"`},
+		{name: "team scope with empty teams", resourceType: "archestra_llm_proxy", config: `{"name":"p","scope":"team","teams":[]}`, wantErr: `teams must not be empty`},
+		{name: "team scope with unknown teams", resourceType: "archestra_mcp_gateway", config: `{"name":"g","scope":"team"}`, unknown: []string{"teams"}},
+		{name: "teams on default scope", resourceType: "archestra_mcp_gateway", config: `{"name":"g","teams":["` + validatorTeamID + `"]}`},
+		{name: "teams with unknown scope", resourceType: "archestra_llm_proxy", config: `{"name":"p","teams":["` + validatorTeamID + `"]}`, unknown: []string{"scope"}},
+		{name: "empty teams on org scope", resourceType: "archestra_agent", config: `{"name":"a","scope":"org","teams":[]}`},
+
+		// Agent family: LLM model and key.
+		{name: "model without key", resourceType: "archestra_agent", config: `{"name":"a","llm_model":"gpt-4o"}`, wantErr: "llm_api_key_id must be set when llm_model is set"},
+		{name: "proxy model without key", resourceType: "archestra_llm_proxy", config: `{"name":"p","llm_model":"gpt-4o"}`, wantErr: "llm_api_key_id must be set when llm_model is set"},
+		{name: "model with key", resourceType: "archestra_agent", config: `{"name":"a","llm_model":"gpt-4o","llm_api_key_id":"k"}`},
+		{name: "key without model", resourceType: "archestra_llm_proxy", config: `{"name":"p","llm_api_key_id":"k"}`},
+		{name: "model with unknown key", resourceType: "archestra_agent", config: `{"name":"a","llm_model":"gpt-4o"}`, unknown: []string{"llm_api_key_id"}},
+
+		// Agent: incoming email.
+		{name: "allowed domain without email", resourceType: "archestra_agent", config: `{"name":"a","incoming_email_allowed_domain":"acme.com"}`, wantErr: "incoming_email_allowed_domain may only be set when incoming_email_enabled = true"},
+		{name: "internal mode without domain", resourceType: "archestra_agent", config: `{"name":"a","incoming_email_enabled":true,"incoming_email_security_mode":"internal"}`, wantErr: `incoming_email_allowed_domain must be set when incoming_email_security_mode = "internal"`},
+		{name: "internal mode with domain", resourceType: "archestra_agent", config: `{"name":"a","incoming_email_enabled":true,"incoming_email_security_mode":"internal","incoming_email_allowed_domain":"acme.com"}`},
+
+		// Limits.
+		{name: "token cost with models", resourceType: "archestra_limit", config: `{"entity_id":"o","entity_type":"organization","limit_type":"token_cost","limit_value":1,"model":["gpt-4o"]}`},
+		{name: "token cost without models", resourceType: "archestra_limit", config: `{"entity_id":"o","entity_type":"organization","limit_type":"token_cost","limit_value":1}`, wantErr: `model must be set when limit_type = "token_cost"`},
+		{name: "token cost with server", resourceType: "archestra_limit", config: `{"entity_id":"o","entity_type":"organization","limit_type":"token_cost","limit_value":1,"model":["gpt-4o"],"mcp_server_name":"fs"}`, wantErr: "mcp_server_name may only be set when"},
+		{name: "tool calls without tool", resourceType: "archestra_limit", config: `{"entity_id":"o","entity_type":"organization","limit_type":"tool_calls","limit_value":1,"mcp_server_name":"fs"}`, wantErr: `tool_name must be set when limit_type = "tool_calls"`},
+		{name: "server calls with model", resourceType: "archestra_limit", config: `{"entity_id":"o","entity_type":"organization","limit_type":"mcp_server_calls","limit_value":1,"mcp_server_name":"fs","model":["gpt-4o"]}`, wantErr: `model may only be set when limit_type = "token_cost"`},
+		{name: "server calls with unknown server", resourceType: "archestra_limit", config: `{"entity_id":"o","entity_type":"organization","limit_type":"mcp_server_calls","limit_value":1}`, unknown: []string{"mcp_server_name"}},
+
+		// Optimization rules.
+		{name: "one condition each", resourceType: "archestra_optimization_rule", config: `{"entity_id":"o","entity_type":"organization","llm_provider":"openai","target_model":"gpt-4o-mini","conditions":[{"max_length":1000,"has_tools":null},{"max_length":null,"has_tools":false}]}`},
+		{name: "condition with both", resourceType: "archestra_optimization_rule", config: `{"entity_id":"o","entity_type":"organization","llm_provider":"openai","target_model":"gpt-4o-mini","conditions":[{"max_length":1000,"has_tools":null},{"max_length":1000,"has_tools":true}]}`, wantErr: "conditions[1] sets 2 of max_length, has_tools"},
+		{name: "condition with neither", resourceType: "archestra_optimization_rule", config: `{"entity_id":"o","entity_type":"organization","llm_provider":"openai","target_model":"gpt-4o-mini","conditions":[{"max_length":null,"has_tools":null}]}`, wantErr: "conditions[0] sets 0 of"},
+	}
+
+	ps, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaResp, err := ps.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			configType := schemaResp.ResourceSchemas[tc.resourceType].ValueType().(tftypes.Object)
+			config := validatorConfig(t, configType, tc.config, tc.unknown)
+
+			resp, err := ps.ValidateResourceConfig(t.Context(), &tfprotov6.ValidateResourceConfigRequest{
+				TypeName: tc.resourceType,
+				Config:   &config,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantErr == "" {
+				failOnDiagnostics(t, "ValidateResourceConfig", resp.Diagnostics)
+				return
+			}
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError && strings.Contains(d.Summary+": "+d.Detail, tc.wantErr) {
+					return
+				}
+			}
+			t.Errorf("expected an error containing %q, got %v", tc.wantErr, resp.Diagnostics)
+		})
+	}
+}
+
+// validatorConfig builds a config value from the attributes in raw, with
+// every other root attribute null and those named in unknown unknown.
+func validatorConfig(t *testing.T, configType tftypes.Object, raw string, unknown []string) tfprotov6.DynamicValue {
+	t.Helper()
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(raw), &attrs); err != nil {
+		t.Fatal(err)
+	}
+	for name := range configType.AttributeTypes {
+		if _, ok := attrs[name]; !ok {
+			attrs[name] = json.RawMessage("null")
+		}
+	}
+	full, err := json.Marshal(attrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := (&tfprotov6.RawState{JSON: full}).Unmarshal(configType)
+	if err != nil {
+		t.Fatalf("config does not match the schema: %v", err)
+	}
+
+	if len(unknown) > 0 {
+		var fields map[string]tftypes.Value
+		if err := value.As(&fields); err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range unknown {
+			fields[name] = tftypes.NewValue(configType.AttributeTypes[name], tftypes.UnknownValue)
+		}
+		value = tftypes.NewValue(configType, fields)
+	}
+
+	config, err := tfprotov6.NewDynamicValue(configType, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &AgentEmailAddressDataSource{}
+
+func NewAgentEmailAddressDataSource() datasource.DataSource {
+	return &AgentEmailAddressDataSource{}
+}
+
+type AgentEmailAddressDataSource struct {
+	client *client.ClientWithResponses
+}
+
+type AgentEmailAddressDataSourceModel struct {
+	AgentID         types.String `tfsdk:"agent_id"`
+	EmailAddress    types.String `tfsdk:"email_address"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+	SecurityMode    types.String `tfsdk:"security_mode"`
+	AllowedDomain   types.String `tfsdk:"allowed_domain"`
+	ProviderEnabled types.Bool   `tfsdk:"provider_enabled"`
+}
+
+func (d *AgentEmailAddressDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_email_address"
+}
+
+func (d *AgentEmailAddressDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Inbound email address of an agent, for wiring MX records and forwarding rules to it. " +
+			"The same value is exposed as `archestra_agent.incoming_email_address`; use this data source for agents managed elsewhere.",
+
+		Attributes: map[string]schema.Attribute{
+			"agent_id": schema.StringAttribute{
+				MarkdownDescription: "Agent identifier",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegexp, "agent_id must be a UUID"),
+				},
+			},
+			"email_address": schema.StringAttribute{
+				MarkdownDescription: "Address that invokes the agent. Null when the organization has no incoming-email provider configured.",
+				Computed:            true,
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the agent accepts incoming email",
+				Computed:            true,
+			},
+			"security_mode": schema.StringAttribute{
+				MarkdownDescription: "Who may email the agent: `private`, `internal` or `public`",
+				Computed:            true,
+			},
+			"allowed_domain": schema.StringAttribute{
+				MarkdownDescription: "Sender domain accepted in `internal` mode",
+				Computed:            true,
+			},
+			"provider_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the organization has an incoming-email provider configured",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AgentEmailAddressDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *AgentEmailAddressDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AgentEmailAddressDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	agentID, err := uuid.Parse(data.AgentID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("agent_id"), "Invalid agent_id", fmt.Sprintf("Could not parse agent_id as UUID: %s", err))
+		return
+	}
+	apiResp, err := d.client.GetAgentEmailAddressWithResponse(ctx, agentID)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read agent email address, got error: %s", err))
+		return
+	}
+	if apiResp.JSON404 != nil {
+		resp.Diagnostics.AddError("Not Found", fmt.Sprintf("Agent with ID %s not found", data.AgentID.ValueString()))
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected API Response",
+			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
+		)
+		return
+	}
+
+	address := apiResp.JSON200
+	data.EmailAddress = types.StringPointerValue(address.EmailAddress)
+	data.Enabled = types.BoolValue(address.AgentIncomingEmailEnabled)
+	data.SecurityMode = types.StringValue(string(address.AgentSecurityMode))
+	data.AllowedDomain = types.StringPointerValue(address.AgentAllowedDomain)
+	data.ProviderEnabled = types.BoolValue(address.ProviderEnabled)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
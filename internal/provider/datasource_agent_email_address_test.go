@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAgentEmailAddressDataSource(t *testing.T) {
+	server := newFixtureBackend(t, map[string]json.RawMessage{
+		"/api/agents/" + moveAgentID + "/email-address": json.RawMessage(`{"emailAddress":"agent-123@in.acme.com","agentIncomingEmailEnabled":true,` +
+			`"agentSecurityMode":"internal","agentAllowedDomain":"acme.com","providerEnabled":true}`),
+	})
+	ps := newConfiguredProviderServer(t, server.URL)
+
+	schemaResp, err := ps.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.DataSourceSchemas["archestra_agent_email_address"].ValueType().(tftypes.Object)
+	config := validatorConfig(t, objType, `{"agent_id":"`+moveAgentID+`"}`, nil)
+
+	resp, err := ps.ReadDataSource(t.Context(), &tfprotov6.ReadDataSourceRequest{TypeName: "archestra_agent_email_address", Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ReadDataSource", resp.Diagnostics)
+
+	got, err := resp.State.Unmarshal(objType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := tftypes.NewValue(objType, map[string]tftypes.Value{
+		"agent_id":         tftypes.NewValue(tftypes.String, moveAgentID),
+		"email_address":    tftypes.NewValue(tftypes.String, "agent-123@in.acme.com"),
+		"enabled":          tftypes.NewValue(tftypes.Bool, true),
+		"security_mode":    tftypes.NewValue(tftypes.String, "internal"),
+		"allowed_domain":   tftypes.NewValue(tftypes.String, "acme.com"),
+		"provider_enabled": tftypes.NewValue(tftypes.Bool, true),
+	})
+	assertStateEqual(t, "data source state", got, want)
+}
+
+// TestAgentEmailAddressDataSourceInvalidID checks that Read reports a
+// malformed agent_id against the attribute. Config validation catches it
+// first under Terraform, but Read must not rely on that.
+func TestAgentEmailAddressDataSourceInvalidID(t *testing.T) {
+	ps := newConfiguredProviderServer(t, newFixtureBackend(t, nil).URL)
+
+	schemaResp, err := ps.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.DataSourceSchemas["archestra_agent_email_address"].ValueType().(tftypes.Object)
+	config := validatorConfig(t, objType, `{"agent_id":"not-a-uuid"}`, nil)
+
+	resp, err := ps.ReadDataSource(t.Context(), &tfprotov6.ReadDataSourceRequest{TypeName: "archestra_agent_email_address", Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Invalid agent_id" || resp.Diagnostics[0].Attribute == nil {
+		t.Errorf("expected one Invalid agent_id attribute error, got %v", resp.Diagnostics)
+	}
+}
@@ -62,16 +62,16 @@ func (d *AgentToolDataSource) Configure(_ context.Context, req datasource.Config
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = c
+	d.client = providerData.Client
 }
 
 func (d *AgentToolDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
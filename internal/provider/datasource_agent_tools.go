@@ -101,15 +101,15 @@ func (d *AgentToolsDataSource) Configure(_ context.Context, req datasource.Confi
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData),
 		)
 		return
 	}
-	d.client = c
+	d.client = providerData.Client
 }
 
 func (d *AgentToolsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &LimitsDataSource{}
+
+func NewLimitsDataSource() datasource.DataSource {
+	return &LimitsDataSource{}
+}
+
+type LimitsDataSource struct {
+	client *client.ClientWithResponses
+}
+
+type LimitsDataSourceModel struct {
+	EntityType types.String `tfsdk:"entity_type"`
+	EntityID   types.String `tfsdk:"entity_id"`
+	LimitType  types.String `tfsdk:"limit_type"`
+	Limits     types.List   `tfsdk:"limits"`
+}
+
+var limitModelUsageObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"model":      types.StringType,
+	"cost":       types.Float64Type,
+	"tokens_in":  types.Int64Type,
+	"tokens_out": types.Int64Type,
+}}
+
+var limitObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":                  types.StringType,
+	"entity_type":         types.StringType,
+	"entity_id":           types.StringType,
+	"limit_type":          types.StringType,
+	"limit_value":         types.Int64Type,
+	"model":               types.ListType{ElemType: types.StringType},
+	"tool_name":           types.StringType,
+	"mcp_server_name":     types.StringType,
+	"current_usage":       types.Float64Type,
+	"remaining":           types.Float64Type,
+	"utilization_percent": types.Float64Type,
+	"model_usage":         types.ListType{ElemType: limitModelUsageObjectType},
+	"last_cleanup":        types.StringType,
+}}
+
+func (d *LimitsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_limits"
+}
+
+func (d *LimitsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Usage limits with their live usage, for dashboards and `check` blocks that alarm before a team or agent runs out of budget.\n\n" +
+			"```hcl\n" +
+			"data \"archestra_limits\" \"engineering\" {\n" +
+			"  entity_type = \"team\"\n" +
+			"  entity_id   = archestra_team.engineering.id\n" +
+			"}\n\n" +
+			"check \"engineering_budget\" {\n" +
+			"  assert {\n" +
+			"    condition     = alltrue([for l in data.archestra_limits.engineering.limits : coalesce(l.utilization_percent, 0) < 80])\n" +
+			"    error_message = \"A limit on the engineering team is above 80% usage.\"\n" +
+			"  }\n" +
+			"}\n" +
+			"```\n\n" +
+			"The backend reports usage for `token_cost` limits only; the usage attributes are null on call limits.",
+
+		Attributes: map[string]schema.Attribute{
+			"entity_type": schema.StringAttribute{
+				MarkdownDescription: "Optional. Only return limits on this entity type: `organization`, `team` or `agent`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("organization", "team", "agent"),
+				},
+			},
+			"entity_id": schema.StringAttribute{
+				MarkdownDescription: "Optional. Only return limits on this entity.",
+				Optional:            true,
+			},
+			"limit_type": schema.StringAttribute{
+				MarkdownDescription: "Optional. Only return limits of this type: `token_cost`, `tool_calls` or `mcp_server_calls`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("token_cost", "tool_calls", "mcp_server_calls"),
+				},
+			},
+			"limits": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching limits, in backend order.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":                  schema.StringAttribute{Computed: true, MarkdownDescription: "Limit UUID."},
+						"entity_type":         schema.StringAttribute{Computed: true, MarkdownDescription: "`organization`, `team` or `agent`."},
+						"entity_id":           schema.StringAttribute{Computed: true, MarkdownDescription: "ID of the entity the limit applies to."},
+						"limit_type":          schema.StringAttribute{Computed: true, MarkdownDescription: "`token_cost`, `tool_calls` or `mcp_server_calls`."},
+						"limit_value":         schema.Int64Attribute{Computed: true, MarkdownDescription: "Limit threshold."},
+						"model":               schema.ListAttribute{Computed: true, ElementType: types.StringType, MarkdownDescription: "Models a `token_cost` limit applies to. Null otherwise."},
+						"tool_name":           schema.StringAttribute{Computed: true, MarkdownDescription: "Tool a `tool_calls` limit applies to. Null otherwise."},
+						"mcp_server_name":     schema.StringAttribute{Computed: true, MarkdownDescription: "MCP server a call limit applies to. Null for `token_cost` limits."},
+						"current_usage":       schema.Float64Attribute{Computed: true, MarkdownDescription: "Summed cost across `model_usage` since `last_cleanup`. Null for call limits."},
+						"remaining":           schema.Float64Attribute{Computed: true, MarkdownDescription: "`limit_value` minus `current_usage`, floored at 0. Null for call limits."},
+						"utilization_percent": schema.Float64Attribute{Computed: true, MarkdownDescription: "`current_usage` as a percentage of `limit_value`, rounded to two decimals; exceeds 100 once overrun. Null for call limits."},
+						"model_usage": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "Per-model breakdown of `current_usage`. Null for call limits.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"model":      schema.StringAttribute{Computed: true, MarkdownDescription: "Model name."},
+									"cost":       schema.Float64Attribute{Computed: true, MarkdownDescription: "Cost incurred on this model."},
+									"tokens_in":  schema.Int64Attribute{Computed: true, MarkdownDescription: "Input tokens consumed."},
+									"tokens_out": schema.Int64Attribute{Computed: true, MarkdownDescription: "Output tokens produced."},
+								},
+							},
+						},
+						"last_cleanup": schema.StringAttribute{Computed: true, MarkdownDescription: "When the cleanup scheduler last reset the usage (RFC 3339, UTC). Null until the first cleanup."},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *LimitsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData))
+		return
+	}
+	d.client = providerData.Client
+}
+
+func (d *LimitsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data LimitsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := client.GetLimitsParams{EntityId: optionalFilter(data.EntityID)}
+	if v := optionalFilter(data.EntityType); v != nil {
+		entityType := client.GetLimitsParamsEntityType(*v)
+		params.EntityType = &entityType
+	}
+	if v := optionalFilter(data.LimitType); v != nil {
+		limitType := client.GetLimitsParamsLimitType(*v)
+		params.LimitType = &limitType
+	}
+	apiResp, err := d.client.GetLimitsWithResponse(ctx, &params)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read limits: %s", err))
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected API Response",
+			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
+		)
+		return
+	}
+
+	limits := make([]attr.Value, 0, len(*apiResp.JSON200))
+	for _, l := range *apiResp.JSON200 {
+		model := types.ListNull(types.StringType)
+		if l.Model != nil {
+			v, diags := types.ListValueFrom(ctx, types.StringType, *l.Model)
+			resp.Diagnostics.Append(diags...)
+			model = v
+		}
+		modelUsage := types.ListNull(limitModelUsageObjectType)
+		if l.ModelUsage != nil {
+			usage := make([]attr.Value, 0, len(*l.ModelUsage))
+			for _, u := range *l.ModelUsage {
+				obj, diags := types.ObjectValue(limitModelUsageObjectType.AttrTypes, map[string]attr.Value{
+					"model":      types.StringValue(u.Model),
+					"cost":       types.Float64Value(widenFloat32(u.Cost)),
+					"tokens_in":  types.Int64Value(int64(u.TokensIn)),
+					"tokens_out": types.Int64Value(int64(u.TokensOut)),
+				})
+				resp.Diagnostics.Append(diags...)
+				usage = append(usage, obj)
+			}
+			v, diags := types.ListValue(limitModelUsageObjectType, usage)
+			resp.Diagnostics.Append(diags...)
+			modelUsage = v
+		}
+		lastCleanup := types.StringNull()
+		if l.LastCleanup != nil {
+			lastCleanup = timestampValue(*l.LastCleanup)
+		}
+		current, remaining, utilization := limitUsage(l.LimitValue, l.ModelUsage)
+
+		obj, diags := types.ObjectValue(limitObjectType.AttrTypes, map[string]attr.Value{
+			"id":                  types.StringValue(l.Id.String()),
+			"entity_type":         types.StringValue(string(l.EntityType)),
+			"entity_id":           types.StringValue(l.EntityId),
+			"limit_type":          types.StringValue(string(l.LimitType)),
+			"limit_value":         types.Int64Value(int64(l.LimitValue)),
+			"model":               model,
+			"tool_name":           types.StringPointerValue(l.ToolName),
+			"mcp_server_name":     types.StringPointerValue(l.McpServerName),
+			"current_usage":       current,
+			"remaining":           remaining,
+			"utilization_percent": utilization,
+			"model_usage":         modelUsage,
+			"last_cleanup":        lastCleanup,
+		})
+		resp.Diagnostics.Append(diags...)
+		limits = append(limits, obj)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listValue, diags := types.ListValue(limitObjectType, limits)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	data.Limits = listValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
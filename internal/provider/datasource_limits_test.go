@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testLimitID = "5f0c7c52-3d7e-4b8a-9a51-0d2b7c1e9f10"
+
+// limitsFixture serves one token_cost limit at 85% usage; GetLimit, like the
+// real endpoint, carries no modelUsage.
+func limitsFixture(t *testing.T) map[string]json.RawMessage {
+	t.Helper()
+	limit := `"id":"` + testLimitID + `","entityType":"team","entityId":"team-1","limitType":"token_cost","limitValue":200,` +
+		`"model":["gpt-4o","claude-sonnet-4-5"],"toolName":null,"mcpServerName":null,` +
+		`"lastCleanup":"2026-10-01T00:00:00Z","createdAt":"2026-09-01T00:00:00Z","updatedAt":"2026-09-01T00:00:00Z"`
+	return map[string]json.RawMessage{
+		"/api/limits/" + testLimitID: json.RawMessage(`{` + limit + `}`),
+		"/api/limits": json.RawMessage(`[{` + limit + `,"modelUsage":[` +
+			`{"model":"gpt-4o","cost":120.5,"tokensIn":1000,"tokensOut":500},` +
+			`{"model":"claude-sonnet-4-5","cost":49.5,"tokensIn":300,"tokensOut":100}]}]`),
+	}
+}
+
+func TestLimitsDataSource(t *testing.T) {
+	ps := newConfiguredProviderServer(t, newFixtureBackend(t, limitsFixture(t)).URL)
+
+	schemaResp, err := ps.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.DataSourceSchemas["archestra_limits"].ValueType().(tftypes.Object)
+	config := validatorConfig(t, objType, `{"entity_type":"team","entity_id":"team-1"}`, nil)
+
+	resp, err := ps.ReadDataSource(t.Context(), &tfprotov6.ReadDataSourceRequest{TypeName: "archestra_limits", Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ReadDataSource", resp.Diagnostics)
+
+	got, err := resp.State.Unmarshal(objType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limit := tftypes.NewAttributePath().WithAttributeName("limits").WithElementKeyInt(0)
+	assertAttrEqual(t, got, limit.WithAttributeName("id"), tftypes.NewValue(tftypes.String, testLimitID))
+	assertLimitUsage(t, got, limit)
+	assertAttrEqual(t, got, limit.WithAttributeName("model_usage").WithElementKeyInt(0).WithAttributeName("tokens_in"),
+		tftypes.NewValue(tftypes.Number, big.NewFloat(1000)))
+	assertAttrEqual(t, got, limit.WithAttributeName("model_usage").WithElementKeyInt(1).WithAttributeName("cost"),
+		tftypes.NewValue(tftypes.Number, big.NewFloat(49.5)))
+}
+
+// TestLimitResourceCreateUsageUnavailable creates a limit against a backend
+// whose usage listing fails: the create must still succeed, with a warning
+// and null usage.
+func TestLimitResourceCreateUsageUnavailable(t *testing.T) {
+	limit := limitsFixture(t)["/api/limits/"+testLimitID]
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && r.URL.Path == "/api/limits" {
+			_, _ = w.Write(limit)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":{"message":"boom","type":"api_internal_server_error"}}`))
+	}))
+	t.Cleanup(server.Close)
+	ps := newConfiguredProviderServer(t, server.URL)
+
+	schemaResp, err := ps.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.ResourceSchemas["archestra_limit"].ValueType().(tftypes.Object)
+	raw := `{"entity_type":"team","entity_id":"team-1","limit_type":"token_cost","limit_value":200,"model":["gpt-4o","claude-sonnet-4-5"]}`
+	config := validatorConfig(t, objType, raw, nil)
+	planned := validatorConfig(t, objType, raw, []string{"id", "organization_id", "created_at", "updated_at", "current_usage", "remaining", "utilization_percent", "last_cleanup"})
+	null, err := tfprotov6.NewDynamicValue(objType, tftypes.NewValue(objType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := ps.ApplyResourceChange(t.Context(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "archestra_limit",
+		PriorState:   &null,
+		PlannedState: &planned,
+		Config:       &config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ApplyResourceChange", resp.Diagnostics)
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Limit Usage Unavailable" {
+		t.Errorf("diagnostics = %+v, want one Limit Usage Unavailable warning", resp.Diagnostics)
+	}
+	got, err := resp.NewState.Unmarshal(objType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertAttrEqual(t, got, tftypes.NewAttributePath().WithAttributeName("id"), tftypes.NewValue(tftypes.String, testLimitID))
+	for _, name := range []string{"current_usage", "remaining", "utilization_percent"} {
+		assertAttrEqual(t, got, tftypes.NewAttributePath().WithAttributeName(name), tftypes.NewValue(tftypes.Number, nil))
+	}
+	assertAttrEqual(t, got, tftypes.NewAttributePath().WithAttributeName("last_cleanup"), tftypes.NewValue(tftypes.String, nil))
+}
+
+func TestLimitResourceReadsUsage(t *testing.T) {
+	ps := newConfiguredProviderServer(t, newFixtureBackend(t, limitsFixture(t)).URL)
+
+	schemaResp, err := ps.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stateType := schemaResp.ResourceSchemas["archestra_limit"].ValueType().(tftypes.Object)
+	state := validatorConfig(t, stateType, `{"id":"`+testLimitID+`"}`, nil)
+
+	resp, err := ps.ReadResource(t.Context(), &tfprotov6.ReadResourceRequest{TypeName: "archestra_limit", CurrentState: &state})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ReadResource", resp.Diagnostics)
+
+	got, err := resp.NewState.Unmarshal(stateType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertLimitUsage(t, got, tftypes.NewAttributePath())
+}
+
+// assertLimitUsage checks the usage attributes under at against
+// limitsFixture: 170 spent of 200.
+func assertLimitUsage(t *testing.T, v tftypes.Value, at *tftypes.AttributePath) {
+	t.Helper()
+	for name, want := range map[string]tftypes.Value{
+		"current_usage":       tftypes.NewValue(tftypes.Number, big.NewFloat(170)),
+		"remaining":           tftypes.NewValue(tftypes.Number, big.NewFloat(30)),
+		"utilization_percent": tftypes.NewValue(tftypes.Number, big.NewFloat(85)),
+		"last_cleanup":        tftypes.NewValue(tftypes.String, "2026-10-01T00:00:00Z"),
+	} {
+		assertAttrEqual(t, v, at.WithAttributeName(name), want)
+	}
+}
+
+func assertAttrEqual(t *testing.T, v tftypes.Value, at *tftypes.AttributePath, want tftypes.Value) {
+	t.Helper()
+	got, _, err := tftypes.WalkAttributePath(v, at)
+	if err != nil {
+		t.Fatalf("%s: %v", at, err)
+	}
+	if !got.(tftypes.Value).Equal(want) {
+		t.Errorf("%s: got %v, want %v", at, got, want)
+	}
+}
+
+func TestLimitUsage(t *testing.T) {
+	t.Parallel()
+
+	current, remaining, utilization := limitUsage(100, nil)
+	if !current.IsNull() || !remaining.IsNull() || !utilization.IsNull() {
+		t.Errorf("no usage reported: got %s, %s, %s, want nulls", current, remaining, utilization)
+	}
+
+	_, _, utilization = limitUsage(0, &limitModelUsage{{Model: "gpt-4o", Cost: 0.1}})
+	if !utilization.IsNull() {
+		t.Errorf("zero limit: utilization got %s, want null", utilization)
+	}
+
+	// Float32 costs widen through their decimal form, not their binary one.
+	current, _, _ = limitUsage(1, &limitModelUsage{{Model: "gpt-4o", Cost: 0.1}})
+	if !current.Equal(types.Float64Value(0.1)) {
+		t.Errorf("widened cost: got %s, want 0.1", current)
+	}
+
+	current, remaining, utilization = limitUsage(1, &limitModelUsage{{Model: "gpt-4o", Cost: 1.5}})
+	for name, c := range map[string]struct{ got, want types.Float64 }{
+		"current":     {current, types.Float64Value(1.5)},
+		"remaining":   {remaining, types.Float64Value(0)},
+		"utilization": {utilization, types.Float64Value(150)},
+	} {
+		if !c.got.Equal(c.want) {
+			t.Errorf("overrun %s: got %s, want %s", name, c.got, c.want)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+var _ datasource.DataSource = &ToolPolicyEvaluationDataSource{}
+
+func NewToolPolicyEvaluationDataSource() datasource.DataSource {
+	return &ToolPolicyEvaluationDataSource{}
+}
+
+type ToolPolicyEvaluationDataSource struct {
+	client *client.ClientWithResponses
+}
+
+type ToolPolicyEvaluationDataSourceModel struct {
+	ToolID         types.String                     `tfsdk:"tool_id"`
+	Arguments      jsontypes.Normalized             `tfsdk:"arguments"`
+	ContextTrusted types.Bool                       `tfsdk:"context_trusted"`
+	Policies       []CandidateInvocationPolicyModel `tfsdk:"policies"`
+	DefaultAction  types.String                     `tfsdk:"default_action"`
+
+	Action             types.String `tfsdk:"action"`
+	MatchedPolicyID    types.String `tfsdk:"matched_policy_id"`
+	MatchedPolicyIndex types.Int64  `tfsdk:"matched_policy_index"`
+	Reason             types.String `tfsdk:"reason"`
+	Blocked            types.Bool   `tfsdk:"blocked"`
+	RequiresApproval   types.Bool   `tfsdk:"requires_approval"`
+}
+
+// CandidateInvocationPolicyModel is one element of a `policies` input: an
+// invocation rule evaluated locally instead of the tool's backend rules.
+type CandidateInvocationPolicyModel struct {
+	ToolID     types.String           `tfsdk:"tool_id"`
+	Conditions []PolicyConditionModel `tfsdk:"conditions"`
+	Action     types.String           `tfsdk:"action"`
+	Reason     types.String           `tfsdk:"reason"`
+}
+
+// candidateInvocationPoliciesAttribute is the `policies` input of the data
+// sources that evaluate invocation rules locally. Its element shape matches
+// `archestra_tool_policy_set.invocation_policies`, so that attribute can be
+// passed straight in.
+func candidateInvocationPoliciesAttribute(description string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"tool_id": schema.StringAttribute{
+					MarkdownDescription: "Tool the rule applies to. Rules for other tools are ignored; null applies the rule to `tool_id`.",
+					Optional:            true,
+				},
+				"conditions": schema.ListNestedAttribute{
+					MarkdownDescription: "Conditions evaluated against the call's arguments. ALL must match for `action` to fire.",
+					Required:            true,
+					Validators: []validator.List{
+						listvalidator.SizeAtLeast(1),
+						policyConditionsValidator(),
+					},
+					NestedObject: schema.NestedAttributeObject{
+						Attributes: map[string]schema.Attribute{
+							"key": schema.StringAttribute{
+								MarkdownDescription: "Argument name to match.",
+								Required:            true,
+							},
+							"operator": schema.StringAttribute{
+								MarkdownDescription: "Comparison operator. One of `equal`, `notEqual`, `contains`, `notContains`, `startsWith`, `endsWith`, `regex`.",
+								Required:            true,
+								Validators: []validator.String{
+									stringvalidator.OneOf("equal", "notEqual", "contains", "notContains", "startsWith", "endsWith", "regex"),
+								},
+							},
+							"value": schema.StringAttribute{
+								MarkdownDescription: "Value to compare against.",
+								Required:            true,
+							},
+						},
+					},
+				},
+				"action": schema.StringAttribute{
+					MarkdownDescription: "Action the rule takes. One of `allow_when_context_is_untrusted`, `block_when_context_is_untrusted`, `block_always`, `require_approval`.",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("allow_when_context_is_untrusted", "block_when_context_is_untrusted", "block_always", "require_approval"),
+					},
+				},
+				"reason": schema.StringAttribute{
+					MarkdownDescription: "Optional reason describing why the rule exists.",
+					Optional:            true,
+				},
+			},
+		},
+	}
+}
+
+// candidateInvocationRules converts `policies` elements for tool into rules,
+// keeping each rule's position so results can point back at it. Elements
+// for other tools become condition-less rules, which evaluation skips.
+func candidateInvocationRules(policies []CandidateInvocationPolicyModel, tool openapi_types.UUID) []policySetRow {
+	rules := make([]policySetRow, len(policies))
+	for i, p := range policies {
+		if !p.ToolID.IsNull() && !strings.EqualFold(p.ToolID.ValueString(), tool.String()) {
+			continue
+		}
+		rules[i] = policySetRow{policySetRule: policySetRule{
+			ToolID:     tool,
+			Conditions: policySetConditionsFromModel(p.Conditions),
+			Action:     p.Action.ValueString(),
+			Note:       p.Reason.ValueStringPointer(),
+		}}
+	}
+	return rules
+}
+
+// backendInvocationRules returns the tool's conditional invocation rules
+// and its default action ("" for none).
+func backendInvocationRules(ctx context.Context, c *client.ClientWithResponses, tool openapi_types.UUID) ([]policySetRow, string, error) {
+	rows, err := invocationPolicyTable(c).list(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	var rules []policySetRow
+	defaultAction := ""
+	for _, row := range rows {
+		if row.ToolID != tool {
+			continue
+		}
+		if len(row.Conditions) == 0 {
+			defaultAction = row.Action
+			continue
+		}
+		rules = append(rules, row)
+	}
+	return rules, defaultAction, nil
+}
+
+func (d *ToolPolicyEvaluationDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tool_policy_evaluation"
+}
+
+func (d *ToolPolicyEvaluationDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Evaluates a tool's invocation policies against sample arguments, locally and without calling the tool, so `check` blocks and tests can assert what the platform would do with a call.\n\n" +
+			"```hcl\n" +
+			"data \"archestra_tool_policy_evaluation\" \"rm_rf\" {\n" +
+			"  tool_id   = archestra_mcp_server_installation.shell.tool_id_by_name[\"shell__run\"]\n" +
+			"  arguments = jsonencode({ command = \"rm -rf /\" })\n" +
+			"}\n\n" +
+			"check \"rm_rf_is_blocked\" {\n" +
+			"  assert {\n" +
+			"    condition     = data.archestra_tool_policy_evaluation.rm_rf.blocked\n" +
+			"    error_message = \"rm -rf is not blocked.\"\n" +
+			"  }\n" +
+			"}\n" +
+			"```\n\n" +
+			"A rule matches when ALL of its conditions hold; when several match, the most restrictive action wins (`block_always`, then `require_approval`, `block_when_context_is_untrusted`, `allow_when_context_is_untrusted`). With no match, the tool's default applies. " +
+			"A condition `key` names a top-level argument, or a path such as `options.mode` or `paths[0]` when no argument has that name; a missing argument fails the condition. " +
+			"Numbers and booleans compare by their JSON text. `regex` searches the value with JavaScript semantics, translated to Go's RE2 engine: patterns using lookaround, backreferences or repetition counts above 1000 fail with an error instead of being approximated.\n\n" +
+			"Set `policies` to evaluate rules that are not applied yet, such as `archestra_tool_policy_set.<n>.invocation_policies`.",
+
+		Attributes: map[string]schema.Attribute{
+			"tool_id": schema.StringAttribute{
+				MarkdownDescription: "Bare tool UUID the sample call is for.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegexp, "tool_id must be a bare tool UUID"),
+				},
+			},
+			"arguments": schema.StringAttribute{
+				MarkdownDescription: "Sample call arguments as a JSON object, typically `jsonencode({...})`.",
+				Required:            true,
+				CustomType:          jsontypes.NormalizedType{},
+				Validators: []validator.String{
+					jsonObjectValidator(),
+				},
+			},
+			"context_trusted": schema.BoolAttribute{
+				MarkdownDescription: "Optional. Whether the call runs in a trusted context, which decides `blocked` for `block_when_context_is_untrusted`. Defaults to false.",
+				Optional:            true,
+			},
+			"policies": candidateInvocationPoliciesAttribute("Optional. Rules to evaluate instead of the tool's conditional rules on the backend."),
+			"default_action": schema.StringAttribute{
+				MarkdownDescription: "Optional. Default action to use instead of the tool's default on the backend.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("allow_when_context_is_untrusted", "block_when_context_is_untrusted", "block_always", "require_approval"),
+				},
+			},
+			"action": schema.StringAttribute{
+				MarkdownDescription: "Resulting action. Null when no rule matches and the tool has no default.",
+				Computed:            true,
+			},
+			"matched_policy_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the backend rule that decided. Null when the default decided or `policies` is set.",
+				Computed:            true,
+			},
+			"matched_policy_index": schema.Int64Attribute{
+				MarkdownDescription: "Index into `policies` of the rule that decided. Null when the default decided or `policies` is not set.",
+				Computed:            true,
+			},
+			"reason": schema.StringAttribute{
+				MarkdownDescription: "Reason of the rule that decided, if it has one.",
+				Computed:            true,
+			},
+			"blocked": schema.BoolAttribute{
+				MarkdownDescription: "True when the call would not execute: `action` is `block_always`, or `block_when_context_is_untrusted` outside a trusted context.",
+				Computed:            true,
+			},
+			"requires_approval": schema.BoolAttribute{
+				MarkdownDescription: "True when `action` is `require_approval`.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *ToolPolicyEvaluationDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData))
+		return
+	}
+	d.client = providerData.Client
+}
+
+func (d *ToolPolicyEvaluationDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ToolPolicyEvaluationDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tool, err := uuid.Parse(data.ToolID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("tool_id"), "Invalid tool_id", err.Error())
+		return
+	}
+	var args map[string]any
+	if err := json.Unmarshal([]byte(data.Arguments.ValueString()), &args); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("arguments"), "Invalid arguments", err.Error())
+		return
+	}
+
+	// `policies` and `default_action` each replace their backend
+	// counterpart; the backend is only read for what they leave out.
+	var rules []policySetRow
+	defaultAction := data.DefaultAction.ValueString()
+	if data.Policies == nil || data.DefaultAction.IsNull() {
+		backendRules, backendDefault, err := backendInvocationRules(ctx, d.client, tool)
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", err.Error())
+			return
+		}
+		rules = backendRules
+		if data.DefaultAction.IsNull() {
+			defaultAction = backendDefault
+		}
+	}
+	if data.Policies != nil {
+		rules = candidateInvocationRules(data.Policies, tool)
+	}
+
+	result, err := evaluateInvocationRules(rules, defaultAction, args)
+	if err != nil {
+		resp.Diagnostics.AddError("Policy Evaluation Failed", err.Error())
+		return
+	}
+	flattenPolicyEvaluation(result, data.Policies != nil, data.ContextTrusted.ValueBool(), &data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func flattenPolicyEvaluation(result policyEvaluation, candidates, contextTrusted bool, data *ToolPolicyEvaluationDataSourceModel) {
+	data.Action = types.StringNull()
+	if result.Action != "" {
+		data.Action = types.StringValue(result.Action)
+	}
+	data.MatchedPolicyID = types.StringNull()
+	data.MatchedPolicyIndex = types.Int64Null()
+	data.Reason = types.StringNull()
+	if result.Rule != nil {
+		if candidates {
+			data.MatchedPolicyIndex = types.Int64Value(int64(result.Index))
+		} else {
+			data.MatchedPolicyID = types.StringValue(result.Rule.ID.String())
+		}
+		data.Reason = types.StringPointerValue(result.Rule.Note)
+	}
+	data.Blocked = types.BoolValue(result.Blocked(contextTrusted))
+	data.RequiresApproval = types.BoolValue(result.Action == "require_approval")
+}
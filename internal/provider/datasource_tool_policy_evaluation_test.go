@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestToolPolicyEvaluationDataSource(t *testing.T) {
+	routes := map[string]json.RawMessage{
+		"/api/autonomy-policies/tool-invocation": json.RawMessage(`[` +
+			`{"id":"11111111-0000-4000-8000-000000000001","toolId":"` + policySetToolA + `","action":"block_always","reason":"no rm -rf",` +
+			`"conditions":[{"key":"command","operator":"regex","value":"rm\\s+-rf"}],"createdAt":"2026-10-01T00:00:00Z","updatedAt":"2026-10-01T00:00:00Z"},` +
+			`{"id":"11111111-0000-4000-8000-000000000002","toolId":"` + policySetToolA + `","action":"allow_when_context_is_untrusted",` +
+			`"conditions":[],"createdAt":"2026-10-01T00:00:00Z","updatedAt":"2026-10-01T00:00:00Z"},` +
+			`{"id":"11111111-0000-4000-8000-000000000003","toolId":"` + unmanagedTool + `","action":"block_always",` +
+			`"conditions":[],"createdAt":"2026-10-01T00:00:00Z","updatedAt":"2026-10-01T00:00:00Z"}]`),
+	}
+	ps := newConfiguredProviderServer(t, newFixtureBackend(t, routes).URL)
+	schemaResp, err := ps.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.DataSourceSchemas["archestra_tool_policy_evaluation"].ValueType().(tftypes.Object)
+
+	tests := []struct {
+		name    string
+		config  string
+		action  string
+		policy  tftypes.Value
+		index   tftypes.Value
+		blocked bool
+	}{
+		{
+			name:    "backend rule",
+			config:  `{"tool_id":"` + policySetToolA + `","arguments":"{\"command\":\"rm -rf /\"}"}`,
+			action:  "block_always",
+			policy:  tftypes.NewValue(tftypes.String, "11111111-0000-4000-8000-000000000001"),
+			index:   tftypes.NewValue(tftypes.Number, nil),
+			blocked: true,
+		},
+		{
+			name:    "backend default",
+			config:  `{"tool_id":"` + policySetToolA + `","arguments":"{\"command\":\"ls\"}"}`,
+			action:  "allow_when_context_is_untrusted",
+			policy:  tftypes.NewValue(tftypes.String, nil),
+			index:   tftypes.NewValue(tftypes.Number, nil),
+			blocked: false,
+		},
+		{
+			name: "candidate rules",
+			config: `{"tool_id":"` + policySetToolA + `","arguments":"{\"command\":\"ls /etc\"}","default_action":"block_when_context_is_untrusted",` +
+				`"policies":[{"tool_id":"` + unmanagedTool + `","action":"block_always","conditions":[{"key":"command","operator":"contains","value":"ls"}]},` +
+				`{"action":"require_approval","conditions":[{"key":"command","operator":"endsWith","value":"/etc"}]}]}`,
+			action:  "require_approval",
+			policy:  tftypes.NewValue(tftypes.String, nil),
+			index:   tftypes.NewValue(tftypes.Number, 1),
+			blocked: false,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := validatorConfig(t, objType, tc.config, nil)
+			resp, err := ps.ReadDataSource(t.Context(), &tfprotov6.ReadDataSourceRequest{TypeName: "archestra_tool_policy_evaluation", Config: &config})
+			if err != nil {
+				t.Fatal(err)
+			}
+			failOnDiagnostics(t, "ReadDataSource", resp.Diagnostics)
+			got, err := resp.State.Unmarshal(objType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			root := tftypes.NewAttributePath()
+			assertAttrEqual(t, got, root.WithAttributeName("action"), tftypes.NewValue(tftypes.String, tc.action))
+			assertAttrEqual(t, got, root.WithAttributeName("matched_policy_id"), tc.policy)
+			assertAttrEqual(t, got, root.WithAttributeName("matched_policy_index"), tc.index)
+			assertAttrEqual(t, got, root.WithAttributeName("blocked"), tftypes.NewValue(tftypes.Bool, tc.blocked))
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &ToolPolicyReplayDataSource{}
+
+func NewToolPolicyReplayDataSource() datasource.DataSource {
+	return &ToolPolicyReplayDataSource{}
+}
+
+type ToolPolicyReplayDataSource struct {
+	client *client.ClientWithResponses
+}
+
+type ToolPolicyReplayDataSourceModel struct {
+	ToolID         types.String                     `tfsdk:"tool_id"`
+	Policies       []CandidateInvocationPolicyModel `tfsdk:"policies"`
+	DefaultAction  types.String                     `tfsdk:"default_action"`
+	ContextTrusted types.Bool                       `tfsdk:"context_trusted"`
+	MaxCalls       types.Int64                      `tfsdk:"max_calls"`
+	MaxExamples    types.Int64                      `tfsdk:"max_examples"`
+
+	ToolName                   types.String              `tfsdk:"tool_name"`
+	ReplayedCount              types.Int64               `tfsdk:"replayed_count"`
+	BlockedCount               types.Int64               `tfsdk:"blocked_count"`
+	RequiresApprovalCount      types.Int64               `tfsdk:"requires_approval_count"`
+	NewlyBlockedCount          types.Int64               `tfsdk:"newly_blocked_count"`
+	NewlyRequiresApprovalCount types.Int64               `tfsdk:"newly_requires_approval_count"`
+	Examples                   []ToolPolicyReplayExample `tfsdk:"examples"`
+}
+
+// ToolPolicyReplayExample is one replayed call the candidate rules would
+// block or hold for approval.
+type ToolPolicyReplayExample struct {
+	CallID             types.String `tfsdk:"call_id"`
+	CreatedAt          types.String `tfsdk:"created_at"`
+	Arguments          types.String `tfsdk:"arguments"`
+	Action             types.String `tfsdk:"action"`
+	CurrentAction      types.String `tfsdk:"current_action"`
+	MatchedPolicyIndex types.Int64  `tfsdk:"matched_policy_index"`
+	Changed            types.Bool   `tfsdk:"changed"`
+}
+
+const (
+	defaultToolPolicyReplayMaxCalls    int64 = 100
+	defaultToolPolicyReplayMaxExamples int64 = 10
+)
+
+func (d *ToolPolicyReplayDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tool_policy_replay"
+}
+
+func (d *ToolPolicyReplayDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Replays a tool's most recent calls from the MCP tool-call audit log through candidate invocation rules, locally, and reports how many would now be blocked or held for approval. Put it next to a policy change so the plan shows the change's blast radius before apply.\n\n" +
+			"```hcl\n" +
+			"data \"archestra_tool_policy_replay\" \"shell\" {\n" +
+			"  tool_id  = archestra_mcp_server_installation.shell.tool_id_by_name[\"shell__run\"]\n" +
+			"  policies = archestra_tool_policy_set.shell.invocation_policies\n" +
+			"}\n\n" +
+			"output \"newly_blocked_shell_calls\" {\n" +
+			"  value = data.archestra_tool_policy_replay.shell.newly_blocked_count\n" +
+			"}\n" +
+			"```\n\n" +
+			"Rules are evaluated as by `archestra_tool_policy_evaluation`, against each call's recorded arguments. `policies` and `default_action` each replace their backend counterpart; the `newly_*` counts compare against the rules on the backend today. " +
+			"Calls are matched to the tool by name, so calls recorded under a previous name of the tool are not replayed.",
+
+		Attributes: map[string]schema.Attribute{
+			"tool_id": schema.StringAttribute{
+				MarkdownDescription: "Bare tool UUID whose calls are replayed.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(uuidRegexp, "tool_id must be a bare tool UUID"),
+				},
+			},
+			"policies": candidateInvocationPoliciesAttribute("Optional. Candidate rules to replay instead of the tool's conditional rules on the backend."),
+			"default_action": schema.StringAttribute{
+				MarkdownDescription: "Optional. Candidate default action to use instead of the tool's default on the backend.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("allow_when_context_is_untrusted", "block_when_context_is_untrusted", "block_always", "require_approval"),
+				},
+			},
+			"context_trusted": schema.BoolAttribute{
+				MarkdownDescription: "Optional. Whether the replayed calls are treated as running in a trusted context, which decides blocking for `block_when_context_is_untrusted`. Defaults to false.",
+				Optional:            true,
+			},
+			"max_calls": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Optional. Number of most recent calls to replay. Defaults to %d.", defaultToolPolicyReplayMaxCalls),
+				Optional:            true,
+			},
+			"max_examples": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Optional. Cap on `examples`. Defaults to %d.", defaultToolPolicyReplayMaxExamples),
+				Optional:            true,
+			},
+			"tool_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the tool, as matched against the audit log.",
+				Computed:            true,
+			},
+			"replayed_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of calls replayed. Less than `max_calls` when the audit log has fewer calls of the tool.",
+				Computed:            true,
+			},
+			"blocked_count": schema.Int64Attribute{
+				MarkdownDescription: "Replayed calls the candidate rules would block.",
+				Computed:            true,
+			},
+			"requires_approval_count": schema.Int64Attribute{
+				MarkdownDescription: "Replayed calls the candidate rules would hold for approval.",
+				Computed:            true,
+			},
+			"newly_blocked_count": schema.Int64Attribute{
+				MarkdownDescription: "Of `blocked_count`, calls the backend's current rules do not block.",
+				Computed:            true,
+			},
+			"newly_requires_approval_count": schema.Int64Attribute{
+				MarkdownDescription: "Of `requires_approval_count`, calls the backend's current rules do not hold for approval.",
+				Computed:            true,
+			},
+			"examples": schema.ListNestedAttribute{
+				MarkdownDescription: "Replayed calls the candidate rules would block or hold for approval, newest first, those whose outcome changes listed before the rest.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"call_id":              schema.StringAttribute{Computed: true, MarkdownDescription: "Audit log record UUID."},
+						"created_at":           schema.StringAttribute{Computed: true, MarkdownDescription: "RFC 3339 timestamp of when the call was recorded."},
+						"arguments":            schema.StringAttribute{Computed: true, MarkdownDescription: "Recorded arguments encoded as a JSON string."},
+						"action":               schema.StringAttribute{Computed: true, MarkdownDescription: "Action under the candidate rules."},
+						"current_action":       schema.StringAttribute{Computed: true, MarkdownDescription: "Action under the backend's current rules. Null when nothing applies."},
+						"matched_policy_index": schema.Int64Attribute{Computed: true, MarkdownDescription: "Index into `policies` of the rule that decided. Null when the default decided or `policies` is not set."},
+						"changed":              schema.BoolAttribute{Computed: true, MarkdownDescription: "True when the call was not already blocked, or held for approval, under the current rules."},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ToolPolicyReplayDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData))
+		return
+	}
+	d.client = providerData.Client
+}
+
+func (d *ToolPolicyReplayDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ToolPolicyReplayDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tool, err := uuid.Parse(data.ToolID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("tool_id"), "Invalid tool_id", err.Error())
+		return
+	}
+	maxCalls := defaultToolPolicyReplayMaxCalls
+	if !data.MaxCalls.IsNull() {
+		if maxCalls = data.MaxCalls.ValueInt64(); maxCalls <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_calls"), "Invalid max_calls", "max_calls must be > 0")
+			return
+		}
+	}
+	maxExamples := defaultToolPolicyReplayMaxExamples
+	if !data.MaxExamples.IsNull() {
+		if maxExamples = data.MaxExamples.ValueInt64(); maxExamples < 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("max_examples"), "Invalid max_examples", "max_examples must be >= 0")
+			return
+		}
+	}
+
+	toolName, found, err := toolNameByID(ctx, d.client, tool)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read tools: %s", err))
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddAttributeError(path.Root("tool_id"), "Tool Not Found", fmt.Sprintf("No tool with ID %s", tool))
+		return
+	}
+
+	currentRules, currentDefault, err := backendInvocationRules(ctx, d.client, tool)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", err.Error())
+		return
+	}
+	candidateRules, candidateDefault := currentRules, currentDefault
+	if data.Policies != nil {
+		candidateRules = candidateInvocationRules(data.Policies, tool)
+	}
+	if !data.DefaultAction.IsNull() {
+		candidateDefault = data.DefaultAction.ValueString()
+	}
+
+	calls, err := recentToolCalls(ctx, d.client, toolName, maxCalls)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read MCP tool calls: %s", err))
+		return
+	}
+
+	contextTrusted := data.ContextTrusted.ValueBool()
+	var blocked, approval, newlyBlocked, newlyApproval int64
+	var changedExamples, otherExamples []ToolPolicyReplayExample
+	for _, call := range calls {
+		candidate, err := evaluateInvocationRules(candidateRules, candidateDefault, call.Arguments)
+		if err != nil {
+			resp.Diagnostics.AddError("Policy Evaluation Failed", err.Error())
+			return
+		}
+		current, err := evaluateInvocationRules(currentRules, currentDefault, call.Arguments)
+		if err != nil {
+			resp.Diagnostics.AddError("Policy Evaluation Failed", err.Error())
+			return
+		}
+
+		var changed bool
+		switch {
+		case candidate.Blocked(contextTrusted):
+			blocked++
+			if changed = !current.Blocked(contextTrusted); changed {
+				newlyBlocked++
+			}
+		case candidate.Action == "require_approval":
+			approval++
+			if changed = current.Action != "require_approval"; changed {
+				newlyApproval++
+			}
+		default:
+			continue
+		}
+
+		example := ToolPolicyReplayExample{
+			CallID:             types.StringValue(call.ID),
+			CreatedAt:          types.StringValue(call.CreatedAt),
+			Arguments:          types.StringValue(call.ArgumentsJSON),
+			Action:             types.StringValue(candidate.Action),
+			CurrentAction:      types.StringNull(),
+			MatchedPolicyIndex: types.Int64Null(),
+			Changed:            types.BoolValue(changed),
+		}
+		if current.Action != "" {
+			example.CurrentAction = types.StringValue(current.Action)
+		}
+		if candidate.Rule != nil && data.Policies != nil {
+			example.MatchedPolicyIndex = types.Int64Value(int64(candidate.Index))
+		}
+		if changed {
+			changedExamples = append(changedExamples, example)
+		} else {
+			otherExamples = append(otherExamples, example)
+		}
+	}
+
+	data.Examples = append(changedExamples, otherExamples...)
+	if int64(len(data.Examples)) > maxExamples {
+		data.Examples = data.Examples[:maxExamples]
+	}
+	if data.Examples == nil {
+		data.Examples = []ToolPolicyReplayExample{}
+	}
+	data.ToolName = types.StringValue(toolName)
+	data.ReplayedCount = types.Int64Value(int64(len(calls)))
+	data.BlockedCount = types.Int64Value(blocked)
+	data.RequiresApprovalCount = types.Int64Value(approval)
+	data.NewlyBlockedCount = types.Int64Value(newlyBlocked)
+	data.NewlyRequiresApprovalCount = types.Int64Value(newlyApproval)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// replayedToolCall is the part of an audit log record a replay needs.
+type replayedToolCall struct {
+	ID            string
+	CreatedAt     string
+	Arguments     map[string]any
+	ArgumentsJSON string
+}
+
+// toolNameByID resolves a tool's name, which is how the audit log
+// identifies it.
+func toolNameByID(ctx context.Context, c *client.ClientWithResponses, tool uuid.UUID) (string, bool, error) {
+	toolsResp, err := c.GetToolsWithResponse(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	if toolsResp.JSON200 == nil {
+		return "", false, fmt.Errorf("expected 200 OK, got status %d: %s", toolsResp.StatusCode(), string(toolsResp.Body))
+	}
+	for _, t := range *toolsResp.JSON200 {
+		if t.Id == tool {
+			return t.Name, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// recentToolCalls returns up to limit of the most recent tools/call
+// records for the named tool, newest first. The order is requested
+// explicitly rather than left to the endpoint's default. The backend has
+// no tool filter, so the search narrows pages to records mentioning the
+// name and the exact match happens here.
+func recentToolCalls(ctx context.Context, c *client.ClientWithResponses, toolName string, limit int64) ([]replayedToolCall, error) {
+	pageSize := 100
+	offset := 0
+	sortBy, direction := client.GetMcpToolCallsParamsSortByCreatedAt, client.GetMcpToolCallsParamsSortDirectionDesc
+	params := &client.GetMcpToolCallsParams{
+		Search:        &toolName,
+		Limit:         &pageSize,
+		Offset:        &offset,
+		SortBy:        &sortBy,
+		SortDirection: &direction,
+	}
+
+	var calls []replayedToolCall
+	for {
+		callsResp, err := c.GetMcpToolCallsWithResponse(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		if callsResp.JSON200 == nil {
+			return nil, fmt.Errorf("expected 200 OK, got status %d: %s", callsResp.StatusCode(), string(callsResp.Body))
+		}
+		for i := range callsResp.JSON200.Data {
+			record := &callsResp.JSON200.Data[i]
+			if record.ToolCall == nil || record.ToolCall.Name != toolName {
+				continue
+			}
+			args := record.ToolCall.Arguments
+			if args == nil {
+				args = map[string]any{}
+			}
+			argsJSON, err := json.Marshal(args)
+			if err != nil {
+				return nil, fmt.Errorf("call %s: %w", record.Id, err)
+			}
+			calls = append(calls, replayedToolCall{
+				ID:            record.Id.String(),
+				CreatedAt:     record.CreatedAt.Format(time.RFC3339),
+				Arguments:     args,
+				ArgumentsJSON: string(argsJSON),
+			})
+			if int64(len(calls)) >= limit {
+				return calls, nil
+			}
+		}
+		if !callsResp.JSON200.Pagination.HasNext {
+			return calls, nil
+		}
+		offset += pageSize
+	}
+}
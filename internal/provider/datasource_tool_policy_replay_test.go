@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestToolPolicyReplayDataSource replays four recorded calls, one of them
+// for another tool, through a candidate set that adds an approval rule to
+// a backend that already blocks `rm -rf`.
+func TestToolPolicyReplayDataSource(t *testing.T) {
+	call := func(id, tool, command string) string {
+		return `{"id":"` + id + `","mcpServerName":"shell","method":"tools/call","createdAt":"2026-10-0` + id[len(id)-1:] + `T00:00:00Z",` +
+			`"toolCall":{"id":"c` + id[len(id)-1:] + `","name":"` + tool + `","arguments":{"command":"` + command + `"}}}`
+	}
+	routes := map[string]json.RawMessage{
+		"/api/tools": json.RawMessage(`[{"id":"` + policySetToolA + `","name":"shell__run","createdAt":"2026-10-01T00:00:00Z","updatedAt":"2026-10-01T00:00:00Z"}]`),
+		"/api/autonomy-policies/tool-invocation": json.RawMessage(`[` +
+			`{"id":"11111111-0000-4000-8000-000000000001","toolId":"` + policySetToolA + `","action":"block_always",` +
+			`"conditions":[{"key":"command","operator":"contains","value":"rm -rf"}],"createdAt":"2026-10-01T00:00:00Z","updatedAt":"2026-10-01T00:00:00Z"}]`),
+		"/api/mcp-tool-calls": json.RawMessage(`{"data":[` +
+			call("22222222-0000-4000-8000-000000000004", "shell__run", "rm -rf /tmp/x") + `,` +
+			call("22222222-0000-4000-8000-000000000003", "shell__run", "sudo ls") + `,` +
+			call("22222222-0000-4000-8000-000000000002", "shell__run_as", "sudo rm") + `,` +
+			call("22222222-0000-4000-8000-000000000001", "shell__run", "ls") + `],` +
+			`"pagination":{"currentPage":1,"hasNext":false,"hasPrev":false,"limit":100,"total":4,"totalPages":1}}`),
+	}
+	ps := newConfiguredProviderServer(t, newFixtureBackend(t, routes).URL)
+	schemaResp, err := ps.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.DataSourceSchemas["archestra_tool_policy_replay"].ValueType().(tftypes.Object)
+	config := validatorConfig(t, objType, `{"tool_id":"`+policySetToolA+`","policies":[`+
+		`{"action":"block_always","conditions":[{"key":"command","operator":"contains","value":"rm -rf"}]},`+
+		`{"action":"require_approval","conditions":[{"key":"command","operator":"startsWith","value":"sudo "}]}]}`, nil)
+
+	resp, err := ps.ReadDataSource(t.Context(), &tfprotov6.ReadDataSourceRequest{TypeName: "archestra_tool_policy_replay", Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ReadDataSource", resp.Diagnostics)
+	got, err := resp.State.Unmarshal(objType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := tftypes.NewAttributePath()
+	for name, want := range map[string]int{
+		"replayed_count":                3,
+		"blocked_count":                 1,
+		"requires_approval_count":       1,
+		"newly_blocked_count":           0,
+		"newly_requires_approval_count": 1,
+	} {
+		assertAttrEqual(t, got, root.WithAttributeName(name), tftypes.NewValue(tftypes.Number, want))
+	}
+	first := root.WithAttributeName("examples").WithElementKeyInt(0)
+	assertAttrEqual(t, got, first.WithAttributeName("call_id"), tftypes.NewValue(tftypes.String, "22222222-0000-4000-8000-000000000003"))
+	assertAttrEqual(t, got, first.WithAttributeName("action"), tftypes.NewValue(tftypes.String, "require_approval"))
+	assertAttrEqual(t, got, first.WithAttributeName("current_action"), tftypes.NewValue(tftypes.String, nil))
+	assertAttrEqual(t, got, first.WithAttributeName("matched_policy_index"), tftypes.NewValue(tftypes.Number, 1))
+	assertAttrEqual(t, got, first.WithAttributeName("changed"), tftypes.NewValue(tftypes.Bool, true))
+	second := root.WithAttributeName("examples").WithElementKeyInt(1)
+	assertAttrEqual(t, got, second.WithAttributeName("arguments"), tftypes.NewValue(tftypes.String, `{"command":"rm -rf /tmp/x"}`))
+	assertAttrEqual(t, got, second.WithAttributeName("changed"), tftypes.NewValue(tftypes.Bool, false))
+}
+
+// TestToolPolicyReplayNewestCalls checks that max_calls keeps the newest
+// calls. The fake backend lists oldest first unless asked for createdAt
+// descending; only the two newest calls run `rm -rf`.
+func TestToolPolicyReplayNewestCalls(t *testing.T) {
+	calls := make([]string, 0, 4)
+	for i, command := range []string{"ls", "pwd", "rm -rf /a", "rm -rf /b"} {
+		n := string(rune('1' + i))
+		calls = append(calls, `{"id":"22222222-0000-4000-8000-00000000000`+n+`","mcpServerName":"shell","method":"tools/call","createdAt":"2026-10-0`+n+`T00:00:00Z",`+
+			`"toolCall":{"id":"c`+n+`","name":"shell__run","arguments":{"command":"`+command+`"}}}`)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/tools":
+			_, _ = w.Write([]byte(`[{"id":"` + policySetToolA + `","name":"shell__run","createdAt":"2026-10-01T00:00:00Z","updatedAt":"2026-10-01T00:00:00Z"}]`))
+		case "/api/autonomy-policies/tool-invocation":
+			_, _ = w.Write([]byte(`[]`))
+		case "/api/mcp-tool-calls":
+			ordered := slices.Clone(calls)
+			if q := r.URL.Query(); q.Get("sortBy") == "createdAt" && q.Get("sortDirection") == "desc" {
+				slices.Reverse(ordered)
+			}
+			_, _ = w.Write([]byte(`{"data":[` + strings.Join(ordered, ",") + `],` +
+				`"pagination":{"currentPage":1,"hasNext":false,"hasPrev":false,"limit":100,"total":4,"totalPages":1}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	ps := newConfiguredProviderServer(t, server.URL)
+	schemaResp, err := ps.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.DataSourceSchemas["archestra_tool_policy_replay"].ValueType().(tftypes.Object)
+	config := validatorConfig(t, objType, `{"tool_id":"`+policySetToolA+`","max_calls":2,"policies":[`+
+		`{"action":"block_always","conditions":[{"key":"command","operator":"startsWith","value":"rm -rf"}]}]}`, nil)
+
+	resp, err := ps.ReadDataSource(t.Context(), &tfprotov6.ReadDataSourceRequest{TypeName: "archestra_tool_policy_replay", Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ReadDataSource", resp.Diagnostics)
+	got, err := resp.State.Unmarshal(objType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	root := tftypes.NewAttributePath()
+	assertAttrEqual(t, got, root.WithAttributeName("replayed_count"), tftypes.NewValue(tftypes.Number, 2))
+	assertAttrEqual(t, got, root.WithAttributeName("blocked_count"), tftypes.NewValue(tftypes.Number, 2))
+	assertAttrEqual(t, got, root.WithAttributeName("examples").WithElementKeyInt(0).WithAttributeName("call_id"),
+		tftypes.NewValue(tftypes.String, "22222222-0000-4000-8000-000000000004"))
+}
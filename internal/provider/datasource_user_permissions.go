@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &UserPermissionsDataSource{}
+
+func NewUserPermissionsDataSource() datasource.DataSource {
+	return &UserPermissionsDataSource{}
+}
+
+type UserPermissionsDataSource struct {
+	client *client.ClientWithResponses
+}
+
+type UserPermissionsDataSourceModel struct {
+	Permissions types.Map  `tfsdk:"permissions"`
+	IsOrgAdmin  types.Bool `tfsdk:"is_org_admin"`
+}
+
+func (d *UserPermissionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_permissions"
+}
+
+func (d *UserPermissionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Permissions granted to the identity behind the provider's API key. " +
+			"Use it to branch shared modules on what the running identity can do — for example, skip organization-scoped resources when a team admin runs the module.",
+
+		Attributes: map[string]schema.Attribute{
+			"permissions": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.SetType{ElemType: types.StringType},
+				MarkdownDescription: "RBAC resource name → set of allowed actions (e.g. `{ agent = [\"create\", \"read\", \"update\", \"delete\"] }`). A resource the identity has no access to is absent from the map.",
+			},
+			"is_org_admin": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the identity can change organization-wide settings (`organization: update`) — the permission that separates organization admins from team admins and members.",
+			},
+		},
+	}
+}
+
+func (d *UserPermissionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *UserPermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UserPermissionsDataSourceModel
+
+	apiResp, err := d.client.GetUserPermissionsWithResponse(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read user permissions: %s", err))
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected API Response",
+			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
+		)
+		return
+	}
+
+	permissions := permissionsFromAPI(apiResp.JSON200)
+	permissionsMap, diags := types.MapValueFrom(ctx, types.SetType{ElemType: types.StringType}, map[string][]string(permissions))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Permissions = permissionsMap
+	data.IsOrgAdmin = types.BoolValue(permissions.IsOrgAdmin())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccUserPermissionsDataSource(t *testing.T) {
+	// The acceptance-test key is minted for the seeded admin, so the grant
+	// includes organization: update.
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "archestra_user_permissions" "current" {}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.archestra_user_permissions.current",
+						tfjsonpath.New("is_org_admin"),
+						knownvalue.Bool(true),
+					),
+					statecheck.ExpectKnownValue(
+						"data.archestra_user_permissions.current",
+						tfjsonpath.New("permissions").AtMapKey("agent"),
+						knownvalue.SetPartial([]knownvalue.Check{knownvalue.StringExact("read")}),
+					),
+				},
+			},
+		},
+	})
+}
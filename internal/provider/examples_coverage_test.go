@@ -5,12 +5,16 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
-// TestExamplesCoverage fails when a registered resource or data source lacks
-// its `examples/{resources|data-sources}/<type>/<resource|data-source>.tf`
+// TestExamplesCoverage fails when a registered resource, data source, list
+// resource, function or action lacks its
+// `examples/{resources|data-sources|list-resources|functions|actions}/<type>/` example
 // file. The example is the user-facing how-to — tfplugindocs renders it
 // inline into `docs/`, and the schema reference alone doesn't show how
 // arguments compose. A missing example forces users to read the source.
@@ -45,6 +49,17 @@ func TestExamplesCoverage(t *testing.T) {
 					t.Errorf("missing import.sh for %s — expected %s", meta.TypeName, importPath)
 				}
 			}
+
+			// Likewise import-by-identity.tf for resources with an identity
+			// schema that can also be imported, which renders the identity
+			// attribute reference.
+			_, importable := r.(resource.ResourceWithImportState)
+			if _, ok := r.(resource.ResourceWithIdentity); ok && importable {
+				identityPath := filepath.Join(dir, "import-by-identity.tf")
+				if _, err := os.Stat(identityPath); err != nil {
+					t.Errorf("missing import-by-identity.tf for %s — expected %s", meta.TypeName, identityPath)
+				}
+			}
 		}
 	})
 
@@ -59,4 +74,39 @@ func TestExamplesCoverage(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("list_resources", func(t *testing.T) {
+		for _, ctor := range prov.(provider.ProviderWithListResources).ListResources(ctx) {
+			l := ctor()
+			var meta resource.MetadataResponse
+			l.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "archestra"}, &meta)
+			path := filepath.Join(repoRoot, "examples", "list-resources", meta.TypeName, "list-resource.tfquery.hcl")
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("missing example for %s — expected %s", meta.TypeName, path)
+			}
+		}
+	})
+
+	t.Run("functions", func(t *testing.T) {
+		for _, ctor := range prov.(provider.ProviderWithFunctions).Functions(ctx) {
+			f := ctor()
+			var meta function.MetadataResponse
+			f.Metadata(ctx, function.MetadataRequest{}, &meta)
+			path := filepath.Join(repoRoot, "examples", "functions", meta.Name, "function.tf")
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("missing example for %s — expected %s", meta.Name, path)
+			}
+		}
+	})
+	t.Run("actions", func(t *testing.T) {
+		for _, ctor := range prov.(provider.ProviderWithActions).Actions(ctx) {
+			a := ctor()
+			var meta action.MetadataResponse
+			a.Metadata(ctx, action.MetadataRequest{ProviderTypeName: "archestra"}, &meta)
+			path := filepath.Join(repoRoot, "examples", "actions", meta.TypeName, "action.tf")
+			if _, err := os.Stat(path); err != nil {
+				t.Errorf("missing example for %s — expected %s", meta.TypeName, path)
+			}
+		}
+	})
 }
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var _ function.Function = &DelegationIDFunction{}
+
+func NewDelegationIDFunction() function.Function {
+	return &DelegationIDFunction{}
+}
+
+// DelegationIDFunction builds the composite `archestra_agent_delegation` ID,
+// the format its `id` attribute and ID-based import use.
+type DelegationIDFunction struct{}
+
+func (f *DelegationIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "delegation_id"
+}
+
+func (f *DelegationIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build an agent delegation ID",
+		MarkdownDescription: "Returns the composite ID of an `archestra_agent_delegation`, `<agent_id>:<target_agent_id>`, for `import` blocks and anywhere else the ID is expected. " +
+			"Both arguments must be UUIDs.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "agent_id",
+				MarkdownDescription: "Agent that delegates.",
+			},
+			function.StringParameter{
+				Name:                "target_agent_id",
+				MarkdownDescription: "Agent delegated to.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DelegationIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var agentID, targetAgentID string
+	resp.Error = req.Arguments.Get(ctx, &agentID, &targetAgentID)
+	if resp.Error != nil {
+		return
+	}
+
+	for i, id := range []string{agentID, targetAgentID} {
+		if _, err := uuid.Parse(id); err != nil {
+			resp.Error = function.NewArgumentFuncError(int64(i), fmt.Sprintf("%q is not a UUID: %s", id, err))
+			return
+		}
+	}
+
+	resp.Error = resp.Result.Set(ctx, agentID+":"+targetAgentID)
+}
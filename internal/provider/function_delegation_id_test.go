@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDelegationIDFunction(t *testing.T) {
+	t.Parallel()
+
+	const a = "8f9c2b1e-3d4a-4e5f-9a6b-7c8d9e0f1a2b"
+	const b = "1a2b3c4d-5e6f-4a8b-9c0d-1e2f3a4b5c6d"
+
+	tests := []struct {
+		name        string
+		agent       string
+		target      string
+		want        string
+		wantErrArg  int64
+		wantErrText bool
+	}{
+		{name: "joins with a colon", agent: a, target: b, want: a + ":" + b},
+		{name: "agent not a UUID", agent: "support", target: b, wantErrArg: 0, wantErrText: true},
+		{name: "target not a UUID", agent: a, target: "", wantErrArg: 1, wantErrText: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := runFunction(t, &DelegationIDFunction{}, types.StringValue(tc.agent), types.StringValue(tc.target))
+			if tc.wantErrText {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				if err.FunctionArgument == nil || *err.FunctionArgument != tc.wantErrArg {
+					t.Errorf("error points at argument %v, want %d", err.FunctionArgument, tc.wantErrArg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(types.StringValue(tc.want)) {
+				t.Errorf("got %v, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &LabelFilterFunction{}
+
+func NewLabelFilterFunction() function.Function {
+	return &LabelFilterFunction{}
+}
+
+// LabelFilterFunction renders a label map in the backend's list-filter
+// syntax. Shares labelFilter and its checks with the list resources so the
+// two can't drift.
+type LabelFilterFunction struct{}
+
+func (f *LabelFilterFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "label_filter"
+}
+
+func (f *LabelFilterFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Encode labels as a backend label filter",
+		MarkdownDescription: "Renders a map of labels in the backend's list-filter syntax, `key1:value1;key2:value2`, with keys sorted so the result is stable. " +
+			"Use it wherever the API expects a `labels` query parameter, e.g. in an `http` data source against the Archestra API.\n\n" +
+			"A `|` inside a value is the backend's OR: `{ env = \"prod|staging\" }` matches either. " +
+			"Fails when a key or value contains `:` or `;`, which the syntax cannot express, or when a key is empty.",
+		Parameters: []function.Parameter{
+			function.MapParameter{
+				Name:                "labels",
+				MarkdownDescription: "Label keys and values to filter on.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *LabelFilterFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var labels map[string]string
+	resp.Error = req.Arguments.Get(ctx, &labels)
+	if resp.Error != nil {
+		return
+	}
+
+	if err := labelFilterError(labels); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, labelFilter(labels))
+}
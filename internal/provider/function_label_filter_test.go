@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// runFunction calls f.Run the way the framework does, with result seeded
+// as an unknown of the declared return type. Shared by the function tests.
+func runFunction(t *testing.T, f function.Function, args ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+	ctx := t.Context()
+
+	var defResp function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &defResp)
+	result, err := defResp.Definition.Return.NewResultData(ctx)
+	if err != nil {
+		t.Fatalf("NewResultData: %s", err)
+	}
+	resp := function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(args)}, &resp)
+	return resp.Result.Value(), resp.Error
+}
+
+func TestLabelFilterFunction(t *testing.T) {
+	t.Parallel()
+
+	labels := func(m map[string]string) attr.Value {
+		elems := make(map[string]attr.Value, len(m))
+		for k, v := range m {
+			elems[k] = types.StringValue(v)
+		}
+		return types.MapValueMust(types.StringType, elems)
+	}
+
+	tests := []struct {
+		name    string
+		labels  map[string]string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty", labels: map[string]string{}, want: ""},
+		{name: "sorted by key", labels: map[string]string{"team": "ml", "env": "prod"}, want: "env:prod;team:ml"},
+		{name: "empty value allowed", labels: map[string]string{"env": ""}, want: "env:"},
+		{name: "alternatives pass through", labels: map[string]string{"env": "prod|staging"}, want: "env:prod|staging"},
+		{name: "separator in value", labels: map[string]string{"env": "a;b"}, wantErr: true},
+		{name: "colon in key", labels: map[string]string{"a:b": "x"}, wantErr: true},
+		{name: "empty key", labels: map[string]string{"": "x"}, wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := runFunction(t, &LabelFilterFunction{}, labels(tc.labels))
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(types.StringValue(tc.want)) {
+				t.Errorf("got %v, want %q", got, tc.want)
+			}
+		})
+	}
+}
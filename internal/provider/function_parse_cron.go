@@ -0,0 +1,241 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	// Embedded zoneinfo: timezone validation must not depend on the
+	// machine running Terraform having /usr/share/zoneinfo.
+	_ "time/tzdata"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ParseCronFunction{}
+
+func NewParseCronFunction() function.Function {
+	return &ParseCronFunction{}
+}
+
+// ParseCronFunction validates a schedule-trigger cron expression and
+// timezone at plan time — the backend otherwise rejects them at apply — and
+// returns the expression in the plain five-field form the backend stores.
+// Pure: no "next run" output, since provider functions must be
+// deterministic.
+type ParseCronFunction struct{}
+
+var parseCronReturnAttrTypes = map[string]attr.Type{
+	"expression":   types.StringType,
+	"timezone":     types.StringType,
+	"minute":       types.ListType{ElemType: types.Int64Type},
+	"hour":         types.ListType{ElemType: types.Int64Type},
+	"day_of_month": types.ListType{ElemType: types.Int64Type},
+	"month":        types.ListType{ElemType: types.Int64Type},
+	"day_of_week":  types.ListType{ElemType: types.Int64Type},
+}
+
+type parsedCron struct {
+	Expression string  `tfsdk:"expression"`
+	Timezone   string  `tfsdk:"timezone"`
+	Minute     []int64 `tfsdk:"minute"`
+	Hour       []int64 `tfsdk:"hour"`
+	DayOfMonth []int64 `tfsdk:"day_of_month"`
+	Month      []int64 `tfsdk:"month"`
+	DayOfWeek  []int64 `tfsdk:"day_of_week"`
+}
+
+func (f *ParseCronFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_cron"
+}
+
+func (f *ParseCronFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Validate and normalize a cron schedule",
+		MarkdownDescription: "Parses a five-field cron expression (`minute hour day-of-month month day-of-week`) together with an IANA timezone, as used by schedule triggers, and fails the plan on anything the backend would reject.\n\n" +
+			"Fields accept `*`, numbers, ranges (`1-5`), lists (`1,15`) and steps (`*/15`, `0-30/10`); months and weekdays also accept three-letter names (`JAN`, `MON`). " +
+			"The macros `@yearly`, `@annually`, `@monthly`, `@weekly`, `@daily`, `@midnight` and `@hourly` are expanded.\n\n" +
+			"Returns an object with `expression` (names replaced by numbers, macros expanded, whitespace collapsed), the validated `timezone`, and each field expanded to its sorted values (`day_of_week` uses 0 for Sunday).",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "expression",
+				MarkdownDescription: "Cron expression.",
+			},
+			function.StringParameter{
+				Name:                "timezone",
+				MarkdownDescription: "IANA timezone the schedule runs in, e.g. `Europe/Berlin` or `UTC`.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parseCronReturnAttrTypes,
+		},
+	}
+}
+
+func (f *ParseCronFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expr, tz string
+	resp.Error = req.Arguments.Get(ctx, &expr, &tz)
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := parseCron(expr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	// LoadLocation accepts "" and "Local" as the machine's zone; neither
+	// means anything to the backend.
+	if tz == "" || tz == "Local" {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%q is not an IANA timezone", tz))
+		return
+	}
+	if _, err := time.LoadLocation(tz); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("unknown timezone %q", tz))
+		return
+	}
+	parsed.Timezone = tz
+
+	resp.Error = resp.Result.Set(ctx, parsed)
+}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names[i] stands for min+i
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day-of-month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	// 7 is accepted as Sunday and folded to 0.
+	{name: "day-of-week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// parseCron parses a five-field expression or macro. Timezone is left empty.
+func parseCron(expr string) (parsedCron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		macro, ok := cronMacros[strings.ToLower(fields[0])]
+		if !ok {
+			return parsedCron{}, fmt.Errorf("unknown macro %q", fields[0])
+		}
+		fields = strings.Fields(macro)
+	}
+	if len(fields) != len(cronFields) {
+		return parsedCron{}, fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d in %q", len(fields), expr)
+	}
+
+	normalized := make([]string, len(fields))
+	values := make([][]int64, len(fields))
+	for i, field := range fields {
+		var err error
+		normalized[i], values[i], err = cronFields[i].parse(field)
+		if err != nil {
+			return parsedCron{}, err
+		}
+	}
+
+	return parsedCron{
+		Expression: strings.Join(normalized, " "),
+		Minute:     values[0],
+		Hour:       values[1],
+		DayOfMonth: values[2],
+		Month:      values[3],
+		DayOfWeek:  values[4],
+	}, nil
+}
+
+// parse expands one field and returns it with names replaced by numbers.
+func (f cronField) parse(field string) (string, []int64, error) {
+	set := map[int]bool{}
+	items := strings.Split(field, ",")
+	normalized := make([]string, len(items))
+	for i, item := range items {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n < 1 {
+				return "", nil, fmt.Errorf("%s: invalid step %q in %q", f.name, stepPart, item)
+			}
+			step = n
+		}
+
+		lo, hi := f.min, f.max
+		normalizedRange := "*"
+		if rangePart != "*" {
+			loText, hiText, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = f.value(loText); err != nil {
+				return "", nil, err
+			}
+			hi = lo
+			normalizedRange = strconv.Itoa(lo)
+			if isRange {
+				if hi, err = f.value(hiText); err != nil {
+					return "", nil, err
+				}
+				if hi < lo {
+					return "", nil, fmt.Errorf("%s: range %q runs backwards", f.name, rangePart)
+				}
+				normalizedRange += "-" + strconv.Itoa(hi)
+			} else if hasStep {
+				// `5/15` means "from 5 to the end, every 15".
+				hi = f.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			if f.name == "day-of-week" && v == 7 {
+				set[0] = true
+				continue
+			}
+			set[v] = true
+		}
+		normalized[i] = normalizedRange
+		if hasStep {
+			normalized[i] += "/" + strconv.Itoa(step)
+		}
+	}
+
+	values := make([]int64, 0, len(set))
+	for v := range set {
+		values = append(values, int64(v))
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return strings.Join(normalized, ","), values, nil
+}
+
+// value parses a single number or name within the field's bounds.
+func (f cronField) value(text string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(text, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %q is not a number", f.name, text)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s: %d is out of range %d-%d", f.name, n, f.min, f.max)
+	}
+	return n, nil
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseCron(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		expr    string
+		want    parsedCron
+		wantErr string
+	}{
+		{
+			expr: "*/15 9-17 * * MON-FRI",
+			want: parsedCron{
+				Expression: "*/15 9-17 * * 1-5",
+				Minute:     []int64{0, 15, 30, 45},
+				Hour:       []int64{9, 10, 11, 12, 13, 14, 15, 16, 17},
+				DayOfMonth: seq(1, 31),
+				Month:      seq(1, 12),
+				DayOfWeek:  []int64{1, 2, 3, 4, 5},
+			},
+		},
+		{
+			expr: "  0   6  1,15 jan,jul  7 ",
+			want: parsedCron{
+				Expression: "0 6 1,15 1,7 7",
+				Minute:     []int64{0},
+				Hour:       []int64{6},
+				DayOfMonth: []int64{1, 15},
+				Month:      []int64{1, 7},
+				DayOfWeek:  []int64{0},
+			},
+		},
+		{
+			expr: "@daily",
+			want: parsedCron{
+				Expression: "0 0 * * *",
+				Minute:     []int64{0},
+				Hour:       []int64{0},
+				DayOfMonth: seq(1, 31),
+				Month:      seq(1, 12),
+				DayOfWeek:  seq(0, 6),
+			},
+		},
+		{
+			expr: "5/20 0 * * *",
+			want: parsedCron{
+				Expression: "5/20 0 * * *",
+				Minute:     []int64{5, 25, 45},
+				Hour:       []int64{0},
+				DayOfMonth: seq(1, 31),
+				Month:      seq(1, 12),
+				DayOfWeek:  seq(0, 6),
+			},
+		},
+		{expr: "0 0 * *", wantErr: "expected 5 fields"},
+		{expr: "0 0 * * * *", wantErr: "expected 5 fields"},
+		{expr: "60 * * * *", wantErr: "minute: 60 is out of range 0-59"},
+		{expr: "0 24 * * *", wantErr: "hour: 24 is out of range"},
+		{expr: "0 0 0 * *", wantErr: "day-of-month: 0 is out of range"},
+		{expr: "0 0 * FOO *", wantErr: `month: "FOO" is not a number`},
+		{expr: "0 0 * * 5-1", wantErr: "runs backwards"},
+		{expr: "*/0 * * * *", wantErr: "invalid step"},
+		{expr: "@fortnightly", wantErr: "unknown macro"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseCron(tc.expr)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %+v\nwant %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseCronFunction_Timezone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tz      string
+		wantErr bool
+	}{
+		{tz: "UTC"},
+		{tz: "Europe/Berlin"},
+		{tz: "America/Argentina/Buenos_Aires"},
+		{tz: "Mars/Olympus_Mons", wantErr: true},
+		{tz: "Local", wantErr: true},
+		{tz: "", wantErr: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.tz, func(t *testing.T) {
+			t.Parallel()
+			got, err := runFunction(t, &ParseCronFunction{}, types.StringValue("0 9 * * 1"), types.StringValue(tc.tz))
+			if tc.wantErr {
+				if err == nil || err.FunctionArgument == nil || *err.FunctionArgument != 1 {
+					t.Fatalf("expected an error on the timezone argument, got %v / %v", got, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			obj, ok := got.(types.Object)
+			if !ok {
+				t.Fatalf("result is %T, want types.Object", got)
+			}
+			if tz := obj.Attributes()["timezone"]; !tz.Equal(types.StringValue(tc.tz)) {
+				t.Errorf("timezone = %v, want %q", tz, tc.tz)
+			}
+			if expr := obj.Attributes()["expression"]; !expr.Equal(types.StringValue("0 9 * * 1")) {
+				t.Errorf("expression = %v", expr)
+			}
+		})
+	}
+}
+
+func seq(from, to int64) []int64 {
+	out := make([]int64, 0, to-from+1)
+	for v := from; v <= to; v++ {
+		out = append(out, v)
+	}
+	return out
+}
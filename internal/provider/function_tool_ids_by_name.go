@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ToolIDsByNameFunction{}
+
+func NewToolIDsByNameFunction() function.Function {
+	return &ToolIDsByNameFunction{}
+}
+
+// ToolIDsByNameFunction picks tool IDs out of an installation's `tools`
+// list by name. Unlike indexing `tool_id_by_name`, a misspelt or vanished
+// tool fails the plan with the list of names that do exist.
+type ToolIDsByNameFunction struct{}
+
+// toolRef is the subset of mcpServerToolObjectType the function reads;
+// Terraform drops the other attributes when converting the argument.
+type toolRef struct {
+	ID   string `tfsdk:"id"`
+	Name string `tfsdk:"name"`
+}
+
+func (f *ToolIDsByNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "tool_ids_by_name"
+}
+
+func (f *ToolIDsByNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Look up tool IDs by tool name",
+		MarkdownDescription: "Returns the IDs of the named tools, in the order of `names`. " +
+			"`tools` is any list of objects with `id` and `name`, typically `archestra_mcp_server_installation.<n>.tools`. " +
+			"Names are the full wire names (`<server>__<tool>`).\n\n" +
+			"Fails when a name matches no tool, listing the names that are available, so a renamed or removed tool surfaces at plan time instead of as a missing assignment.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "tools",
+				MarkdownDescription: "Tools to search; each element needs `id` and `name`.",
+				ElementType: types.ObjectType{AttrTypes: map[string]attr.Type{
+					"id":   types.StringType,
+					"name": types.StringType,
+				}},
+			},
+			function.ListParameter{
+				Name:                "names",
+				MarkdownDescription: "Tool names to look up.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.ListReturn{ElementType: types.StringType},
+	}
+}
+
+func (f *ToolIDsByNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var tools []toolRef
+	var names []string
+	resp.Error = req.Arguments.Get(ctx, &tools, &names)
+	if resp.Error != nil {
+		return
+	}
+
+	ids, err := toolIDsByName(tools, names)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, ids)
+}
+
+// toolIDsByName resolves names against tools, failing on the first name
+// that matches nothing.
+func toolIDsByName(tools []toolRef, names []string) ([]string, error) {
+	byName := make(map[string]string, len(tools))
+	for _, t := range tools {
+		byName[t.Name] = t.ID
+	}
+
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		id, ok := byName[name]
+		if !ok {
+			known := make([]string, 0, len(byName))
+			for n := range byName {
+				known = append(known, n)
+			}
+			if len(known) == 0 {
+				return nil, fmt.Errorf("no tool named %q; the tool list is empty (is tool discovery still running?)", name)
+			}
+			sort.Strings(known)
+			return nil, fmt.Errorf("no tool named %q; available: %s", name, strings.Join(known, ", "))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestToolIDsByNameFunction(t *testing.T) {
+	t.Parallel()
+
+	toolType := types.ObjectType{AttrTypes: map[string]attr.Type{"id": types.StringType, "name": types.StringType}}
+	tools := func(pairs ...string) attr.Value {
+		elems := make([]attr.Value, 0, len(pairs)/2)
+		for i := 0; i < len(pairs); i += 2 {
+			elems = append(elems, types.ObjectValueMust(toolType.AttrTypes, map[string]attr.Value{
+				"id":   types.StringValue(pairs[i]),
+				"name": types.StringValue(pairs[i+1]),
+			}))
+		}
+		return types.ListValueMust(toolType, elems)
+	}
+	names := func(n ...string) attr.Value {
+		elems := make([]attr.Value, len(n))
+		for i, s := range n {
+			elems[i] = types.StringValue(s)
+		}
+		return types.ListValueMust(types.StringType, elems)
+	}
+
+	installed := tools("id-read", "fs__read_file", "id-write", "fs__write_file", "id-list", "fs__list_dir")
+
+	tests := []struct {
+		name    string
+		tools   attr.Value
+		names   attr.Value
+		want    attr.Value
+		wantErr string
+	}{
+		{
+			name:  "in the order of names",
+			tools: installed,
+			names: names("fs__list_dir", "fs__read_file"),
+			want:  names("id-list", "id-read"),
+		},
+		{
+			name:  "no names",
+			tools: installed,
+			names: names(),
+			want:  names(),
+		},
+		{
+			name:    "unknown name lists the available ones",
+			tools:   installed,
+			names:   names("fs__read_file", "fs__delete_file"),
+			wantErr: `no tool named "fs__delete_file"; available: fs__list_dir, fs__read_file, fs__write_file`,
+		},
+		{
+			name:    "empty tool list",
+			tools:   tools(),
+			names:   names("fs__read_file"),
+			wantErr: "the tool list is empty",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := runFunction(t, &ToolIDsByNameFunction{}, tc.tools, tc.names)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
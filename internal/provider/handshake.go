@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiSpecVersion is the Archestra platform release internal/client was
+// generated from. Bump it together with ARCHESTRA_VERSION in
+// .github/workflows/on-pull-request.yml on every `make codegen-api-client`.
+const apiSpecVersion = "1.2.20"
+
+const envSkipCredentialsValidation = "ARCHESTRA_SKIP_CREDENTIALS_VALIDATION"
+
+const envOrganizationID = "ARCHESTRA_ORGANIZATION_ID"
+
+// validateCredentials makes one cheap authenticated call so a wrong key or
+// base URL fails Configure with a single diagnostic instead of a 401 per
+// resource halfway through the plan. GetUserPermissions is used because
+// every key can call it regardless of role, and its result doubles as the
+// permission cache resources consult at plan time.
+func validateCredentials(ctx context.Context, apiClient *client.ClientWithResponses, baseURL string) (UserPermissions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	apiResp, err := apiClient.GetUserPermissionsWithResponse(ctx)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("base_url"),
+			"Unable to Reach Archestra API",
+			fmt.Sprintf("The provider could not connect to %s to validate its credentials: %s\n\n"+
+				"Check base_url / ARCHESTRA_BASE_URL, or set skip_credentials_validation = true to defer the check to the first API call.",
+				baseURL, err),
+		)
+		return nil, diags
+	}
+
+	switch apiResp.StatusCode() {
+	case http.StatusOK:
+		return permissionsFromAPI(apiResp.JSON200), diags
+	case http.StatusUnauthorized:
+		diags.AddAttributeError(
+			path.Root("api_key"),
+			"Invalid Archestra API Key",
+			fmt.Sprintf("The Archestra API at %s rejected the configured API key (401 Unauthorized). "+
+				"Check api_key / ARCHESTRA_API_KEY — the key may be mistyped, revoked, or minted on a different Archestra deployment.", baseURL),
+		)
+	default:
+		// A 404 here almost always means base_url points at the frontend
+		// (port 3000) rather than the API (port 9000).
+		diags.AddAttributeError(
+			path.Root("base_url"),
+			"Unexpected Archestra API Response",
+			fmt.Sprintf("Validating credentials against %s returned status %d: %s\n\n"+
+				"Check that base_url points at the Archestra API rather than the web UI.",
+				baseURL, apiResp.StatusCode(), string(apiResp.Body)),
+		)
+	}
+	return nil, diags
+}
+
+// resolveOrganization returns the organization the API key acts in. The
+// backend selects the organization from the key itself and has no header or
+// parameter to pick another, so a configured organization_id is an
+// assertion, not a selector: a key from another organization fails here
+// rather than writing into the wrong one.
+//
+// A configured organization_id that can't be checked, because the lookup
+// fails or the key lacks `organization: read`, fails too. Without one, a
+// failed lookup returns "", which disables the per-resource organization
+// checks.
+func resolveOrganization(ctx context.Context, apiClient *client.ClientWithResponses, configured string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	apiResp, err := apiClient.GetOrganizationWithResponse(ctx)
+	if err != nil || apiResp.JSON200 == nil {
+		if configured == "" {
+			tflog.Debug(ctx, "organization lookup failed; organization checks disabled", map[string]any{"error": fmt.Sprint(err)})
+			return "", diags
+		}
+		reason := fmt.Sprint(err)
+		if err == nil {
+			reason = fmt.Sprintf("status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+		}
+		diags.AddAttributeError(
+			path.Root("organization_id"),
+			"Unable to Verify Archestra Organization",
+			fmt.Sprintf("organization_id is %q, but looking up the API key's organization failed: %s\n\n"+
+				"The key needs the `organization: read` permission for the provider to check it. "+
+				"Grant it, or unset organization_id to skip the check.", configured, reason),
+		)
+		return "", diags
+	}
+
+	actual := apiResp.JSON200.Id
+	if configured != "" && configured != actual {
+		diags.AddAttributeError(
+			path.Root("organization_id"),
+			"Archestra Organization Mismatch",
+			fmt.Sprintf("The configured API key belongs to organization %q, but organization_id is %q. "+
+				"Archestra API keys are scoped to a single organization: use a key minted in organization %q, "+
+				"or declare one provider alias per organization.", actual, configured, configured),
+		)
+		return "", diags
+	}
+	return actual, diags
+}
+
+func permissionsFromAPI(apiPerms *map[string][]client.GetUserPermissions200) UserPermissions {
+	out := UserPermissions{}
+	if apiPerms == nil {
+		return out
+	}
+	for rbacResource, actions := range *apiPerms {
+		for _, a := range actions {
+			out[rbacResource] = append(out[rbacResource], string(a))
+		}
+	}
+	return out
+}
+
+// checkBackendVersion warns when the backend predates the spec the client was
+// generated from. The Health tag is excluded from codegen (oapi-config.yaml),
+// so /health is called directly. Any failure here is logged and swallowed:
+// the credential check has already proven the API is reachable, and an
+// unparseable version is no reason to block a plan.
+func checkBackendVersion(ctx context.Context, httpClient *http.Client, baseURL string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	backendVersion, err := fetchBackendVersion(ctx, httpClient, baseURL)
+	if err != nil {
+		tflog.Debug(ctx, "skipping Archestra backend version check", map[string]any{"error": err.Error()})
+		return diags
+	}
+
+	cmp, ok := compareVersions(backendVersion, apiSpecVersion)
+	if !ok {
+		tflog.Debug(ctx, "skipping Archestra backend version check: unparseable version", map[string]any{"version": backendVersion})
+		return diags
+	}
+	if cmp < 0 {
+		diags.AddWarning(
+			"Archestra Backend Older Than Provider",
+			fmt.Sprintf("The Archestra backend at %s reports version %s, but this provider was built against the %s API. "+
+				"Resources that rely on newer endpoints or fields may fail with 400 or 404 errors. "+
+				"Upgrade the backend, or pin a provider release built for %s.",
+				baseURL, backendVersion, apiSpecVersion, backendVersion),
+		)
+	}
+	return diags
+}
+
+func fetchBackendVersion(ctx context.Context, httpClient *http.Client, baseURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(baseURL, "/")+"/health", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET /health returned status %d", resp.StatusCode)
+	}
+	var health struct {
+		Version string `json:"version"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return "", fmt.Errorf("decode /health response: %w", err)
+	}
+	if health.Version == "" {
+		return "", fmt.Errorf("/health response has no version field")
+	}
+	return health.Version, nil
+}
+
+// compareVersions compares the MAJOR.MINOR.PATCH core of two release
+// versions, ignoring a leading "v" and any pre-release / build suffix.
+// ok is false when either side doesn't parse.
+func compareVersions(a, b string) (cmp int, ok bool) {
+	pa, okA := parseVersionCore(a)
+	pb, okB := parseVersionCore(b)
+	if !okA || !okB {
+		return 0, false
+	}
+	for i := range pa {
+		switch {
+		case pa[i] < pb[i]:
+			return -1, true
+		case pa[i] > pb[i]:
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+func parseVersionCore(v string) ([3]int, bool) {
+	var out [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return out, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
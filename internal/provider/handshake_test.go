@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+)
+
+func newHandshakeServer(t *testing.T, permissionsStatus int, healthBody string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/user/permissions":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(permissionsStatus)
+			if permissionsStatus == http.StatusOK {
+				_, _ = w.Write([]byte(`{"agent":["read","create"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"error":{"message":"nope","type":"api_authentication_error"}}`))
+		case "/api/organization":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"org-a","name":"Acme"}`))
+		case "/health":
+			if healthBody == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(healthBody))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestValidateCredentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantError string
+	}{
+		{name: "valid key", status: http.StatusOK},
+		{name: "rejected key", status: http.StatusUnauthorized, wantError: "Invalid Archestra API Key"},
+		{name: "frontend url", status: http.StatusNotFound, wantError: "Unexpected Archestra API Response"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newHandshakeServer(t, tc.status, "")
+			apiClient, err := client.NewClientWithResponses(server.URL)
+			if err != nil {
+				t.Fatalf("NewClientWithResponses: %v", err)
+			}
+
+			_, diags := validateCredentials(t.Context(), apiClient, server.URL)
+			if tc.wantError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected exactly one error, got %v", diags)
+			}
+			if got := diags.Errors()[0].Summary(); got != tc.wantError {
+				t.Errorf("summary = %q, want %q", got, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestValidateCredentials_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	apiClient, err := client.NewClientWithResponses(url)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+	_, diags := validateCredentials(t.Context(), apiClient, url)
+	if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Unable to Reach Archestra API" {
+		t.Fatalf("expected a single unreachable error, got %v", diags)
+	}
+}
+
+func TestResolveOrganization(t *testing.T) {
+	server := newHandshakeServer(t, http.StatusOK, "")
+	apiClient, err := client.NewClientWithResponses(server.URL)
+	if err != nil {
+		t.Fatalf("NewClientWithResponses: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		configured string
+		want       string
+		wantError  bool
+	}{
+		{name: "unset resolves from key", want: "org-a"},
+		{name: "matching", configured: "org-a", want: "org-a"},
+		{name: "key from another organization", configured: "org-b", wantError: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, diags := resolveOrganization(t.Context(), apiClient, tc.configured)
+			if diags.HasError() != tc.wantError {
+				t.Fatalf("error = %v, want %v (%v)", diags.HasError(), tc.wantError, diags)
+			}
+			if got != tc.want {
+				t.Errorf("organization = %q, want %q", got, tc.want)
+			}
+		})
+	}
+
+	t.Run("lookup not permitted", func(t *testing.T) {
+		forbidden := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		t.Cleanup(forbidden.Close)
+		apiClient, err := client.NewClientWithResponses(forbidden.URL)
+		if err != nil {
+			t.Fatalf("NewClientWithResponses: %v", err)
+		}
+		got, diags := resolveOrganization(t.Context(), apiClient, "")
+		if diags.HasError() || got != "" {
+			t.Errorf("expected organization checks disabled, got %q (%v)", got, diags)
+		}
+		_, diags = resolveOrganization(t.Context(), apiClient, "org-b")
+		if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Unable to Verify Archestra Organization" {
+			t.Errorf("expected configured organization_id to fail unverified, got %v", diags)
+		}
+	})
+
+	t.Run("lookup unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		url := server.URL
+		server.Close()
+		apiClient, err := client.NewClientWithResponses(url)
+		if err != nil {
+			t.Fatalf("NewClientWithResponses: %v", err)
+		}
+		_, diags := resolveOrganization(t.Context(), apiClient, "org-a")
+		if diags.ErrorsCount() != 1 || diags.Errors()[0].Summary() != "Unable to Verify Archestra Organization" {
+			t.Errorf("expected configured organization_id to fail unverified, got %v", diags)
+		}
+	})
+}
+
+func TestCheckBackendVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		healthBody  string
+		wantWarning bool
+	}{
+		{name: "older backend warns", healthBody: `{"name":"Archestra","status":"ok","version":"1.0.3"}`, wantWarning: true},
+		{name: "same version", healthBody: `{"version":"` + apiSpecVersion + `"}`},
+		{name: "newer backend", healthBody: `{"version":"v99.0.0"}`},
+		{name: "no health endpoint", healthBody: ""},
+		{name: "unparseable version", healthBody: `{"version":"dev"}`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newHandshakeServer(t, http.StatusOK, tc.healthBody)
+
+			diags := checkBackendVersion(t.Context(), server.Client(), server.URL)
+			if diags.HasError() {
+				t.Fatalf("version check must never error, got %v", diags)
+			}
+			if got := diags.WarningsCount() > 0; got != tc.wantWarning {
+				t.Errorf("warning emitted = %v, want %v (%v)", got, tc.wantWarning, diags)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		want   int
+		wantOK bool
+	}{
+		{a: "1.2.20", b: "1.2.20", want: 0, wantOK: true},
+		{a: "v1.2.19", b: "1.2.20", want: -1, wantOK: true},
+		{a: "1.10.0", b: "1.9.9", want: 1, wantOK: true},
+		{a: "2.0.0-rc.1", b: "1.2.20", want: 1, wantOK: true},
+		{a: "1.2.20+build.7", b: "1.2.20", want: 0, wantOK: true},
+		{a: "1.2", b: "1.2.20", wantOK: false},
+		{a: "latest", b: "1.2.20", wantOK: false},
+	}
+	for _, tc := range tests {
+		got, ok := compareVersions(tc.a, tc.b)
+		if ok != tc.wantOK {
+			t.Errorf("compareVersions(%q, %q) ok = %v, want %v", tc.a, tc.b, ok, tc.wantOK)
+			continue
+		}
+		if ok && got != tc.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -179,7 +179,15 @@ func mapIdentityProviderResponse(rawBody any, target *IdentityProviderResourceMo
 	target.UserID = stringValueOrNull(api.UserId)
 
 	if api.OidcConfig != nil {
+		prior := target.OidcConfig
 		target.OidcConfig = mapIdentityProviderOidcConfig(api.OidcConfig)
+		// The backend echoes the secret even when it was sent through
+		// client_secret_wo; keep it out of state for as long as the
+		// write-only variant is in use.
+		if prior != nil && !prior.ClientSecretWOVersion.IsNull() {
+			target.OidcConfig.ClientSecret = types.StringNull()
+			target.OidcConfig.ClientSecretWOVersion = prior.ClientSecretWOVersion
+		}
 	} else {
 		target.OidcConfig = nil
 	}
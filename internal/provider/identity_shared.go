@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Resource identity is what `import` blocks with `identity = {...}` and
+// `terraform query` list results address objects by. Every identity
+// attribute mirrors the same-named state attribute, so resources never
+// build identity by hand: syncIdentity copies it across after each
+// State.Set in Create / Read / Update.
+//
+// Read also syncs from the *prior* state before any API call. The framework
+// rejects a Read that returns without identity, and a 404 -> RemoveResource
+// on state written by an older provider (no identity yet) would otherwise
+// fail the refresh instead of planning a recreate.
+
+// idIdentitySchema is the identity of every object addressed by its
+// backend UUID alone.
+func idIdentitySchema(description string) identityschema.Schema {
+	return compositeIdentitySchema(map[string]string{"id": description})
+}
+
+// compositeIdentitySchema is the identity of an object addressed by several
+// state attributes (an agent + tool pair, a team + group, ...). Keys are
+// the state attribute names, values their descriptions.
+func compositeIdentitySchema(attributes map[string]string) identityschema.Schema {
+	s := identityschema.Schema{Attributes: map[string]identityschema.Attribute{}}
+	for name, description := range attributes {
+		s.Attributes[name] = identityschema.StringAttribute{
+			RequiredForImport: true,
+			Description:       description,
+		}
+	}
+	return s
+}
+
+// syncIdentity copies each identity attribute from the same-named attribute
+// of state. A nil identity (no identity schema) or removed state is a no-op.
+func syncIdentity(ctx context.Context, state tfsdk.State, identity *tfsdk.ResourceIdentity, diags *diag.Diagnostics) {
+	if identity == nil || state.Raw.IsNull() || diags.HasError() {
+		return
+	}
+	for name := range identity.Schema.GetAttributes() {
+		var v types.String
+		diags.Append(state.GetAttribute(ctx, path.Root(name), &v)...)
+		if diags.HasError() {
+			return
+		}
+		if v.IsUnknown() {
+			continue
+		}
+		diags.Append(identity.SetAttribute(ctx, path.Root(name), v)...)
+	}
+}
+
+// importFromIdentity handles an `import` block with `identity = {...}` by
+// copying each identity attribute into the same-named state attribute. It
+// reports false for an ID-based import, which the caller parses as before;
+// on true the caller only has to derive what identity does not carry
+// (typically the composite `id`).
+func importFromIdentity(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) bool {
+	if req.ID != "" || req.Identity == nil {
+		return false
+	}
+	for name := range req.Identity.Schema.GetAttributes() {
+		var v types.String
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root(name), &v)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(name), v)...)
+	}
+	return true
+}
+
+// setCompositeImportID derives the composite `id` after importFromIdentity
+// by joining the named state attributes with sep, matching the format an
+// ID-based import of the same resource takes.
+func setCompositeImportID(ctx context.Context, resp *resource.ImportStateResponse, sep string, attributes ...string) {
+	parts := make([]string, 0, len(attributes))
+	for _, name := range attributes {
+		var v types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root(name), &v)...)
+		parts = append(parts, v.ValueString())
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strings.Join(parts, sep))...)
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSyncIdentity(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	stateSchema := schema.Schema{Attributes: map[string]schema.Attribute{
+		"id":   schema.StringAttribute{Computed: true},
+		"name": schema.StringAttribute{Optional: true},
+	}}
+	identitySchema := idIdentitySchema("Test identifier.")
+	newIdentity := func() *tfsdk.ResourceIdentity {
+		return &tfsdk.ResourceIdentity{Schema: identitySchema, Raw: tftypes.NewValue(identitySchema.Type().TerraformType(ctx), nil)}
+	}
+	state := tfsdk.State{Schema: stateSchema, Raw: tftypes.NewValue(stateSchema.Type().TerraformType(ctx), nil)}
+	var diags diag.Diagnostics
+
+	// Removed state leaves identity untouched.
+	identity := newIdentity()
+	syncIdentity(ctx, state, identity, &diags)
+	if !identity.Raw.IsNull() {
+		t.Errorf("identity = %s, want null for removed state", identity.Raw)
+	}
+
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), "11111111-1111-1111-1111-111111111111")...)
+	syncIdentity(ctx, state, identity, &diags)
+	var id types.String
+	diags.Append(identity.GetAttribute(ctx, path.Root("id"), &id)...)
+	if diags.HasError() || id.ValueString() != "11111111-1111-1111-1111-111111111111" {
+		t.Errorf("identity id = %s (%v), want the state id", id, diags)
+	}
+
+	// No identity schema: nothing to do.
+	syncIdentity(ctx, state, nil, &diags)
+}
+
+func TestImportFromIdentity(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	stateSchema := schema.Schema{Attributes: map[string]schema.Attribute{
+		"id":       schema.StringAttribute{Computed: true},
+		"agent_id": schema.StringAttribute{Required: true},
+		"tool_id":  schema.StringAttribute{Required: true},
+	}}
+	identitySchema := compositeIdentitySchema(map[string]string{
+		"agent_id": "Agent identifier.",
+		"tool_id":  "Tool identifier.",
+	})
+	newResp := func() *resource.ImportStateResponse {
+		return &resource.ImportStateResponse{State: tfsdk.State{
+			Schema: stateSchema,
+			Raw:    tftypes.NewValue(stateSchema.Type().TerraformType(ctx), nil),
+		}}
+	}
+
+	// An ID-based import is left to the caller.
+	if importFromIdentity(ctx, resource.ImportStateRequest{ID: "a:t"}, newResp()) {
+		t.Error("importFromIdentity handled an ID-based import")
+	}
+
+	identity := &tfsdk.ResourceIdentity{Schema: identitySchema, Raw: tftypes.NewValue(identitySchema.Type().TerraformType(ctx), nil)}
+	var diags diag.Diagnostics
+	diags.Append(identity.SetAttribute(ctx, path.Root("agent_id"), "agent")...)
+	diags.Append(identity.SetAttribute(ctx, path.Root("tool_id"), "tool")...)
+	if diags.HasError() {
+		t.Fatalf("building identity: %v", diags)
+	}
+
+	resp := newResp()
+	if !importFromIdentity(ctx, resource.ImportStateRequest{Identity: identity}, resp) {
+		t.Fatal("importFromIdentity ignored an identity import")
+	}
+	setCompositeImportID(ctx, resp, ":", "agent_id", "tool_id")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diags: %v", resp.Diagnostics)
+	}
+
+	for name, want := range map[string]string{"id": "agent:tool", "agent_id": "agent", "tool_id": "tool"} {
+		var got types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root(name), &got)...)
+		if got.ValueString() != want {
+			t.Errorf("%s = %q, want %q", name, got.ValueString(), want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// naturalKeyImport lets `terraform import` (and `import` blocks) take a
+// prefixed human-readable key such as `name:support-bot` instead of a UUID
+// dug out of the browser. Lookup resolves the key through the resource's
+// list endpoint and returns the IDs of every exact match.
+type naturalKeyImport struct {
+	// Prefix is the key selector, without the trailing colon (`name`,
+	// `provider_id`, `team`).
+	Prefix string
+	// Noun names the object in diagnostics ("agent", "team", ...).
+	Noun   string
+	Lookup func(ctx context.Context, value string) ([]string, error)
+}
+
+// importByNaturalKey resolves a `<prefix>:<value>` import ID to the single
+// matching object's ID and passes anything else through unchanged, so
+// UUID imports keep working. Zero or several matches fail the import —
+// guessing would silently adopt the wrong object.
+func importByNaturalKey(ctx context.Context, key naturalKeyImport, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	value, ok := strings.CutPrefix(req.ID, key.Prefix+":")
+	if !ok {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		return
+	}
+	if value == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Import ID %q has an empty %s. Expected `%s:<value>` or the %s's ID.", req.ID, key.Prefix, key.Prefix, key.Noun),
+		)
+		return
+	}
+
+	ids, err := key.Lookup(ctx, value)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to resolve import ID %q: %s", req.ID, err))
+		return
+	}
+
+	switch len(ids) {
+	case 0:
+		resp.Diagnostics.AddError(
+			"Import Target Not Found",
+			fmt.Sprintf("No %s with %s %q is visible to the configured API key. Natural-key matches are exact and case-sensitive.", key.Noun, key.Prefix, value),
+		)
+	case 1:
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+	default:
+		resp.Diagnostics.AddError(
+			"Ambiguous Import ID",
+			fmt.Sprintf("%d %ss have %s %q (IDs: %s). Import by ID instead.", len(ids), key.Noun, key.Prefix, value, strings.Join(ids, ", ")),
+		)
+	}
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestImportByNaturalKey(t *testing.T) {
+	t.Parallel()
+
+	importSchema := schema.Schema{Attributes: map[string]schema.Attribute{"id": schema.StringAttribute{Computed: true}}}
+	objects := map[string][]string{
+		"support-bot": {"11111111-1111-1111-1111-111111111111"},
+		"duplicate":   {"22222222-2222-2222-2222-222222222222", "33333333-3333-3333-3333-333333333333"},
+	}
+	key := naturalKeyImport{
+		Prefix: "name",
+		Noun:   "agent",
+		Lookup: func(_ context.Context, name string) ([]string, error) {
+			if name == "boom" {
+				return nil, errors.New("backend unavailable")
+			}
+			return objects[name], nil
+		},
+	}
+
+	tests := []struct {
+		name      string
+		importID  string
+		wantID    string
+		wantError string
+	}{
+		{name: "uuid passes through", importID: "44444444-4444-4444-4444-444444444444", wantID: "44444444-4444-4444-4444-444444444444"},
+		{name: "single match", importID: "name:support-bot", wantID: "11111111-1111-1111-1111-111111111111"},
+		{name: "no match", importID: "name:ghost", wantError: "Import Target Not Found"},
+		{name: "several matches", importID: "name:duplicate", wantError: "Ambiguous Import ID"},
+		{name: "empty value", importID: "name:", wantError: "Invalid Import ID"},
+		{name: "lookup failure", importID: "name:boom", wantError: "API Error"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := resource.ImportStateResponse{State: tfsdk.State{
+				Schema: importSchema,
+				Raw:    tftypes.NewValue(importSchema.Type().TerraformType(t.Context()), nil),
+			}}
+			importByNaturalKey(t.Context(), key, resource.ImportStateRequest{ID: tc.importID}, &resp)
+
+			if tc.wantError != "" {
+				if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != tc.wantError {
+					t.Fatalf("expected %q error, got %v", tc.wantError, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diags: %v", resp.Diagnostics)
+			}
+			var got types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(t.Context(), path.Root("id"), &got)...)
+			if got.ValueString() != tc.wantID {
+				t.Errorf("id = %q, want %q", got.ValueString(), tc.wantID)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Provider-level `default_labels` are merged into every labelled resource
+// (agent, LLM proxy, MCP gateway, catalog item) the same way AWS merges
+// `default_tags` into `tags_all`:
+//
+//   - `labels` stays exactly what the resource's HCL says. Read strips
+//     labels inherited from the provider defaults, so adding a default
+//     never shows up as drift on `labels`.
+//   - `effective_labels` (Computed) is the merged map ModifyPlan derives
+//     from defaults + `labels`, resource-level keys winning. It is also what
+//     the backend actually stores, so Read refreshes it from the API.
+//   - On the wire, `labels` is Synthetic and `effective_labels` carries the
+//     `labels` JSONName (encoded by encodeEffectiveLabels): merge-patch emits
+//     the label list only when the merged result changes, not when a key
+//     moves between the two sources.
+
+func effectiveLabelsSchemaAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Computed:    true,
+		ElementType: types.StringType,
+		MarkdownDescription: "All labels stored on the backend: the provider's `default_labels` merged with `labels`, " +
+			"resource-level values winning on key conflicts.",
+	}
+}
+
+// encodeEffectiveLabels turns the effective_labels map into the backend's
+// `[{key, value}]` list. Keys are sorted so the wire body is deterministic.
+func encodeEffectiveLabels(v any) any {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make([]any, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, map[string]any{"key": k, "value": m[k]})
+	}
+	return out
+}
+
+// labelCollection is the common surface of types.Set (agent family) and
+// types.List (catalog items) that planEffectiveLabels needs from `labels`.
+type labelCollection interface {
+	IsNull() bool
+	IsUnknown() bool
+	ElementsAs(ctx context.Context, target interface{}, allowUnhandled bool) diag.Diagnostics
+}
+
+// planEffectiveLabels sets the planned `effective_labels` to
+// default_labels ∪ labels. Unknown label entries make the whole map
+// unknown; an empty merge plans null, matching what Read stores for an
+// unlabelled object. Callers pass the planned `labels` attribute and skip
+// destroy plans.
+func planEffectiveLabels(ctx context.Context, providerData *ArchestraProviderData, labels labelCollection, resp *resource.ModifyPlanResponse) {
+	effective := path.Root("effective_labels")
+	if labels.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, effective, types.MapUnknown(types.StringType))...)
+		return
+	}
+	var elements []AgentLabelModel
+	if !labels.IsNull() {
+		resp.Diagnostics.Append(labels.ElementsAs(ctx, &elements, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	merged := map[string]string{}
+	for k, v := range providerData.defaultLabels() {
+		merged[k] = v
+	}
+	for _, l := range elements {
+		if l.Key.IsUnknown() || l.Value.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, effective, types.MapUnknown(types.StringType))...)
+			return
+		}
+		merged[l.Key.ValueString()] = l.Value.ValueString()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, effective, labelMapValue(ctx, merged, &resp.Diagnostics))...)
+}
+
+// labelMapValue builds the effective_labels value, collapsing an empty map
+// to null.
+func labelMapValue(ctx context.Context, labels map[string]string, diags *diag.Diagnostics) types.Map {
+	if len(labels) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	m, d := types.MapValueFrom(ctx, types.StringType, labels)
+	diags.Append(d...)
+	return m
+}
+
+// isInheritedDefaultLabel reports whether an API label came from the
+// provider's default_labels rather than the resource's own `labels`. A key
+// the resource configures itself is never inherited, even when the values
+// happen to match.
+func isInheritedDefaultLabel(key, value string, configuredKeys map[string]bool, defaults map[string]string) bool {
+	if configuredKeys[key] {
+		return false
+	}
+	v, ok := defaults[key]
+	return ok && v == value
+}
+
+// ownAgentLabels drops the API labels inherited from default_labels, leaving
+// what flattenAgentLabels should copy into `labels`. prior is the plan or
+// state `labels` the resource configured.
+func ownAgentLabels(prior []AgentLabelModel, apiLabels []agentAPILabel, defaults map[string]string) []agentAPILabel {
+	configured := labelKeys(prior)
+	out := make([]agentAPILabel, 0, len(apiLabels))
+	for _, l := range apiLabels {
+		if !isInheritedDefaultLabel(l.Key, l.Value, configured, defaults) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// agentEffectiveLabels flattens every API label into `effective_labels`.
+func agentEffectiveLabels(ctx context.Context, apiLabels []agentAPILabel, diags *diag.Diagnostics) types.Map {
+	m := make(map[string]string, len(apiLabels))
+	for _, l := range apiLabels {
+		m[l.Key] = l.Value
+	}
+	return labelMapValue(ctx, m, diags)
+}
+
+// splitLabelList is the list-typed counterpart of ownAgentLabels +
+// agentEffectiveLabels, for resources whose `labels` is a ListNestedAttribute
+// of {key, value}. all is every API label already mapped to that list type.
+func splitLabelList(ctx context.Context, all, prior types.List, defaults map[string]string, diags *diag.Diagnostics) (own types.List, effective types.Map) {
+	var labels, priorLabels []AgentLabelModel
+	if !all.IsNull() {
+		diags.Append(all.ElementsAs(ctx, &labels, false)...)
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		diags.Append(prior.ElementsAs(ctx, &priorLabels, false)...)
+	}
+	if diags.HasError() {
+		return all, types.MapNull(types.StringType)
+	}
+
+	configured := labelKeys(priorLabels)
+	m := make(map[string]string, len(labels))
+	values := make([]attr.Value, 0, len(labels))
+	for i, l := range labels {
+		m[l.Key.ValueString()] = l.Value.ValueString()
+		if !isInheritedDefaultLabel(l.Key.ValueString(), l.Value.ValueString(), configured, defaults) {
+			values = append(values, all.Elements()[i])
+		}
+	}
+	effective = labelMapValue(ctx, m, diags)
+
+	elemType := all.ElementType(ctx)
+	if len(values) == 0 {
+		return types.ListNull(elemType), effective
+	}
+	own, d := types.ListValue(elemType, values)
+	diags.Append(d...)
+	return own, effective
+}
+
+func labelKeys(labels []AgentLabelModel) map[string]bool {
+	keys := make(map[string]bool, len(labels))
+	for _, l := range labels {
+		keys[l.Key.ValueString()] = true
+	}
+	return keys
+}
+
+func (d *ArchestraProviderData) defaultLabels() map[string]string {
+	if d == nil {
+		return nil
+	}
+	return d.DefaultLabels
+}
@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestEncodeEffectiveLabels(t *testing.T) {
+	t.Parallel()
+
+	got := encodeEffectiveLabels(map[string]any{"team": "platform", "env": "prod"})
+	want := []any{
+		map[string]any{"key": "env", "value": "prod"},
+		map[string]any{"key": "team", "value": "platform"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("encodeEffectiveLabels = %v, want %v", got, want)
+	}
+}
+
+func TestOwnAgentLabels(t *testing.T) {
+	t.Parallel()
+
+	defaults := map[string]string{"env": "prod", "owner": "platform", "tier": "critical"}
+	api := []agentAPILabel{
+		{Key: "env", Value: "prod"},      // inherited default
+		{Key: "owner", Value: "search"},  // default key, overridden value
+		{Key: "team", Value: "support"},  // resource-only key
+		{Key: "tier", Value: "critical"}, // configured below, same value as the default
+	}
+	prior := []AgentLabelModel{
+		{Key: types.StringValue("team"), Value: types.StringValue("support")},
+		{Key: types.StringValue("tier"), Value: types.StringValue("critical")},
+	}
+
+	got := ownAgentLabels(prior, api, defaults)
+	var keys []string
+	for _, l := range got {
+		keys = append(keys, l.Key)
+	}
+	if want := []string{"owner", "team", "tier"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("own label keys = %v, want %v", keys, want)
+	}
+
+	var diags diag.Diagnostics
+	effective := agentEffectiveLabels(t.Context(), api, &diags)
+	if diags.HasError() || len(effective.Elements()) != 4 {
+		t.Errorf("effective labels = %v (%v), want all 4 API labels", effective, diags)
+	}
+	if got := agentEffectiveLabels(t.Context(), nil, &diags); !got.IsNull() {
+		t.Errorf("no API labels should flatten to null, got %v", got)
+	}
+}
+
+func TestSplitLabelList(t *testing.T) {
+	t.Parallel()
+
+	objType := types.ObjectType{AttrTypes: labelAttrTypes}
+	label := func(k, v string) attr.Value {
+		return types.ObjectValueMust(labelAttrTypes, map[string]attr.Value{"key": types.StringValue(k), "value": types.StringValue(v)})
+	}
+	all := types.ListValueMust(objType, []attr.Value{label("env", "prod"), label("team", "infra")})
+	defaults := map[string]string{"env": "prod"}
+
+	var diags diag.Diagnostics
+	own, effective := splitLabelList(t.Context(), all, types.ListNull(objType), defaults, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+	if len(own.Elements()) != 1 || !own.Elements()[0].Equal(label("team", "infra")) {
+		t.Errorf("own = %v, want only team=infra", own)
+	}
+	if len(effective.Elements()) != 2 {
+		t.Errorf("effective = %v, want both labels", effective)
+	}
+
+	// Only defaults on the backend and nothing configured: labels stays null.
+	onlyDefault := types.ListValueMust(objType, []attr.Value{label("env", "prod")})
+	own, _ = splitLabelList(t.Context(), onlyDefault, types.ListNull(objType), defaults, &diags)
+	if !own.IsNull() {
+		t.Errorf("own = %v, want null", own)
+	}
+}
+
+// TestMergePatch_EffectiveLabels pins the wire contract: labels moving
+// between default_labels and the resource's own `labels` without changing
+// the merged set send nothing; a real change sends the whole merged list.
+func TestMergePatch_EffectiveLabels(t *testing.T) {
+	t.Parallel()
+
+	labelT := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"key": tftypes.String, "value": tftypes.String}}
+	objT := map[string]tftypes.Type{
+		"labels":           tftypes.Set{ElementType: labelT},
+		"effective_labels": tftypes.Map{ElementType: tftypes.String},
+	}
+	spec := []AttrSpec{
+		{TFName: "labels", JSONName: "labels", Kind: Synthetic},
+		{TFName: "effective_labels", JSONName: "labels", Kind: Map, Encoder: encodeEffectiveLabels},
+	}
+	labels := func(kv ...string) tftypes.Value {
+		var elems []tftypes.Value
+		for i := 0; i < len(kv); i += 2 {
+			elems = append(elems, tftypes.NewValue(labelT, map[string]tftypes.Value{
+				"key":   tftypes.NewValue(tftypes.String, kv[i]),
+				"value": tftypes.NewValue(tftypes.String, kv[i+1]),
+			}))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: labelT}, elems)
+	}
+	effective := func(m map[string]string) tftypes.Value {
+		vals := map[string]tftypes.Value{}
+		for k, v := range m {
+			vals[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, vals)
+	}
+
+	prior := objVal(objT, map[string]tftypes.Value{
+		"labels":           labels(),
+		"effective_labels": effective(map[string]string{"env": "prod"}),
+	})
+
+	// env moves from default_labels into the resource's own labels.
+	moved := objVal(objT, map[string]tftypes.Value{
+		"labels":           labels("env", "prod"),
+		"effective_labels": effective(map[string]string{"env": "prod"}),
+	})
+	var diags diag.Diagnostics
+	if got := MergePatch(t.Context(), moved, prior, spec, &diags); len(got) != 0 {
+		t.Errorf("moving a label between sources should send nothing, got %v", got)
+	}
+
+	changed := objVal(objT, map[string]tftypes.Value{
+		"labels":           labels("team", "infra"),
+		"effective_labels": effective(map[string]string{"env": "prod", "team": "infra"}),
+	})
+	got := MergePatch(t.Context(), changed, prior, spec, &diags)
+	want := map[string]any{"labels": []any{
+		map[string]any{"key": "env", "value": "prod"},
+		map[string]any{"key": "team", "value": "infra"},
+	}}
+	if diags.HasError() || !reflect.DeepEqual(got, want) {
+		t.Errorf("MergePatch = %v (%v), want %v", got, diags, want)
+	}
+}
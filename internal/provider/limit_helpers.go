@@ -1,6 +1,11 @@
 package provider
 
 import (
+	"math"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
 )
 
@@ -18,9 +23,44 @@ func (r *LimitResource) AttrSpecs() []AttrSpec { return limitAttrSpec }
 
 func (r *LimitResource) APIShape() any { return client.GetLimitResponse{} }
 
-// KnownIntentionallySkipped: createdAt/updatedAt are audit timestamps;
-// lastCleanup is a backend bookkeeping field tracking the limit cleanup
-// scheduler — debug-only, not user-facing.
+// KnownIntentionallySkipped: empty. lastCleanup is the computed-only
+// last_cleanup attribute; the usage attributes come from GetLimits'
+// modelUsage, which GetLimitResponse does not carry.
 func (r *LimitResource) KnownIntentionallySkipped() []string {
-	return []string{"createdAt", "updatedAt", "lastCleanup"}
+	return nil
+}
+
+// limitModelUsage is GetLimits' per-model spend against a token_cost limit.
+// Only the list endpoint reports it; GetLimit and the write endpoints don't.
+type limitModelUsage = []struct {
+	Cost      float32 `json:"cost"`
+	Model     string  `json:"model"`
+	TokensIn  float32 `json:"tokensIn"`
+	TokensOut float32 `json:"tokensOut"`
+}
+
+// limitUsage derives current_usage, remaining and utilization_percent from a
+// GetLimits row. The backend reports usage only for token_cost limits, as
+// per-model cost since the last cleanup; without it all three are null.
+func limitUsage(limitValue int, modelUsage *limitModelUsage) (current, remaining, utilization types.Float64) {
+	if modelUsage == nil {
+		return types.Float64Null(), types.Float64Null(), types.Float64Null()
+	}
+	var used float64
+	for _, u := range *modelUsage {
+		used += widenFloat32(u.Cost)
+	}
+	limit := float64(limitValue)
+	utilization = types.Float64Null()
+	if limit > 0 {
+		utilization = types.Float64Value(math.Round(used/limit*10000) / 100)
+	}
+	return types.Float64Value(used), types.Float64Value(math.Max(limit-used, 0)), utilization
+}
+
+// widenFloat32 converts through the shortest decimal form, so a wire 0.1
+// lands in state as 0.1 rather than 0.10000000149011612.
+func widenFloat32(f float32) float64 {
+	v, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64)
+	return v
 }
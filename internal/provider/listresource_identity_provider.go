@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+)
+
+var _ list.ListResourceWithConfigure = &IdentityProviderResource{}
+
+func NewIdentityProviderListResource() list.ListResource { return &IdentityProviderResource{} }
+
+func (r *IdentityProviderResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists the organization's identity providers. The backend list endpoint takes no filters.",
+	}
+}
+
+func (r *IdentityProviderResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	apiResp, err := r.client.GetIdentityProvidersWithResponse(ctx)
+	if err == nil && apiResp.JSON200 == nil {
+		err = fmt.Errorf("GetIdentityProviders: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+	}
+	if err != nil {
+		stream.Results = listAPIError("identity providers", err)
+		return
+	}
+
+	objects := make([]listedObject, 0, len(*apiResp.JSON200))
+	for _, idp := range *apiResp.JSON200 {
+		objects = append(objects, listedObject{ID: idp.Id, DisplayName: idp.ProviderId})
+	}
+	streamListedObjects(ctx, r, req, stream, objects)
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResourceWithConfigure = &LimitResource{}
+
+func NewLimitListResource() list.ListResource { return &LimitResource{} }
+
+// LimitListResourceModel is the limit `list` block; every filter is a
+// GetLimits query parameter.
+type LimitListResourceModel struct {
+	EntityType types.String `tfsdk:"entity_type"`
+	EntityID   types.String `tfsdk:"entity_id"`
+	LimitType  types.String `tfsdk:"limit_type"`
+}
+
+func (r *LimitResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists the organization's usage limits.",
+		Attributes: map[string]listschema.Attribute{
+			"entity_type": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list limits on this entity type: `organization`, `team` or `agent`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("organization", "team", "agent"),
+				},
+			},
+			"entity_id": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list limits on this entity.",
+			},
+			"limit_type": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list limits of this type: `token_cost`, `tool_calls` or `mcp_server_calls`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("token_cost", "tool_calls", "mcp_server_calls"),
+				},
+			},
+		},
+	}
+}
+
+func (r *LimitResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var cfg LimitListResourceModel
+	if !decodeListConfig(ctx, req, &cfg, stream) {
+		return
+	}
+
+	params := client.GetLimitsParams{EntityId: optionalFilter(cfg.EntityID)}
+	if v := optionalFilter(cfg.EntityType); v != nil {
+		entityType := client.GetLimitsParamsEntityType(*v)
+		params.EntityType = &entityType
+	}
+	if v := optionalFilter(cfg.LimitType); v != nil {
+		limitType := client.GetLimitsParamsLimitType(*v)
+		params.LimitType = &limitType
+	}
+	apiResp, err := r.client.GetLimitsWithResponse(ctx, &params)
+	if err == nil && apiResp.JSON200 == nil {
+		err = fmt.Errorf("GetLimits: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+	}
+	if err != nil {
+		stream.Results = listAPIError("limits", err)
+		return
+	}
+
+	objects := make([]listedObject, 0, len(*apiResp.JSON200))
+	for _, l := range *apiResp.JSON200 {
+		objects = append(objects, listedObject{
+			ID:          l.Id.String(),
+			DisplayName: fmt.Sprintf("%s on %s %s", l.LimitType, l.EntityType, l.EntityId),
+		})
+	}
+	streamListedObjects(ctx, r, req, stream, objects)
+}
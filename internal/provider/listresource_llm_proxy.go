@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+var _ list.ListResourceWithConfigure = &LlmProxyResource{}
+
+func NewLlmProxyListResource() list.ListResource { return &LlmProxyResource{} }
+
+func (r *LlmProxyResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = agentListSchema("LLM proxies")
+}
+
+func (r *LlmProxyResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var cfg AgentListResourceModel
+	if !decodeListConfig(ctx, req, &cfg, stream) {
+		return
+	}
+	objects, err := listAgents(ctx, r.client, client.GetAgentsParamsAgentTypeLlmProxy, cfg)
+	if err != nil {
+		stream.Results = listAPIError("LLM proxies", err)
+		return
+	}
+	streamListedObjects(ctx, r, req, stream, objects)
+}
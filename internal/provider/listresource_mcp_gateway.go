@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+var _ list.ListResourceWithConfigure = &McpGatewayResource{}
+
+func NewMcpGatewayListResource() list.ListResource { return &McpGatewayResource{} }
+
+func (r *McpGatewayResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = agentListSchema("MCP gateways")
+}
+
+func (r *McpGatewayResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var cfg AgentListResourceModel
+	if !decodeListConfig(ctx, req, &cfg, stream) {
+		return
+	}
+	objects, err := listAgents(ctx, r.client, client.GetAgentsParamsAgentTypeMcpGateway, cfg)
+	if err != nil {
+		stream.Results = listAPIError("MCP gateways", err)
+		return
+	}
+	streamListedObjects(ctx, r, req, stream, objects)
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResourceWithConfigure = &MCPServerRegistryResource{}
+
+func NewMCPServerRegistryListResource() list.ListResource { return &MCPServerRegistryResource{} }
+
+// MCPServerRegistryListResourceModel is the catalog item `list` block.
+type MCPServerRegistryListResourceModel struct {
+	Name   types.String      `tfsdk:"name"`
+	Scope  types.String      `tfsdk:"scope"`
+	Labels map[string]string `tfsdk:"labels"`
+}
+
+func (r *MCPServerRegistryResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists MCP registry catalog items visible to the provider's API key.",
+		Attributes: map[string]listschema.Attribute{
+			"name": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the catalog item with exactly this name.",
+			},
+			"scope": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list catalog items with this scope: `personal`, `team` or `org`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("personal", "team", "org"),
+				},
+			},
+			"labels": listschema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Only list catalog items carrying every one of these labels.",
+			},
+		},
+	}
+}
+
+func (r *MCPServerRegistryResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var cfg MCPServerRegistryListResourceModel
+	if !decodeListConfig(ctx, req, &cfg, stream) {
+		return
+	}
+
+	apiResp, err := r.client.GetInternalMcpCatalogWithResponse(ctx)
+	if err == nil && apiResp.JSON200 == nil {
+		err = fmt.Errorf("GetInternalMcpCatalog: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+	}
+	if err != nil {
+		stream.Results = listAPIError("catalog items", err)
+		return
+	}
+
+	var objects []listedObject
+	for _, item := range *apiResp.JSON200 {
+		labels := make(map[string]string, len(item.Labels))
+		for _, l := range item.Labels {
+			labels[l.Key] = l.Value
+		}
+		if !matchesFilter(cfg.Name, item.Name) || !matchesFilter(cfg.Scope, string(item.Scope)) || !hasLabels(labels, cfg.Labels) {
+			continue
+		}
+		objects = append(objects, listedObject{ID: item.Id.String(), DisplayName: item.Name})
+	}
+	streamListedObjects(ctx, r, req, stream, objects)
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResourceWithConfigure = &MCPServerResource{}
+
+func NewMCPServerListResource() list.ListResource { return &MCPServerResource{} }
+
+// MCPServerListResourceModel is the MCP server installation `list` block;
+// both filters are GetMcpServers query parameters.
+type MCPServerListResourceModel struct {
+	CatalogID types.String `tfsdk:"catalog_id"`
+	Scope     types.String `tfsdk:"scope"`
+}
+
+func (r *MCPServerResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists MCP server installations visible to the provider's API key. " +
+			"Listed installations take the backend `display_name` as `name`; see the resource's import notes.",
+		Attributes: map[string]listschema.Attribute{
+			"catalog_id": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list installations of this catalog item.",
+			},
+			"scope": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list installations with this scope: `personal`, `team` or `org`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("personal", "team", "org"),
+				},
+			},
+		},
+	}
+}
+
+func (r *MCPServerResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var cfg MCPServerListResourceModel
+	if !decodeListConfig(ctx, req, &cfg, stream) {
+		return
+	}
+
+	params := client.GetMcpServersParams{CatalogId: optionalFilter(cfg.CatalogID)}
+	if scope := optionalFilter(cfg.Scope); scope != nil {
+		s := client.GetMcpServersParamsAssignmentScope(*scope)
+		params.AssignmentScope = &s
+	}
+	apiResp, err := r.client.GetMcpServersWithResponse(ctx, &params)
+	if err == nil && apiResp.JSON200 == nil {
+		err = fmt.Errorf("GetMcpServers: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+	}
+	if err != nil {
+		stream.Results = listAPIError("MCP server installations", err)
+		return
+	}
+
+	objects := make([]listedObject, 0, len(*apiResp.JSON200))
+	for _, server := range *apiResp.JSON200 {
+		objects = append(objects, listedObject{ID: server.Id.String(), DisplayName: server.Name})
+	}
+	streamListedObjects(ctx, r, req, stream, objects)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResourceWithConfigure = &OptimizationRuleResource{}
+
+func NewOptimizationRuleListResource() list.ListResource { return &OptimizationRuleResource{} }
+
+// OptimizationRuleListResourceModel is the optimization rule `list` block.
+type OptimizationRuleListResourceModel struct {
+	EntityType  types.String `tfsdk:"entity_type"`
+	EntityID    types.String `tfsdk:"entity_id"`
+	LLMProvider types.String `tfsdk:"llm_provider"`
+}
+
+func (r *OptimizationRuleResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists the organization's optimization rules.",
+		Attributes: map[string]listschema.Attribute{
+			"entity_type": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list rules on this entity type: `organization`, `team` or `agent`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("organization", "team", "agent"),
+				},
+			},
+			"entity_id": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list rules on this entity.",
+			},
+			"llm_provider": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list rules routing against this LLM provider.",
+			},
+		},
+	}
+}
+
+func (r *OptimizationRuleResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var cfg OptimizationRuleListResourceModel
+	if !decodeListConfig(ctx, req, &cfg, stream) {
+		return
+	}
+
+	apiResp, err := r.client.GetOptimizationRulesWithResponse(ctx)
+	if err == nil && apiResp.JSON200 == nil {
+		err = fmt.Errorf("GetOptimizationRules: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+	}
+	if err != nil {
+		stream.Results = listAPIError("optimization rules", err)
+		return
+	}
+
+	var objects []listedObject
+	for _, rule := range *apiResp.JSON200 {
+		if !matchesFilter(cfg.EntityType, string(rule.EntityType)) || !matchesFilter(cfg.EntityID, rule.EntityId) || !matchesFilter(cfg.LLMProvider, string(rule.Provider)) {
+			continue
+		}
+		objects = append(objects, listedObject{
+			ID:          rule.Id.String(),
+			DisplayName: fmt.Sprintf("%s/%s on %s %s", rule.Provider, rule.TargetModel, rule.EntityType, rule.EntityId),
+		})
+	}
+	streamListedObjects(ctx, r, req, stream, objects)
+}
@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+var _ list.ListResourceWithConfigure = &ProfileResource{}
+
+func NewProfileListResource() list.ListResource { return &ProfileResource{} }
+
+func (r *ProfileResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = agentListSchema("profiles")
+}
+
+func (r *ProfileResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var cfg AgentListResourceModel
+	if !decodeListConfig(ctx, req, &cfg, stream) {
+		return
+	}
+	objects, err := listAgents(ctx, r.client, client.GetAgentsParamsAgentTypeProfile, cfg)
+	if err != nil {
+		stream.Results = listAPIError("profiles", err)
+		return
+	}
+	streamListedObjects(ctx, r, req, stream, objects)
+}
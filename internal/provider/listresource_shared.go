@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// List resources back `terraform query`: each one enumerates the backend
+// objects of a managed resource type so Terraform can generate `import`
+// blocks (from the identity) and configuration (from the resource state).
+//
+// The list methods live on the managed resource type itself, in
+// listresource_<name>.go, so a listed object's state comes from the very
+// Read that `terraform import` would run — there is no second mapping to
+// drift from the resource's. Filters mirror the backend list endpoint's
+// query parameters where it has them; the remaining filters are applied
+// client-side and say so in their description.
+
+// listedObject is one backend object a list endpoint returned.
+type listedObject struct {
+	ID          string
+	DisplayName string
+}
+
+// streamListedObjects turns the listed objects into list results, honouring
+// the request limit. With IncludeResource set each object's state is read
+// through r.Read, exactly as after an import; an object deleted between the
+// list call and the Read is skipped.
+func streamListedObjects(ctx context.Context, r resource.Resource, req list.ListRequest, stream *list.ListResultsStream, objects []listedObject) {
+	stream.Results = func(push func(list.ListResult) bool) {
+		var pushed int64
+		for _, obj := range objects {
+			if req.Limit > 0 && pushed >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = obj.DisplayName
+			result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root("id"), obj.ID)...)
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				if !readListedObject(ctx, r, req, obj.ID, &result) {
+					continue
+				}
+			}
+
+			pushed++
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// readListedObject fills result.Resource by running r.Read on a state that
+// only carries the ID. It reports false when Read removed the object.
+func readListedObject(ctx context.Context, r resource.Resource, req list.ListRequest, id string, result *list.ListResult) bool {
+	state := tfsdk.State{
+		Schema: req.ResourceSchema,
+		Raw:    tftypes.NewValue(req.ResourceSchema.Type().TerraformType(ctx), nil),
+	}
+	result.Diagnostics.Append(state.SetAttribute(ctx, path.Root("id"), id)...)
+	if result.Diagnostics.HasError() {
+		return true
+	}
+
+	readReq := resource.ReadRequest{
+		State:    state,
+		Identity: &tfsdk.ResourceIdentity{Schema: result.Identity.Schema, Raw: result.Identity.Raw.Copy()},
+	}
+	readResp := resource.ReadResponse{State: state, Identity: result.Identity}
+	r.Read(ctx, readReq, &readResp)
+	result.Diagnostics.Append(readResp.Diagnostics...)
+	if readResp.State.Raw.IsNull() && !result.Diagnostics.HasError() {
+		return false
+	}
+	result.Resource = &tfsdk.Resource{Schema: readResp.State.Schema, Raw: readResp.State.Raw}
+	return true
+}
+
+// decodeListConfig reads the `list` block into target. On failure it hands
+// the diagnostics to the stream and reports false.
+func decodeListConfig(ctx context.Context, req list.ListRequest, target any, stream *list.ListResultsStream) bool {
+	diags := req.Config.Get(ctx, target)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return false
+	}
+	return true
+}
+
+// listAPIError is the stream for a failed list call.
+func listAPIError(noun string, err error) iter.Seq[list.ListResult] {
+	var diags diag.Diagnostics
+	diags.AddError("API Error", fmt.Sprintf("Unable to list %s, got error: %s", noun, err))
+	return list.ListResultsStreamDiagnostics(diags)
+}
+
+// labelFilter renders a labels filter in the backend's list syntax,
+// `key1:value1;key2:value2`, with keys sorted so the query is stable.
+// Callers check labels with labelFilterError first.
+func labelFilter(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, k+":"+labels[k])
+	}
+	return strings.Join(parts, ";")
+}
+
+// labelFilterError reports why labels can't be expressed as a labelFilter:
+// an empty key, or a `:` or `;` in a key or value. Keys are checked in
+// sorted order so the error is stable.
+func labelFilterError(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if k == "" {
+			return errors.New("label keys must not be empty")
+		}
+		if strings.ContainsAny(k, ":;") || strings.ContainsAny(labels[k], ":;") {
+			return fmt.Errorf("label %q: keys and values must not contain ':' or ';'", k)
+		}
+	}
+	return nil
+}
+
+// labelFilterValidator rejects a `labels` list filter that labelFilter
+// would corrupt, the check archestra::label_filter runs.
+func labelFilterValidator() validator.Map {
+	return labelFilterLabels{}
+}
+
+type labelFilterLabels struct{}
+
+func (v labelFilterLabels) Description(_ context.Context) string {
+	return "label keys must be non-empty, and keys and values must not contain ':' or ';'"
+}
+
+func (v labelFilterLabels) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v labelFilterLabels) ValidateMap(_ context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	labels := map[string]string{}
+	for k, elem := range req.ConfigValue.Elements() {
+		if value, ok := elem.(basetypes.StringValue); ok && !value.IsUnknown() {
+			labels[k] = value.ValueString()
+		}
+	}
+	if err := labelFilterError(labels); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Label Filter", err.Error())
+	}
+}
+
+// hasLabels reports whether have carries every key/value pair of want; the
+// client-side counterpart of labelFilter for endpoints without one.
+func hasLabels(have, want map[string]string) bool {
+	for k, v := range want {
+		if got, ok := have[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesFilter reports whether value passes an optional string filter.
+// It and hasLabels serve the list endpoints that take no query parameters
+// (catalog items, optimization rules, tool invocation and trusted data
+// policies): those resources fetch everything and filter client-side.
+func matchesFilter(filter types.String, value string) bool {
+	return filter.IsNull() || filter.IsUnknown() || filter.ValueString() == value
+}
+
+// optionalFilter is the query-parameter form of an optional string filter.
+func optionalFilter(filter types.String) *string {
+	if filter.IsNull() || filter.IsUnknown() {
+		return nil
+	}
+	v := filter.ValueString()
+	return &v
+}
@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLabelFilter(t *testing.T) {
+	t.Parallel()
+
+	if got, want := labelFilter(map[string]string{"team": "support", "env": "prod"}), "env:prod;team:support"; got != want {
+		t.Errorf("labelFilter = %q, want %q", got, want)
+	}
+	if got := labelFilter(nil); got != "" {
+		t.Errorf("labelFilter(nil) = %q, want empty", got)
+	}
+
+	if err := labelFilterError(map[string]string{"env": "prod|staging"}); err != nil {
+		t.Errorf("labelFilterError rejected a valid filter: %v", err)
+	}
+	for _, labels := range []map[string]string{{"env": "a;b"}, {"a:b": "x"}, {"": "x"}} {
+		if labelFilterError(labels) == nil {
+			t.Errorf("labelFilterError(%v) = nil, want an error", labels)
+		}
+	}
+
+	var resp validator.MapResponse
+	labelFilterValidator().ValidateMap(t.Context(), validator.MapRequest{
+		Path:        path.Root("labels"),
+		ConfigValue: types.MapValueMust(types.StringType, map[string]attr.Value{"env": types.StringValue("prod;dev")}),
+	}, &resp)
+	if resp.Diagnostics.ErrorsCount() != 1 || resp.Diagnostics.Errors()[0].Summary() != "Invalid Label Filter" {
+		t.Errorf("labelFilterValidator diagnostics = %v, want one Invalid Label Filter error", resp.Diagnostics)
+	}
+
+	have := map[string]string{"env": "prod", "team": "support"}
+	if !hasLabels(have, map[string]string{"env": "prod"}) || !hasLabels(have, nil) {
+		t.Error("hasLabels should accept a subset")
+	}
+	if hasLabels(have, map[string]string{"env": "dev"}) || hasLabels(have, map[string]string{"tier": "1"}) {
+		t.Error("hasLabels should reject a differing or missing label")
+	}
+}
+
+// listedFake is a managed resource whose Read fills `name` from the ID and
+// removes the object with ID "gone", standing in for a 404.
+type listedFake struct{}
+
+func (listedFake) Metadata(_ context.Context, _ resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = "archestra_fake"
+}
+
+func (listedFake) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{Attributes: map[string]schema.Attribute{
+		"id":   schema.StringAttribute{Computed: true},
+		"name": schema.StringAttribute{Computed: true},
+	}}
+}
+
+func (listedFake) Create(context.Context, resource.CreateRequest, *resource.CreateResponse) {}
+func (listedFake) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {}
+func (listedFake) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {}
+
+func (listedFake) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var id types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
+	if id.ValueString() == "gone" {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), "name-"+id.ValueString())...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func TestStreamListedObjects(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	var schemaResp resource.SchemaResponse
+	listedFake{}.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	objects := []listedObject{{ID: "a", DisplayName: "A"}, {ID: "gone", DisplayName: "Gone"}, {ID: "b", DisplayName: "B"}, {ID: "c", DisplayName: "C"}}
+
+	run := func(includeResource bool, limit int64) []list.ListResult {
+		req := list.ListRequest{
+			IncludeResource:        includeResource,
+			Limit:                  limit,
+			ResourceSchema:         schemaResp.Schema,
+			ResourceIdentitySchema: idIdentitySchema("Fake identifier."),
+		}
+		var stream list.ListResultsStream
+		streamListedObjects(ctx, listedFake{}, req, &stream, objects)
+		var out []list.ListResult
+		for result := range stream.Results {
+			if result.Diagnostics.HasError() {
+				t.Fatalf("unexpected diags: %v", result.Diagnostics)
+			}
+			out = append(out, result)
+		}
+		return out
+	}
+
+	// Without resources every listed object streams, identity only.
+	if got := run(false, 0); len(got) != 4 {
+		t.Fatalf("got %d results, want 4", len(got))
+	}
+
+	// With resources the removed object is skipped and does not count
+	// against the limit.
+	got := run(true, 3)
+	if len(got) != 3 {
+		t.Fatalf("got %d results, want 3", len(got))
+	}
+	for i, want := range []string{"a", "b", "c"} {
+		var id, name types.String
+		got[i].Identity.GetAttribute(ctx, path.Root("id"), &id)
+		got[i].Resource.GetAttribute(ctx, path.Root("name"), &name)
+		if id.ValueString() != want || name.ValueString() != "name-"+want {
+			t.Errorf("result %d = (%s, %s), want id %q read through Read", i, id, name, want)
+		}
+	}
+}
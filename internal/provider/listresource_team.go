@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResourceWithConfigure = &TeamResource{}
+
+func NewTeamListResource() list.ListResource { return &TeamResource{} }
+
+// TeamListResourceModel is the team `list` block.
+type TeamListResourceModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+func (r *TeamResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists the organization's teams.",
+		Attributes: map[string]listschema.Attribute{
+			"name": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list teams whose name contains this string.",
+			},
+		},
+	}
+}
+
+func (r *TeamResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var cfg TeamListResourceModel
+	if !decodeListConfig(ctx, req, &cfg, stream) {
+		return
+	}
+
+	var objects []listedObject
+	limit, offset := 100, 0
+	for {
+		apiResp, err := r.client.GetTeamsWithResponse(ctx, &client.GetTeamsParams{Name: optionalFilter(cfg.Name), Limit: &limit, Offset: &offset})
+		if err == nil && apiResp.JSON200 == nil {
+			err = fmt.Errorf("GetTeams: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+		}
+		if err != nil {
+			stream.Results = listAPIError("teams", err)
+			return
+		}
+		for _, team := range apiResp.JSON200.Data {
+			objects = append(objects, listedObject{ID: team.Id, DisplayName: team.Name})
+		}
+		if !apiResp.JSON200.Pagination.HasNext {
+			break
+		}
+		offset += limit
+	}
+	streamListedObjects(ctx, r, req, stream, objects)
+}
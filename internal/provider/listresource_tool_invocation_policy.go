@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResourceWithConfigure = &ToolInvocationPolicyResource{}
+
+func NewToolInvocationPolicyListResource() list.ListResource { return &ToolInvocationPolicyResource{} }
+
+// ToolInvocationPolicyListResourceModel is the tool invocation policy `list` block.
+type ToolInvocationPolicyListResourceModel struct {
+	ToolID types.String `tfsdk:"tool_id"`
+	Action types.String `tfsdk:"action"`
+}
+
+func (r *ToolInvocationPolicyResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		MarkdownDescription: "Lists the organization's tool invocation policies.",
+		Attributes: map[string]listschema.Attribute{
+			"tool_id": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list policies on this tool.",
+			},
+			"action": listschema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list policies with this action.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("allow_when_context_is_untrusted", "block_when_context_is_untrusted", "block_always", "require_approval"),
+				},
+			},
+		},
+	}
+}
+
+func (r *ToolInvocationPolicyResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var cfg ToolInvocationPolicyListResourceModel
+	if !decodeListConfig(ctx, req, &cfg, stream) {
+		return
+	}
+
+	apiResp, err := r.client.GetToolInvocationPoliciesWithResponse(ctx)
+	if err == nil && apiResp.JSON200 == nil {
+		err = fmt.Errorf("GetToolInvocationPolicies: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+	}
+	if err != nil {
+		stream.Results = listAPIError("tool invocation policies", err)
+		return
+	}
+
+	var objects []listedObject
+	for _, policy := range *apiResp.JSON200 {
+		if !matchesFilter(cfg.ToolID, policy.ToolId.String()) || !matchesFilter(cfg.Action, string(policy.Action)) {
+			continue
+		}
+		objects = append(objects, listedObject{
+			ID:          policy.Id.String(),
+			DisplayName: fmt.Sprintf("%s on tool %s", policy.Action, policy.ToolId),
+		})
+	}
+	streamListedObjects(ctx, r, req, stream, objects)
+}
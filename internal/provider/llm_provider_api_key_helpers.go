@@ -12,6 +12,8 @@ var llmProviderApiKeyAttrSpec = []AttrSpec{
 	{TFName: "name", JSONName: "name", Kind: Scalar},
 	{TFName: "llm_provider", JSONName: "provider", Kind: Scalar},
 	{TFName: "api_key", JSONName: "apiKey", Kind: Scalar, Sensitive: true},
+	{TFName: "api_key_wo", JSONName: "apiKey", Kind: Scalar, Sensitive: true, WriteOnlyVersion: "api_key_wo_version"},
+	{TFName: "api_key_wo_version", Kind: Synthetic},
 	{TFName: "is_organization_default", JSONName: "isPrimary", Kind: Scalar},
 	{TFName: "base_url", JSONName: "baseUrl", Kind: Scalar},
 	{TFName: "scope", JSONName: "scope", Kind: Scalar},
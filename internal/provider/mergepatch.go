@@ -83,6 +83,11 @@ type AttrSpec struct {
 	// "explicitly clear", which matches the backend's actual semantics for
 	// non-nullable optional fields.
 	OmitOnNull bool
+	// WriteOnlyVersion marks a write-only (`_wo`) attribute and names its
+	// sibling `*_wo_version` attribute (declared Synthetic). The value is
+	// null in plan and state, so it only reaches the patch through
+	// injectWriteOnly, which copies it in from config; see writeonly_shared.go.
+	WriteOnlyVersion string
 }
 
 // MergePatch returns an RFC 7396 JSON Merge Patch representing the diff
@@ -159,7 +164,7 @@ func MergePatch(
 		}
 
 		if planV.IsNull() {
-			if spec.OmitOnNull {
+			if spec.OmitOnNull || wireFieldClaimed(spec, attrs, planFields) {
 				continue
 			}
 			out[spec.JSONName] = nil
@@ -189,6 +194,22 @@ func MergePatch(
 	return out
 }
 
+// wireFieldClaimed reports whether another attribute carrying the same wire
+// field has a value in the plan — e.g. `api_key_wo` while `api_key` goes
+// null on the switch to the write-only variant. The null must not clear the
+// field the other attribute is still managing.
+func wireFieldClaimed(spec AttrSpec, attrs []AttrSpec, planFields map[string]tftypes.Value) bool {
+	for _, other := range attrs {
+		if other.TFName == spec.TFName || other.JSONName != spec.JSONName || other.Kind == Synthetic {
+			continue
+		}
+		if v, ok := planFields[other.TFName]; ok && v.IsKnown() && !v.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
 // encodeValue converts a known, non-null tftypes.Value into a JSON-marshalable
 // Go value (bool, float64, string, []any, map[string]any). When attrs is
 // non-empty and the value is an Object, attrs drives the tfsdk → JSON name
@@ -258,6 +279,9 @@ func encodeValue(v tftypes.Value, attrs []AttrSpec) (any, error) {
 			// Spec-driven encoding: re-key tfsdk → JSON, skip null sub-fields,
 			// recurse with each child's own children spec.
 			for _, spec := range attrs {
+				if spec.Kind == Synthetic {
+					continue
+				}
 				sub, ok := fields[spec.TFName]
 				if !ok || sub.IsNull() || !sub.IsKnown() {
 					continue
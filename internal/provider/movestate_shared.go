@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Some resource pairs manage the same backend rows from different angles:
+// an `archestra_agent_tool` is one assignment of an `archestra_agent_tool_batch`,
+// and a per-tool policy without conditions is one tool of a
+// `archestra_*_policy_default`. The target of each pair implements MoveState
+// so a `moved` block re-homes the state instead of destroying and recreating
+// the rows. Movers only translate the keys the target is addressed by; the
+// refresh that follows the move runs the target's Read, which rebuilds
+// everything else from the backend.
+//
+// Movers read the source's raw JSON rather than declaring a SourceSchema, so
+// a source state of any schema version is accepted; a mover that cares about
+// the version runs the source's upgrade steps first.
+
+// stateMoverFrom builds a StateMover for moves from this provider's
+// sourceType. Moves from other types or providers are left to the next
+// mover. move fills the target attributes; identity is synced from them.
+func stateMoverFrom(
+	sourceType string,
+	move func(ctx context.Context, version int64, source map[string]any, resp *resource.MoveStateResponse),
+) resource.StateMover {
+	return resource.StateMover{
+		StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+			if req.SourceTypeName != sourceType || !strings.HasSuffix(req.SourceProviderAddress, "/archestra-ai/archestra") {
+				return
+			}
+			if req.SourceRawState == nil {
+				resp.Diagnostics.AddError("Unable to Move Resource State", "Terraform sent no source state to move.")
+				return
+			}
+
+			var source map[string]any
+			if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Move Resource State",
+					fmt.Sprintf("The %s state is not a JSON object: %s", sourceType, err),
+				)
+				return
+			}
+			move(ctx, req.SourceSchemaVersion, source, resp)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+			syncIdentity(ctx, resp.TargetState, resp.TargetIdentity, &resp.Diagnostics)
+		},
+	}
+}
+
+// setMovedState writes attrs onto the (initially null) target state. Target
+// attributes missing from attrs stay null until the post-move refresh.
+func setMovedState(ctx context.Context, resp *resource.MoveStateResponse, attrs map[string]attr.Value) {
+	for name, v := range attrs {
+		resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root(name), v)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+}
+
+// movedString reads a string attribute from raw source state; absent, null
+// and empty values come back null.
+func movedString(source map[string]any, name string) types.String {
+	if s, ok := source[name].(string); ok && s != "" {
+		return types.StringValue(s)
+	}
+	return types.StringNull()
+}
+
+// moveAgentToolToBatch turns one assignment into a batch over its
+// installation. The post-move Read widens tool_ids to every tool of that
+// installation already assigned to the agent.
+func moveAgentToolToBatch(ctx context.Context, _ int64, source map[string]any, resp *resource.MoveStateResponse) {
+	agentID, toolID, mcpServerID := movedString(source, "agent_id"), movedString(source, "tool_id"), movedString(source, "mcp_server_id")
+	if agentID.IsNull() || toolID.IsNull() {
+		resp.Diagnostics.AddError("Unable to Move Resource State", "The archestra_agent_tool state has no agent_id or tool_id.")
+		return
+	}
+	if mcpServerID.IsNull() {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			"archestra_agent_tool_batch is keyed by MCP server installation, but this archestra_agent_tool has no mcp_server_id. "+
+				"Set mcp_server_id on the archestra_agent_tool and apply before moving it.",
+		)
+		return
+	}
+	mode := movedString(source, "credential_resolution_mode")
+	if mode.IsNull() {
+		mode = types.StringValue("static")
+	}
+	toolIDs, d := types.SetValue(types.StringType, []attr.Value{toolID})
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setMovedState(ctx, resp, map[string]attr.Value{
+		"id":                         types.StringValue(agentID.ValueString() + ":" + mcpServerID.ValueString()),
+		"agent_id":                   agentID,
+		"mcp_server_id":              mcpServerID,
+		"tool_ids":                   toolIDs,
+		"credential_resolution_mode": mode,
+		"organization_id":            movedString(source, "organization_id"),
+	})
+}
+
+// moveAgentToolBatchToAgentTool turns a batch of exactly one tool back into
+// a single assignment. A moved block maps one resource onto one, so larger
+// batches have to be shrunk first.
+func moveAgentToolBatchToAgentTool(ctx context.Context, _ int64, source map[string]any, resp *resource.MoveStateResponse) {
+	agentID, mcpServerID := movedString(source, "agent_id"), movedString(source, "mcp_server_id")
+	toolIDs, _ := source["tool_ids"].([]any)
+	if agentID.IsNull() || len(toolIDs) != 1 {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			fmt.Sprintf("Only an archestra_agent_tool_batch with exactly one tool can move to archestra_agent_tool; this one has %d. "+
+				"Remove the other tools from tool_ids and apply first, or add archestra_agent_tool resources for them and import each one.", len(toolIDs)),
+		)
+		return
+	}
+	toolID, ok := toolIDs[0].(string)
+	if !ok || toolID == "" {
+		resp.Diagnostics.AddError("Unable to Move Resource State", "The archestra_agent_tool_batch state has an empty tool ID.")
+		return
+	}
+
+	setMovedState(ctx, resp, map[string]attr.Value{
+		"id":                         types.StringValue(agentID.ValueString() + ":" + toolID),
+		"agent_id":                   agentID,
+		"tool_id":                    types.StringValue(toolID),
+		"mcp_server_id":              mcpServerID,
+		"credential_resolution_mode": movedString(source, "credential_resolution_mode"),
+		"organization_id":            movedString(source, "organization_id"),
+	})
+}
+
+// movePolicyToDefault turns a per-tool policy without conditions, the
+// backend's unconditional default row for that tool, into a one-tool policy
+// default. Version 0 sources are upgraded with upgrade first. Conditional
+// policies are separate rows the default resources do not manage, so they
+// refuse to move.
+//
+// Since version 1 the source schema requires a condition, so only state
+// that started at version 0 can move: as written, or as the version 1 state
+// upgrade left it with no conditions. Any policy configured at version 1
+// has conditions and is refused.
+func movePolicyToDefault(sourceType string, upgrade stateUpgradeStep) func(context.Context, int64, map[string]any, *resource.MoveStateResponse) {
+	return func(ctx context.Context, version int64, source map[string]any, resp *resource.MoveStateResponse) {
+		if version == 0 {
+			if err := upgrade(source); err != nil {
+				resp.Diagnostics.AddError("Unable to Move Resource State", fmt.Sprintf("Upgrading the %s state failed: %s", sourceType, err))
+				return
+			}
+		}
+		if conditions, _ := source["conditions"].([]any); len(conditions) > 0 {
+			detail := fmt.Sprintf("This %s has %d condition(s); only a policy without conditions is a tool's default. Keep it as %s.", sourceType, len(conditions), sourceType)
+			if version > 0 {
+				detail += fmt.Sprintf(" Only %s state written before conditions were required can move; to manage the tool's default, import it into a new %s_default instead.", sourceType, sourceType)
+			}
+			resp.Diagnostics.AddError("Unable to Move Resource State", detail)
+			return
+		}
+
+		toolID, action := movedString(source, "tool_id"), movedString(source, "action")
+		if toolID.IsNull() || action.IsNull() {
+			resp.Diagnostics.AddError("Unable to Move Resource State", fmt.Sprintf("The %s state has no tool_id or action.", sourceType))
+			return
+		}
+		toolUUID, err := uuid.Parse(toolID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Move Resource State", fmt.Sprintf("tool_id %q is not a UUID: %s", toolID.ValueString(), err))
+			return
+		}
+		toolIDs, d := types.SetValue(types.StringType, []attr.Value{toolID})
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		setMovedState(ctx, resp, map[string]attr.Value{
+			"id":              types.StringValue(syntheticToolSetID([]openapi_types.UUID{toolUUID}, action.ValueString())),
+			"tool_ids":        toolIDs,
+			"action":          action,
+			"organization_id": movedString(source, "organization_id"),
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+const (
+	moveAgentID = "11111111-1111-4111-8111-111111111111"
+	moveToolA   = "aaaaaaaa-aaaa-4aaa-8aaa-aaaaaaaaaaaa"
+	moveToolB   = "bbbbbbbb-bbbb-4bbb-8bbb-bbbbbbbbbbbb"
+	moveServer  = "cccccccc-cccc-4ccc-8ccc-cccccccccccc"
+)
+
+// moveAgentToolsBody is the agent-tools list the fake backend answers with:
+// both tools assigned to the agent from the same installation.
+const moveAgentToolsBody = `{"data":[` +
+	`{"id":"d1d1d1d1-d1d1-4d1d-8d1d-d1d1d1d1d1d1","agent":{"id":"` + moveAgentID + `"},"tool":{"id":"` + moveToolA + `"},"mcpServerId":"` + moveServer + `","credentialResolutionMode":"dynamic"},` +
+	`{"id":"d2d2d2d2-d2d2-4d2d-8d2d-d2d2d2d2d2d2","agent":{"id":"` + moveAgentID + `"},"tool":{"id":"` + moveToolB + `"},"mcpServerId":"` + moveServer + `","credentialResolutionMode":"dynamic"}` +
+	`],"pagination":{"hasNext":false}}`
+
+// TestMoveState moves source states through the provider server and
+// refreshes the result against a fake backend, the same sequence Terraform
+// runs for a `moved` block.
+func TestMoveState(t *testing.T) {
+	toolDefaultID := syntheticToolSetID([]uuid.UUID{uuid.MustParse(moveToolA)}, "block_always")
+	trustedDefaultID := syntheticToolSetID([]uuid.UUID{uuid.MustParse(moveToolA)}, "mark_as_trusted")
+
+	tests := []struct {
+		name          string
+		sourceType    string
+		sourceVersion int64
+		source        string
+		targetType    string
+		backend       map[string]string
+		wantMoved     string
+		wantRead      string
+		wantErr       string
+	}{
+		{
+			name:       "agent tool to batch",
+			sourceType: "archestra_agent_tool",
+			source:     `{"id":"` + moveAgentID + `:` + moveToolA + `","agent_id":"` + moveAgentID + `","tool_id":"` + moveToolA + `","mcp_server_id":"` + moveServer + `","credential_resolution_mode":"dynamic"}`,
+			targetType: "archestra_agent_tool_batch",
+			backend:    map[string]string{"/api/agent-tools": moveAgentToolsBody},
+			wantMoved:  `{"id":"` + moveAgentID + `:` + moveServer + `","agent_id":"` + moveAgentID + `","mcp_server_id":"` + moveServer + `","tool_ids":["` + moveToolA + `"],"credential_resolution_mode":"dynamic"}`,
+			wantRead:   `{"id":"` + moveAgentID + `:` + moveServer + `","agent_id":"` + moveAgentID + `","mcp_server_id":"` + moveServer + `","tool_ids":["` + moveToolA + `","` + moveToolB + `"],"credential_resolution_mode":"dynamic"}`,
+		},
+		{
+			name:       "agent tool without installation",
+			sourceType: "archestra_agent_tool",
+			source:     `{"id":"` + moveAgentID + `:` + moveToolA + `","agent_id":"` + moveAgentID + `","tool_id":"` + moveToolA + `","mcp_server_id":null}`,
+			targetType: "archestra_agent_tool_batch",
+			wantErr:    "has no mcp_server_id",
+		},
+		{
+			name:       "single-tool batch to agent tool",
+			sourceType: "archestra_agent_tool_batch",
+			source:     `{"id":"` + moveAgentID + `:` + moveServer + `","agent_id":"` + moveAgentID + `","mcp_server_id":"` + moveServer + `","tool_ids":["` + moveToolB + `"],"credential_resolution_mode":"dynamic"}`,
+			targetType: "archestra_agent_tool",
+			backend:    map[string]string{"/api/agent-tools": moveAgentToolsBody},
+			wantMoved:  `{"id":"` + moveAgentID + `:` + moveToolB + `","agent_id":"` + moveAgentID + `","tool_id":"` + moveToolB + `","mcp_server_id":"` + moveServer + `","credential_resolution_mode":"dynamic"}`,
+		},
+		{
+			name:       "multi-tool batch to agent tool",
+			sourceType: "archestra_agent_tool_batch",
+			source:     `{"id":"` + moveAgentID + `:` + moveServer + `","agent_id":"` + moveAgentID + `","mcp_server_id":"` + moveServer + `","tool_ids":["` + moveToolA + `","` + moveToolB + `"]}`,
+			targetType: "archestra_agent_tool",
+			wantErr:    "exactly one tool",
+		},
+		{
+			name:          "unconditional v0 tool invocation policy to default",
+			sourceType:    "archestra_tool_invocation_policy",
+			sourceVersion: 0,
+			source:        `{"id":"e1e1e1e1-e1e1-4e1e-8e1e-e1e1e1e1e1e1","profile_tool_id":"` + moveToolA + `","argument_name":null,"operator":null,"value":null,"action":"block_always"}`,
+			targetType:    "archestra_tool_invocation_policy_default",
+			backend: map[string]string{"/api/autonomy-policies/tool-invocation": `[` +
+				`{"id":"e1e1e1e1-e1e1-4e1e-8e1e-e1e1e1e1e1e1","toolId":"` + moveToolA + `","conditions":[],"action":"block_always"}]`},
+			wantMoved: `{"id":"` + toolDefaultID + `","tool_ids":["` + moveToolA + `"],"action":"block_always"}`,
+		},
+		{
+			name:          "upgraded unconditional trusted data policy to default",
+			sourceType:    "archestra_trusted_data_policy",
+			sourceVersion: 1,
+			source:        `{"id":"e2e2e2e2-e2e2-4e2e-8e2e-e2e2e2e2e2e2","tool_id":"` + moveToolA + `","conditions":[],"action":"mark_as_trusted"}`,
+			targetType:    "archestra_trusted_data_policy_default",
+			backend: map[string]string{"/api/trusted-data-policies": `[` +
+				`{"id":"e2e2e2e2-e2e2-4e2e-8e2e-e2e2e2e2e2e2","toolId":"` + moveToolA + `","conditions":[],"action":"mark_as_trusted"}]`},
+			wantMoved: `{"id":"` + trustedDefaultID + `","tool_ids":["` + moveToolA + `"],"action":"mark_as_trusted"}`,
+		},
+		{
+			name:          "conditional policy to default",
+			sourceType:    "archestra_trusted_data_policy",
+			sourceVersion: 0,
+			source:        `{"id":"e3e3e3e3-e3e3-4e3e-8e3e-e3e3e3e3e3e3","profile_tool_id":"` + moveToolA + `","attribute_path":"url","operator":"contains","value":"x","action":"mark_as_trusted"}`,
+			targetType:    "archestra_trusted_data_policy_default",
+			wantErr:       "has 1 condition(s)",
+		},
+		{
+			name:          "conditional v1 policy to default",
+			sourceType:    "archestra_tool_invocation_policy",
+			sourceVersion: 1,
+			source:        `{"id":"e4e4e4e4-e4e4-4e4e-8e4e-e4e4e4e4e4e4","tool_id":"` + moveToolA + `","conditions":[{"key":"path","operator":"startsWith","value":"/etc/"}],"action":"block_always"}`,
+			targetType:    "archestra_tool_invocation_policy_default",
+			wantErr:       "written before conditions were required can move",
+		},
+		{
+			name:       "unsupported source",
+			sourceType: "archestra_team",
+			source:     `{"id":"t"}`,
+			targetType: "archestra_agent_tool_batch",
+			wantErr:    "does not include support for the given source resource",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			routes := make(map[string]json.RawMessage, len(tc.backend))
+			for path, body := range tc.backend {
+				routes[path] = json.RawMessage(body)
+			}
+			server := newFixtureBackend(t, routes)
+			ps := newConfiguredProviderServer(t, server.URL)
+			ctx := t.Context()
+
+			schemaResp, err := ps.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			stateType := schemaResp.ResourceSchemas[tc.targetType].ValueType()
+
+			moveResp, err := ps.MoveResourceState(ctx, &tfprotov6.MoveResourceStateRequest{
+				SourceProviderAddress: "registry.terraform.io/archestra-ai/archestra",
+				SourceTypeName:        tc.sourceType,
+				SourceSchemaVersion:   tc.sourceVersion,
+				SourceState:           &tfprotov6.RawState{JSON: []byte(tc.source)},
+				TargetTypeName:        tc.targetType,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantErr != "" {
+				for _, d := range moveResp.Diagnostics {
+					if d.Severity == tfprotov6.DiagnosticSeverityError && strings.Contains(d.Summary+d.Detail, tc.wantErr) {
+						return
+					}
+				}
+				t.Fatalf("expected an error containing %q, got %v", tc.wantErr, moveResp.Diagnostics)
+			}
+			failOnDiagnostics(t, "MoveResourceState", moveResp.Diagnostics)
+
+			moved, err := moveResp.TargetState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := (&tfprotov6.RawState{JSON: []byte(tc.wantMoved)}).Unmarshal(stateType)
+			if err != nil {
+				t.Fatalf("wantMoved does not match the schema: %v", err)
+			}
+			assertStateEqual(t, "moved state", moved, want)
+			if moveResp.TargetIdentity == nil {
+				t.Error("no identity returned with the moved state")
+			}
+
+			readResp, err := ps.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName:     tc.targetType,
+				CurrentState: moveResp.TargetState,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			failOnDiagnostics(t, "ReadResource", readResp.Diagnostics)
+			refreshed, err := readResp.NewState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantRead != "" {
+				if want, err = (&tfprotov6.RawState{JSON: []byte(tc.wantRead)}).Unmarshal(stateType); err != nil {
+					t.Fatalf("wantRead does not match the schema: %v", err)
+				}
+			}
+			assertStateEqual(t, "state after Read", refreshed, want)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Every resource records the organization it was created in (or imported
+// into) as a computed `organization_id`. Archestra API keys are scoped to a
+// single organization, so swapping the provider's key for one from another
+// organization would otherwise make every Read 404 and plan a silent
+// recreate in the wrong organization. Instead, Read and ModifyPlan compare
+// the recorded organization with the provider's and fail loudly.
+//
+// archestra_team and archestra_identity_provider already report
+// `organization_id` from the API; they skip planOrganization and only run
+// the Read-side checks.
+
+var organizationPath = path.Root("organization_id")
+
+func organizationIDSchemaAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Computed: true,
+		MarkdownDescription: "Organization the resource belongs to, recorded from the provider's organization when the resource is created or imported. " +
+			"Refresh and plan fail if the provider is later configured for a different organization.",
+		PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+	}
+}
+
+// planOrganization fills `organization_id` on create from the provider's
+// organization and rejects plans against an object recorded in another one.
+func planOrganization(ctx context.Context, providerData *ArchestraProviderData, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, organizationPath, &planned)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current := providerData.organizationID()
+	if planned.IsUnknown() {
+		value := types.StringNull()
+		if current != "" {
+			value = types.StringValue(current)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, organizationPath, value)...)
+		return
+	}
+	addOrganizationMismatch(planned, current, &resp.Diagnostics)
+}
+
+// checkOrganization runs at the top of Read, before any API call: with a
+// key from another organization the lookup would 404 and Read would drop the
+// resource from state, planning a recreate in the wrong organization.
+func checkOrganization(ctx context.Context, providerData *ArchestraProviderData, state tfsdk.State, diags *diag.Diagnostics) {
+	var recorded types.String
+	diags.Append(state.GetAttribute(ctx, organizationPath, &recorded)...)
+	if diags.HasError() {
+		return
+	}
+	addOrganizationMismatch(recorded, providerData.organizationID(), diags)
+}
+
+// stampOrganization runs at the end of Read and records the provider's
+// organization on state that has none yet (import, or state written by an
+// older provider).
+func stampOrganization(ctx context.Context, providerData *ArchestraProviderData, state *tfsdk.State, diags *diag.Diagnostics) {
+	current := providerData.organizationID()
+	if diags.HasError() || state.Raw.IsNull() || current == "" {
+		return
+	}
+
+	var recorded types.String
+	diags.Append(state.GetAttribute(ctx, organizationPath, &recorded)...)
+	if diags.HasError() || !(recorded.IsNull() || recorded.IsUnknown()) {
+		return
+	}
+	diags.Append(state.SetAttribute(ctx, organizationPath, current)...)
+}
+
+func addOrganizationMismatch(recorded types.String, current string, diags *diag.Diagnostics) {
+	if current == "" || recorded.IsNull() || recorded.IsUnknown() || recorded.ValueString() == current {
+		return
+	}
+	diags.AddAttributeError(
+		organizationPath,
+		"Resource Belongs to a Different Organization",
+		fmt.Sprintf("This resource was recorded in organization %q, but the provider is configured for organization %q. "+
+			"API keys are scoped to one organization; check that the provider's api_key (and organization_id, if set) "+
+			"point at the organization that owns this state, or use a separate provider alias per organization.",
+			recorded.ValueString(), current),
+	)
+}
+
+func (d *ArchestraProviderData) organizationID() string {
+	if d == nil {
+		return ""
+	}
+	return d.OrganizationID
+}
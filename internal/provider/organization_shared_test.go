@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var organizationTestSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"name":            schema.StringAttribute{Required: true},
+		"organization_id": organizationIDSchemaAttribute(),
+	},
+}
+
+func organizationTestValue(orgID any) tftypes.Value {
+	return tftypes.NewValue(organizationTestSchema.Type().TerraformType(context.Background()), map[string]tftypes.Value{
+		"name":            tftypes.NewValue(tftypes.String, "a"),
+		"organization_id": tftypes.NewValue(tftypes.String, orgID),
+	})
+}
+
+func TestPlanOrganization(t *testing.T) {
+	t.Parallel()
+
+	providerData := &ArchestraProviderData{OrganizationID: "org-a"}
+	tests := []struct {
+		name      string
+		planned   any
+		data      *ArchestraProviderData
+		want      types.String
+		wantError bool
+	}{
+		{name: "create stamps provider organization", planned: tftypes.UnknownValue, data: providerData, want: types.StringValue("org-a")},
+		{name: "create without known organization plans null", planned: tftypes.UnknownValue, want: types.StringNull()},
+		{name: "same organization", planned: "org-a", data: providerData, want: types.StringValue("org-a")},
+		{name: "different organization errors", planned: "org-b", data: providerData, wantError: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plan := tfsdk.Plan{Schema: organizationTestSchema, Raw: organizationTestValue(tc.planned)}
+			req := resource.ModifyPlanRequest{Plan: plan}
+			resp := resource.ModifyPlanResponse{Plan: plan}
+
+			planOrganization(t.Context(), tc.data, req, &resp)
+			if resp.Diagnostics.HasError() != tc.wantError {
+				t.Fatalf("error = %v, want %v (%v)", resp.Diagnostics.HasError(), tc.wantError, resp.Diagnostics)
+			}
+			if tc.wantError {
+				return
+			}
+			var got types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(t.Context(), organizationPath, &got)...)
+			if !got.Equal(tc.want) {
+				t.Errorf("planned organization_id = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckAndStampOrganization(t *testing.T) {
+	t.Parallel()
+
+	providerData := &ArchestraProviderData{OrganizationID: "org-a"}
+
+	t.Run("mismatch fails before any API call", func(t *testing.T) {
+		var resp resource.ReadResponse
+		state := tfsdk.State{Schema: organizationTestSchema, Raw: organizationTestValue("org-b")}
+		checkOrganization(t.Context(), providerData, state, &resp.Diagnostics)
+		if !resp.Diagnostics.HasError() {
+			t.Fatal("expected an organization mismatch error")
+		}
+	})
+
+	t.Run("imported state gets stamped", func(t *testing.T) {
+		var resp resource.ReadResponse
+		state := tfsdk.State{Schema: organizationTestSchema, Raw: organizationTestValue(nil)}
+		checkOrganization(t.Context(), providerData, state, &resp.Diagnostics)
+		stampOrganization(t.Context(), providerData, &state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diags: %v", resp.Diagnostics)
+		}
+		var got types.String
+		resp.Diagnostics.Append(state.GetAttribute(t.Context(), organizationPath, &got)...)
+		if got.ValueString() != "org-a" {
+			t.Errorf("organization_id = %v, want org-a", got)
+		}
+	})
+
+	t.Run("unknown provider organization skips checks", func(t *testing.T) {
+		var resp resource.ReadResponse
+		state := tfsdk.State{Schema: organizationTestSchema, Raw: organizationTestValue("org-b")}
+		checkOrganization(t.Context(), &ArchestraProviderData{}, state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("unexpected diags: %v", resp.Diagnostics)
+		}
+	})
+}
+
+// TestOrganizationCoverage fails when a registered resource doesn't record
+// its organization, which would let a swapped API key drop it from state.
+func TestOrganizationCoverage(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	for _, ctor := range New("test")().Resources(ctx) {
+		r := ctor()
+		var meta resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "archestra"}, &meta)
+		var sch resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &sch)
+		if _, ok := sch.Schema.Attributes["organization_id"]; !ok {
+			t.Errorf("%s has no organization_id attribute; add organizationIDSchemaAttribute()", meta.TypeName)
+		}
+	}
+}
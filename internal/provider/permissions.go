@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// UserPermissions is the caller's RBAC grant as returned by
+// GetUserPermissions: RBAC resource name → allowed actions.
+type UserPermissions map[string][]string
+
+// Allows reports whether the grant includes action on rbacResource.
+func (p UserPermissions) Allows(rbacResource, action string) bool {
+	return slices.Contains(p[rbacResource], action)
+}
+
+// IsOrgAdmin reports whether the grant can change organization-wide
+// settings, the line between organization admins and everyone else.
+func (p UserPermissions) IsOrgAdmin() bool {
+	return p.Allows("organization", "update")
+}
+
+// rbacResources are the RBAC resource names the backend grants on, the keys
+// GetUserPermissions returns for the admin role. A Terraform resource maps
+// onto the backend resource whose routes it calls, which is not always its
+// own name: LLM proxies and MCP gateways are agents, installations are
+// `mcpServer`, optimization rules fall under `limit`, LLM models under
+// `tokenPrice`, and provider API keys under `chatSettings`.
+var rbacResources = []string{
+	"ac", "agent", "chatSettings", "conversation", "dualLlmConfig", "dualLlmResult",
+	"identityProvider", "interaction", "internalMcpCatalog", "invitation", "limit",
+	"mcpServer", "mcpServerInstallationRequest", "mcpToolCall", "member",
+	"organization", "policy", "prompt", "team", "tokenPrice", "tool",
+}
+
+// permissionRule names the RBAC resource a Terraform resource is gated by.
+type permissionRule struct {
+	// Resource is one of rbacResources.
+	Resource string
+	// SubResource marks Terraform resources that model a child of Resource
+	// (tool assignments, delegations, external groups, singletons): every
+	// mutation is an `update` of the parent rather than create/delete.
+	SubResource bool
+}
+
+// plannedAction maps a ModifyPlan request onto the RBAC action the apply
+// will exercise. A no-op plan still refreshes, so it needs `read`.
+func plannedAction(req resource.ModifyPlanRequest, rule permissionRule) string {
+	switch {
+	case req.Plan.Raw.IsNull():
+		if rule.SubResource {
+			return "update"
+		}
+		return "delete"
+	case req.State.Raw.IsNull():
+		if rule.SubResource {
+			return "update"
+		}
+		return "create"
+	case req.Plan.Raw.Equal(req.State.Raw):
+		return "read"
+	default:
+		return "update"
+	}
+}
+
+// checkPlannedPermissions fails the plan when the API key lacks the
+// permission the planned action needs, instead of letting the apply
+// half-complete on an opaque 403. Missing `read` on a no-op plan is only a
+// warning: nothing is being written, and refresh may still succeed for
+// objects the caller owns.
+//
+// The check is skipped when permissions weren't fetched
+// (skip_credentials_validation) — absence of data isn't absence of grant.
+// A resource the key has no access to is absent from the grant, so absence
+// of a known name is a denial. Only a name outside rbacResources, which the
+// grant could never list, is let through.
+func checkPlannedPermissions(ctx context.Context, providerData *ArchestraProviderData, rule permissionRule, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if providerData == nil || providerData.Permissions == nil {
+		return
+	}
+	if !slices.Contains(rbacResources, rule.Resource) {
+		tflog.Debug(ctx, "unknown RBAC resource; skipping permission check", map[string]any{"resource": rule.Resource})
+		return
+	}
+
+	action := plannedAction(req, rule)
+	if providerData.Permissions.Allows(rule.Resource, action) {
+		return
+	}
+
+	summary := "Missing Archestra Permission"
+	detail := fmt.Sprintf("The configured API key lacks the `%s: %s` permission this plan requires. "+
+		"Grant it to the key's role in the Archestra UI (Settings → Roles), or run with a key that has it.",
+		rule.Resource, action)
+
+	tflog.Debug(ctx, "planned action not permitted", map[string]any{"resource": rule.Resource, "action": action})
+	if action == "read" {
+		resp.Diagnostics.AddWarning(summary, detail+" Refresh may fail or return partial data.")
+		return
+	}
+	resp.Diagnostics.AddError(summary, detail)
+}
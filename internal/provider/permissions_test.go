@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"cmp"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var permissionTestType = tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String}}
+
+func permissionTestValue(name *string) tftypes.Value {
+	if name == nil {
+		return tftypes.NewValue(permissionTestType, nil)
+	}
+	return tftypes.NewValue(permissionTestType, map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, *name),
+	})
+}
+
+func permissionTestRequest(prior, planned *string) resource.ModifyPlanRequest {
+	return resource.ModifyPlanRequest{
+		State: tfsdk.State{Raw: permissionTestValue(prior)},
+		Plan:  tfsdk.Plan{Raw: permissionTestValue(planned)},
+	}
+}
+
+func TestPlannedAction(t *testing.T) {
+	a, b := "a", "b"
+	tests := []struct {
+		name           string
+		prior, planned *string
+		subResource    bool
+		want           string
+	}{
+		{name: "create", planned: &a, want: "create"},
+		{name: "delete", prior: &a, want: "delete"},
+		{name: "update", prior: &a, planned: &b, want: "update"},
+		{name: "no-op refresh", prior: &a, planned: &a, want: "read"},
+		{name: "sub-resource create", planned: &a, subResource: true, want: "update"},
+		{name: "sub-resource delete", prior: &a, subResource: true, want: "update"},
+		{name: "sub-resource no-op", prior: &a, planned: &a, subResource: true, want: "read"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := permissionRule{Resource: "agent", SubResource: tc.subResource}
+			if got := plannedAction(permissionTestRequest(tc.prior, tc.planned), rule); got != tc.want {
+				t.Errorf("plannedAction = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckPlannedPermissions(t *testing.T) {
+	a, b := "a", "b"
+	readOnly := &ArchestraProviderData{Permissions: UserPermissions{"agent": {"read"}}}
+	tests := []struct {
+		name           string
+		providerData   *ArchestraProviderData
+		rbacResource   string
+		prior, planned *string
+		wantErrors     int
+		wantWarnings   int
+	}{
+		{name: "granted", providerData: readOnly, prior: &a, planned: &a},
+		{name: "missing create errors", providerData: readOnly, planned: &a, wantErrors: 1},
+		{name: "missing update errors", providerData: readOnly, prior: &a, planned: &b, wantErrors: 1},
+		{name: "missing read warns", providerData: &ArchestraProviderData{Permissions: UserPermissions{"agent": {}}}, prior: &a, planned: &a, wantWarnings: 1},
+		{name: "resource absent from grant", providerData: &ArchestraProviderData{Permissions: UserPermissions{"team": {"read"}}}, planned: &a, wantErrors: 1},
+		{name: "unknown RBAC resource", providerData: readOnly, rbacResource: "notAResource", planned: &a},
+		{name: "permissions not fetched", providerData: &ArchestraProviderData{}, planned: &a},
+		{name: "unconfigured provider", planned: &a},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := permissionRule{Resource: cmp.Or(tc.rbacResource, "agent")}
+			var resp resource.ModifyPlanResponse
+			checkPlannedPermissions(t.Context(), tc.providerData, rule, permissionTestRequest(tc.prior, tc.planned), &resp)
+			if got := resp.Diagnostics.ErrorsCount(); got != tc.wantErrors {
+				t.Errorf("errors = %d, want %d (%v)", got, tc.wantErrors, resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount(); got != tc.wantWarnings {
+				t.Errorf("warnings = %d, want %d (%v)", got, tc.wantWarnings, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+// TestPermissionCoverage fails when a registered resource skips the
+// plan-time permission pre-flight, which would reintroduce mid-apply 403s
+// for non-admin keys on that resource.
+func TestPermissionCoverage(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	for _, ctor := range New("test")().Resources(ctx) {
+		r := ctor()
+		if _, ok := r.(resource.ResourceWithModifyPlan); !ok {
+			var meta resource.MetadataResponse
+			r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "archestra"}, &meta)
+			t.Errorf("%s does not implement ModifyPlan; call checkPlannedPermissions from it", meta.TypeName)
+		}
+	}
+}
+
+// TestAdminPermissionsPassEveryRule plans a create, an update and a delete
+// of every registered resource with the admin role's grant from
+// GET /api/user/permissions (testdata/user_permissions/admin.json). No
+// plan may fail the permission pre-flight.
+func TestAdminPermissionsPassEveryRule(t *testing.T) {
+	planEveryResource(t, loadPermissions(t, "admin.json"), func(typeName, action string, missing []diag.Diagnostic) {
+		for _, d := range missing {
+			t.Errorf("%s %s: %s", typeName, action, d.Detail())
+		}
+	})
+}
+
+// TestReadOnlyPermissionsFailEveryRule plans the same changes with a grant
+// that can read some resources and write none, and has no entry at all for
+// others. Every create, update and delete must fail the pre-flight, which
+// also proves each resource checks a name the backend actually grants on.
+func TestReadOnlyPermissionsFailEveryRule(t *testing.T) {
+	planEveryResource(t, loadPermissions(t, "read_only.json"), func(typeName, action string, missing []diag.Diagnostic) {
+		if len(missing) == 0 || missing[0].Severity() != diag.SeverityError {
+			t.Errorf("%s %s: plan passed the permission pre-flight with a read-only grant", typeName, action)
+		}
+	})
+}
+
+func loadPermissions(t *testing.T, name string) UserPermissions {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join("testdata", "user_permissions", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var permissions UserPermissions
+	if err := json.Unmarshal(raw, &permissions); err != nil {
+		t.Fatal(err)
+	}
+	return permissions
+}
+
+// planEveryResource runs ModifyPlan for a create, an update and a delete of
+// every registered resource under permissions, and hands check the
+// "Missing Archestra Permission" diagnostics of each.
+func planEveryResource(t *testing.T, permissions UserPermissions, check func(typeName, action string, missing []diag.Diagnostic)) {
+	t.Helper()
+	// Plan-time lookups are best effort; a client that can't connect keeps
+	// them out of the way.
+	apiClient, err := client.NewClientWithResponses("http://127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	providerData := &ArchestraProviderData{Client: apiClient, Permissions: permissions}
+
+	ctx := t.Context()
+	for _, ctor := range New("test")().Resources(ctx) {
+		r := ctor()
+		var meta resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "archestra"}, &meta)
+		var schemaResp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+		if c, ok := r.(resource.ResourceWithConfigure); ok {
+			c.Configure(ctx, resource.ConfigureRequest{ProviderData: providerData}, &resource.ConfigureResponse{})
+		}
+		m, ok := r.(resource.ResourceWithModifyPlan)
+		if !ok {
+			continue // TestPermissionCoverage reports these
+		}
+
+		objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+		nullAttrs := map[string]tftypes.Value{}
+		for name, typ := range objType.AttributeTypes {
+			nullAttrs[name] = tftypes.NewValue(typ, nil)
+		}
+		existing := tftypes.NewValue(objType, nullAttrs)
+		planned := tftypes.NewValue(objType, tftypes.UnknownValue)
+		absent := tftypes.NewValue(objType, nil)
+
+		for action, states := range map[string][2]tftypes.Value{
+			"create": {absent, planned},
+			"update": {existing, planned},
+			"delete": {existing, absent},
+		} {
+			req := resource.ModifyPlanRequest{
+				State:  tfsdk.State{Schema: schemaResp.Schema, Raw: states[0]},
+				Plan:   tfsdk.Plan{Schema: schemaResp.Schema, Raw: states[1]},
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: states[1]},
+			}
+			resp := resource.ModifyPlanResponse{Plan: req.Plan}
+			m.ModifyPlan(ctx, req, &resp)
+			var missing []diag.Diagnostic
+			for _, d := range resp.Diagnostics {
+				if d.Summary() == "Missing Archestra Permission" {
+					missing = append(missing, d)
+				}
+			}
+			check(meta.TypeName, action, missing)
+		}
+	}
+}
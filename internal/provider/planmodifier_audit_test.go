@@ -27,6 +27,11 @@ var useStateForUnknownAllowlist = map[string]map[string]string{
 	"archestra_agent": {
 		"built_in_agent_config.max_rounds": "nested in an AtomicObject JSONB block; the parent block governs the diff, not the leaf.",
 	},
+	"archestra_builtin_agent": {
+		"system_prompt":                    "adopt-only resource: omitted fields keep the seeded backend value, and destroy restores the adopted ones; there is no null to clear to.",
+		"auto_configure_on_tool_discovery": "adopt-only resource: omitted fields keep the seeded backend value, and destroy restores the adopted ones; there is no null to clear to.",
+		"max_rounds":                       "adopt-only resource: omitted fields keep the seeded backend value, and destroy restores the adopted ones; there is no null to clear to.",
+	},
 	"archestra_mcp_registry_catalog_item": { //nolint:gosec // G101 false-positive — nested keys are schema attribute names.
 		"client_secret_id":       "computed when the backend auto-creates a BYOS vault reference for an inline `oauth_config.client_secret`; sticky once issued so removing the inline secret doesn't accidentally drop the stored reference.",
 		"local_config_secret_id": "computed when the backend auto-creates a BYOS vault reference for inline `local_config` env values; sticky once issued so removing the inline values doesn't accidentally drop the stored reference.",
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -61,3 +62,36 @@ func (m emptyListOnConfigNull) PlanModifyList(ctx context.Context, req planmodif
 	}
 	resp.PlanValue = empty
 }
+
+// UseStateUnlessChangedString keeps a computed string's prior state value
+// unless the sibling attribute at trigger changes in the plan, in which case
+// the value is left unknown for apply to recompute. Use for values derived
+// from one input, so unrelated updates don't show "(known after apply)".
+func UseStateUnlessChangedString(trigger path.Path) planmodifier.String {
+	return useStateUnlessChangedString{trigger: trigger}
+}
+
+type useStateUnlessChangedString struct {
+	trigger path.Path
+}
+
+func (m useStateUnlessChangedString) Description(_ context.Context) string {
+	return "Keeps the prior value unless " + m.trigger.String() + " changes."
+}
+
+func (m useStateUnlessChangedString) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateUnlessChangedString) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || req.StateValue.IsUnknown() || !req.PlanValue.IsUnknown() {
+		return
+	}
+	var planned, prior attr.Value
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, m.trigger, &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, m.trigger, &prior)...)
+	if resp.Diagnostics.HasError() || planned == nil || prior == nil || !planned.Equal(prior) {
+		return
+	}
+	resp.PlanValue = req.StateValue
+}
@@ -3,8 +3,12 @@ package provider
 import (
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestRemoveOnConfigNullList(t *testing.T) {
@@ -79,3 +83,52 @@ func TestRemoveOnConfigNullList(t *testing.T) {
 		})
 	}
 }
+
+func TestUseStateUnlessChangedString(t *testing.T) {
+	t.Parallel()
+
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"enabled": tftypes.Bool,
+		"address": tftypes.String,
+	}}
+	testSchema := schema.Schema{Attributes: map[string]schema.Attribute{
+		"enabled": schema.BoolAttribute{Optional: true},
+		"address": schema.StringAttribute{Computed: true},
+	}}
+	object := func(enabled bool, address any) tftypes.Value {
+		return tftypes.NewValue(objType, map[string]tftypes.Value{
+			"enabled": tftypes.NewValue(tftypes.Bool, enabled),
+			"address": tftypes.NewValue(tftypes.String, address),
+		})
+	}
+
+	cases := []struct {
+		name         string
+		stateEnabled bool
+		planEnabled  bool
+		wantPlan     types.String
+	}{
+		{name: "trigger unchanged -> prior value", stateEnabled: true, planEnabled: true, wantPlan: types.StringValue("a@in.acme.com")},
+		{name: "trigger changed -> unknown", stateEnabled: true, planEnabled: false, wantPlan: types.StringUnknown()},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			req := planmodifier.StringRequest{
+				Path:       path.Root("address"),
+				State:      tfsdk.State{Schema: testSchema, Raw: object(tc.stateEnabled, "a@in.acme.com")},
+				Plan:       tfsdk.Plan{Schema: testSchema, Raw: object(tc.planEnabled, tftypes.UnknownValue)},
+				StateValue: types.StringValue("a@in.acme.com"),
+				PlanValue:  types.StringUnknown(),
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			UseStateUnlessChangedString(path.Root("enabled")).PlanModifyString(t.Context(), req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if !resp.PlanValue.Equal(tc.wantPlan) {
+				t.Errorf("plan = %s, want %s", resp.PlanValue, tc.wantPlan)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Local evaluation of tool invocation rules, for data sources that answer
+// "what would the platform do with this call" without making one. A rule
+// matches when ALL of its conditions hold; among matching rules the most
+// restrictive action wins, and with no match the tool's unconditional
+// default applies.
+//
+// Condition semantics follow the backend's:
+//
+//   - `key` names a top-level argument; a key with no such argument is read
+//     as a path (`options.mode`, `paths[0]`) into nested objects and arrays.
+//   - A missing argument fails every condition on it, `notEqual` and
+//     `notContains` included.
+//   - String arguments are compared as-is; numbers and booleans by their
+//     JSON text (`42`, `true`). Objects, arrays and null match nothing.
+//   - `regex` is an unanchored search, as JavaScript's `RegExp.test`.
+
+// invocationActionRank orders invocation actions from least to most
+// restrictive.
+var invocationActionRank = map[string]int{
+	"allow_when_context_is_untrusted": 0,
+	"block_when_context_is_untrusted": 1,
+	"require_approval":                2,
+	"block_always":                    3,
+}
+
+// policyEvaluation is the outcome of evaluating one call. Rule is nil when
+// the default (or nothing) decided; Index is the rule's position in the
+// evaluated slice, -1 when Rule is nil.
+type policyEvaluation struct {
+	Action string
+	Rule   *policySetRow
+	Index  int
+}
+
+// Blocked reports whether the call would not execute, given whether the
+// context it runs in is trusted. A call that requires approval is not
+// blocked.
+func (e policyEvaluation) Blocked(contextTrusted bool) bool {
+	switch e.Action {
+	case "block_always":
+		return true
+	case "block_when_context_is_untrusted":
+		return !contextTrusted
+	}
+	return false
+}
+
+// evaluateInvocationRules decides args against rules and defaultAction ("",
+// no default). Rules with no conditions are skipped; those are defaults.
+func evaluateInvocationRules(rules []policySetRow, defaultAction string, args map[string]any) (policyEvaluation, error) {
+	result := policyEvaluation{Action: defaultAction, Index: -1}
+	for i := range rules {
+		rule := &rules[i]
+		if len(rule.Conditions) == 0 {
+			continue
+		}
+		matched, err := policyConditionsMatch(rule.Conditions, args)
+		if err != nil {
+			return policyEvaluation{}, fmt.Errorf("rule %d: %w", i, err)
+		}
+		if !matched {
+			continue
+		}
+		if result.Rule == nil || invocationActionRank[rule.Action] > invocationActionRank[result.Action] {
+			result = policyEvaluation{Action: rule.Action, Rule: rule, Index: i}
+		}
+	}
+	return result, nil
+}
+
+func policyConditionsMatch(conditions []policySetCondition, args map[string]any) (bool, error) {
+	for _, c := range conditions {
+		matched, err := policyConditionMatches(c, args)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func policyConditionMatches(c policySetCondition, args map[string]any) (bool, error) {
+	raw, ok := lookupPolicyArgument(args, c.Key)
+	if !ok {
+		return false, nil
+	}
+	var value string
+	switch v := raw.(type) {
+	case string:
+		value = v
+	case float64, bool, json.Number:
+		b, _ := json.Marshal(v)
+		value = string(b)
+	default:
+		return false, nil
+	}
+
+	switch c.Operator {
+	case "equal":
+		return value == c.Value, nil
+	case "notEqual":
+		return value != c.Value, nil
+	case "contains":
+		return strings.Contains(value, c.Value), nil
+	case "notContains":
+		return !strings.Contains(value, c.Value), nil
+	case "startsWith":
+		return strings.HasPrefix(value, c.Value), nil
+	case "endsWith":
+		return strings.HasSuffix(value, c.Value), nil
+	case "regex":
+		re, err := compilePolicyPattern(c.Value)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(value), nil
+	}
+	return false, fmt.Errorf("unsupported operator %q", c.Operator)
+}
+
+// compilePolicyPattern compiles a `regex` condition value through the same
+// ECMAScript translation the condition validator parses. Patterns using
+// lookaround, backreferences or counts above RE2's limit cannot be
+// evaluated locally and are reported as such rather than guessed at.
+func compilePolicyPattern(pattern string) (*regexp.Regexp, error) {
+	translated, err := translateECMAScriptPattern(pattern)
+	if err == nil && len(translated.unsupported) > 0 {
+		err = fmt.Errorf("RE2 has no %s", strings.Join(translated.unsupported, " or "))
+	}
+	var re *regexp.Regexp
+	if err == nil {
+		re, err = regexp.Compile(translated.re2)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("regex %q cannot be evaluated locally: %w", pattern, err)
+	}
+	return re, nil
+}
+
+// lookupPolicyArgument resolves key against args: the top-level argument
+// of that name, else a dotted path with `[n]` array indexes.
+func lookupPolicyArgument(args map[string]any, key string) (any, bool) {
+	if v, ok := args[key]; ok {
+		return v, true
+	}
+	var current any = args
+	for _, segment := range strings.Split(strings.ReplaceAll(key, "[", ".["), ".") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, "[") && strings.HasSuffix(segment, "]") {
+			list, ok := current.([]any)
+			if !ok {
+				return nil, false
+			}
+			i, err := strconv.Atoi(segment[1 : len(segment)-1])
+			if err != nil || i < 0 || i >= len(list) {
+				return nil, false
+			}
+			current = list[i]
+			continue
+		}
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = obj[segment]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
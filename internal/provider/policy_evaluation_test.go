@@ -0,0 +1,119 @@
+package provider
+
+import "testing"
+
+func TestPolicyConditionMatches(t *testing.T) {
+	args := map[string]any{
+		"command": "rm -rf /",
+		"count":   float64(42),
+		"force":   true,
+		"options": map[string]any{"mode": "recursive"},
+		"paths":   []any{"/etc/passwd", "/tmp/x"},
+		"a.b":     "literal",
+		"nothing": nil,
+	}
+	tests := []struct {
+		name      string
+		condition policySetCondition
+		want      bool
+		wantErr   bool
+	}{
+		{"equal", policySetCondition{"command", "equal", "rm -rf /"}, true, false},
+		{"notEqual", policySetCondition{"command", "notEqual", "ls"}, true, false},
+		{"contains", policySetCondition{"command", "contains", "-rf"}, true, false},
+		{"notContains", policySetCondition{"command", "notContains", "-rf"}, false, false},
+		{"startsWith", policySetCondition{"command", "startsWith", "rm "}, true, false},
+		{"endsWith", policySetCondition{"command", "endsWith", "/"}, true, false},
+		{"regex is a search", policySetCondition{"command", "regex", `rm\s+-[a-z]*r`}, true, false},
+		{"regex anchors", policySetCondition{"command", "regex", `^ls`}, false, false},
+		{"number as JSON text", policySetCondition{"count", "equal", "42"}, true, false},
+		{"bool as JSON text", policySetCondition{"force", "equal", "true"}, true, false},
+		{"nested path", policySetCondition{"options.mode", "equal", "recursive"}, true, false},
+		{"array index", policySetCondition{"paths[0]", "startsWith", "/etc/"}, true, false},
+		{"array index out of range", policySetCondition{"paths[5]", "notEqual", "x"}, false, false},
+		{"top-level key with a dot", policySetCondition{"a.b", "equal", "literal"}, true, false},
+		{"missing argument fails notEqual", policySetCondition{"user", "notEqual", "root"}, false, false},
+		{"null matches nothing", policySetCondition{"nothing", "notContains", "x"}, false, false},
+		{"object matches nothing", policySetCondition{"options", "notEqual", "x"}, false, false},
+		{"lookahead cannot be evaluated", policySetCondition{"command", "regex", `rm(?= -rf)`}, false, true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := policyConditionMatches(tc.condition, args)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestCompilePolicyPattern checks that regex conditions evaluate with
+// ECMAScript semantics where those differ from RE2's.
+func TestCompilePolicyPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    bool
+		wantErr bool
+	}{
+		{`[\d-z]`, "-", true, false},
+		{`[\d-z]`, "m", false, false},
+		{`\é`, "café", true, false},
+		{`^a.b$`, "a\rb", false, false},
+		{`^\s$`, "\u00a0", true, false},
+		{`^[^]$`, "\n", true, false},
+		{`[]`, "x", false, false},
+		{`\cJ`, "\n", true, false},
+		{`\101`, "A", true, false},
+		{`(a)\8`, "a8", true, false},
+		{`[\101]`, "A", true, false},
+		{`(?<q>x)y`, "xy", true, false},
+		{`a{2000}`, "a", false, true},
+		{`(['"]).*\1`, `"x"`, false, true},
+		{`(?<!sudo )rm`, "rm", false, true},
+	}
+	for _, tc := range tests {
+		re, err := compilePolicyPattern(tc.pattern)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("compilePolicyPattern(%q) err = %v, wantErr %v", tc.pattern, err, tc.wantErr)
+			continue
+		}
+		if err == nil && re.MatchString(tc.input) != tc.want {
+			t.Errorf("%q matching %q = %v, want %v", tc.pattern, tc.input, !tc.want, tc.want)
+		}
+	}
+}
+
+func TestEvaluateInvocationRules(t *testing.T) {
+	rule := func(action string, conditions ...policySetCondition) policySetRow {
+		return policySetRow{policySetRule: policySetRule{Action: action, Conditions: conditions}}
+	}
+	rules := []policySetRow{
+		rule("allow_when_context_is_untrusted", policySetCondition{"command", "startsWith", "ls"}),
+		rule("require_approval", policySetCondition{"command", "contains", "rm"}),
+		rule("block_always", policySetCondition{"command", "contains", "rm"}, policySetCondition{"command", "contains", "-rf"}),
+		rule("block_always"),
+	}
+	tests := []struct {
+		command    string
+		wantAction string
+		wantIndex  int
+	}{
+		{"ls -la", "allow_when_context_is_untrusted", 0},
+		{"rm file", "require_approval", 1},
+		{"rm -rf /", "block_always", 2},
+		{"cat file", "block_when_context_is_untrusted", -1},
+	}
+	for _, tc := range tests {
+		got, err := evaluateInvocationRules(rules, "block_when_context_is_untrusted", map[string]any{"command": tc.command})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Action != tc.wantAction || got.Index != tc.wantIndex {
+			t.Errorf("%q: got (%s, %d), want (%s, %d)", tc.command, got.Action, got.Index, tc.wantAction, tc.wantIndex)
+		}
+	}
+}
@@ -1,7 +1,25 @@
 package provider
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Tool invocation policies and trusted data policies share a wire shape:
@@ -30,9 +48,10 @@ func (r *ToolInvocationPolicyResource) APIShape() any {
 	return client.GetToolInvocationPolicyResponse{}
 }
 
-// KnownIntentionallySkipped: createdAt/updatedAt are audit timestamps.
+// KnownIntentionallySkipped: empty; the createdAt/updatedAt audit
+// timestamps are computed-only attributes.
 func (r *ToolInvocationPolicyResource) KnownIntentionallySkipped() []string {
-	return []string{"createdAt", "updatedAt"}
+	return nil
 }
 
 var trustedDataPolicyAttrSpec = []AttrSpec{
@@ -54,7 +73,505 @@ func (r *TrustedDataPolicyResource) APIShape() any {
 	return client.GetTrustedDataPolicyResponse{}
 }
 
-// KnownIntentionallySkipped: createdAt/updatedAt are audit timestamps.
+// KnownIntentionallySkipped: empty; the createdAt/updatedAt audit
+// timestamps are computed-only attributes.
 func (r *TrustedDataPolicyResource) KnownIntentionallySkipped() []string {
-	return []string{"createdAt", "updatedAt"}
+	return nil
+}
+
+// Schema version 1 of both policies is the redesign above. Version 0 states
+// may predate it (`profile_tool_id`, one condition spread over
+// argument_name | attribute_path / operator / value) or already have the new
+// shape, since the redesign shipped without a Version bump.
+
+func (r *ToolInvocationPolicyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(upgradePolicyV0("argument_name"))
+}
+
+func (r *TrustedDataPolicyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(upgradePolicyV0("attribute_path"))
+}
+
+// upgradePolicyV0 renames profile_tool_id to tool_id and folds the scalar
+// condition keyed by keyAttr into a one-element conditions list.
+func upgradePolicyV0(keyAttr string) stateUpgradeStep {
+	return func(state map[string]any) error {
+		renameStateAttribute(state, "profile_tool_id", "tool_id")
+
+		key, hasKey := state[keyAttr]
+		operator, value := state["operator"], state["value"]
+		delete(state, keyAttr)
+		delete(state, "operator")
+		delete(state, "value")
+		if !hasKey || key == nil || state["conditions"] != nil {
+			return nil
+		}
+		if operator == nil || value == nil {
+			return fmt.Errorf("%s is set but operator or value is missing", keyAttr)
+		}
+		state["conditions"] = []any{map[string]any{
+			"key":      key,
+			"operator": operator,
+			"value":    value,
+		}}
+		return nil
+	}
+}
+
+// policyConditionsValidator checks each element of a `conditions` list
+// that would only fail once the platform evaluates it: a `regex` value the
+// backend's `new RegExp(value)` rejects, and an empty value for the
+// substring operators, which matches every call (or none, for
+// `notContains`). Errors point at the offending element's `value`.
+func policyConditionsValidator() validator.List {
+	return policyConditions{}
+}
+
+type policyConditions struct{}
+
+func (v policyConditions) Description(_ context.Context) string {
+	return "regex values must be valid ECMAScript patterns and substring operators need a non-empty value"
+}
+
+func (v policyConditions) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v policyConditions) ValidateList(_ context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for i, elem := range req.ConfigValue.Elements() {
+		obj, ok := elem.(basetypes.ObjectValue)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		operator, _ := obj.Attributes()["operator"].(basetypes.StringValue)
+		value, _ := obj.Attributes()["value"].(basetypes.StringValue)
+		if operator.IsNull() || operator.IsUnknown() || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		valuePath := req.Path.AtListIndex(i).AtName("value")
+		switch operator.ValueString() {
+		case "regex":
+			if err := ecmaScriptPatternError(value.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(valuePath, "Invalid Regex",
+					fmt.Sprintf("%q is not a valid regular expression: %s", value.ValueString(), err))
+			}
+		case "contains", "notContains", "startsWith", "endsWith":
+			if value.ValueString() == "" {
+				resp.Diagnostics.AddAttributeError(valuePath, "Empty Condition Value",
+					fmt.Sprintf("An empty value makes %s match every argument (or none, for notContains). Remove the condition instead.", operator.ValueString()))
+			}
+		}
+	}
+}
+
+// checkPlannedConditionKeys validates each planned condition `key` of a
+// tool invocation policy against its tool's parameter schema, once tool_id
+// and conditions are known and have changed. Trusted data policies have no
+// equivalent: their keys address the tool's result, which has no schema.
+func checkPlannedConditionKeys(ctx context.Context, c *client.ClientWithResponses, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if c == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+	var toolID types.String
+	var conditions types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tool_id"), &toolID)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("conditions"), &conditions)...)
+	if resp.Diagnostics.HasError() || toolID.IsUnknown() || conditions.IsUnknown() || conditions.IsNull() {
+		return
+	}
+	if !req.State.Raw.IsNull() {
+		var priorToolID types.String
+		var priorConditions types.List
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("tool_id"), &priorToolID)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("conditions"), &priorConditions)...)
+		if resp.Diagnostics.HasError() || (priorToolID.Equal(toolID) && priorConditions.Equal(conditions)) {
+			return
+		}
+	}
+	var conds []PolicyConditionModel
+	resp.Diagnostics.Append(conditions.ElementsAs(ctx, &conds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkConditionKeys(fetchToolParameters(ctx, c), toolID.ValueString(), conds, path.Root("conditions"), &resp.Diagnostics)
+}
+
+// toolParameters is a tool's name and input schema, keyed by lower-case
+// tool UUID.
+type toolParameters map[string]struct {
+	Name   string
+	Schema map[string]any
+}
+
+// fetchToolParameters lists every tool's input schema for
+// checkConditionKeys. Best effort: a failed lookup returns nil and leaves
+// the check to the backend.
+func fetchToolParameters(ctx context.Context, c *client.ClientWithResponses) toolParameters {
+	toolsResp, err := c.GetToolsWithResponse(ctx)
+	if err != nil || toolsResp.JSON200 == nil {
+		return nil
+	}
+	out := make(toolParameters, len(*toolsResp.JSON200))
+	for _, tool := range *toolsResp.JSON200 {
+		entry := out[strings.ToLower(tool.Id.String())]
+		entry.Name = tool.Name
+		if tool.Parameters != nil {
+			entry.Schema = *tool.Parameters
+		}
+		out[strings.ToLower(tool.Id.String())] = entry
+	}
+	return out
+}
+
+// checkConditionKeys reports each condition whose `key` names no parameter
+// of toolID, at conditionsPath's element. A tool the backend does not list
+// yet (installed in the same apply), a schema without declared properties,
+// or one allowing additionalProperties is not checked. An undeclared key
+// is an error only where the schema sets `additionalProperties: false`;
+// JSON Schema allows extra properties when it is omitted, as many MCP tool
+// schemas do, so there it is a warning.
+func checkConditionKeys(tools toolParameters, toolID string, conditions []PolicyConditionModel, conditionsPath path.Path, diags *diag.Diagnostics) {
+	tool, ok := tools[strings.ToLower(toolID)]
+	if !ok || tool.Schema == nil {
+		return
+	}
+	for i, cond := range conditions {
+		if cond.Key.IsNull() || cond.Key.IsUnknown() {
+			continue
+		}
+		known, ok, closed := policyKeyInSchema(tool.Schema, cond.Key.ValueString())
+		switch {
+		case ok:
+		case closed:
+			diags.AddAttributeError(conditionsPath.AtListIndex(i).AtName("key"), "Unknown Condition Key",
+				fmt.Sprintf("Tool %s has no parameter %q, so this condition never matches. Parameters at that level: %s.",
+					tool.Name, cond.Key.ValueString(), strings.Join(known, ", ")))
+		default:
+			diags.AddAttributeWarning(conditionsPath.AtListIndex(i).AtName("key"), "Undeclared Condition Key",
+				fmt.Sprintf("Tool %s declares no parameter %q, so this condition only matches if callers pass it anyway. Declared parameters at that level: %s.",
+					tool.Name, cond.Key.ValueString(), strings.Join(known, ", ")))
+		}
+	}
+}
+
+// policyKeyInSchema resolves key against a JSON Schema the way
+// lookupPolicyArgument resolves it against arguments. On failure it
+// returns the property names declared where resolution stopped, and
+// whether that object is closed by `additionalProperties: false`.
+func policyKeyInSchema(schema map[string]any, key string) (known []string, ok, closed bool) {
+	if props, declared := schema["properties"].(map[string]any); declared {
+		if _, ok := props[key]; ok {
+			return nil, true, false
+		}
+	}
+	current := schema
+	for _, segment := range strings.Split(strings.ReplaceAll(key, "[", ".["), ".") {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, "[") {
+			items, described := current["items"].(map[string]any)
+			if !described {
+				return nil, true, false
+			}
+			current = items
+			continue
+		}
+		props, declared := current["properties"].(map[string]any)
+		if !declared {
+			return nil, true, false
+		}
+		additional, set := current["additionalProperties"]
+		if set && additional != false {
+			return nil, true, false
+		}
+		next, found := props[segment].(map[string]any)
+		if !found {
+			known = make([]string, 0, len(props))
+			for name := range props {
+				known = append(known, name)
+			}
+			sort.Strings(known)
+			return known, false, set
+		}
+		current = next
+	}
+	return nil, true, false
+}
+
+// ecmaScriptPattern is a `regex` condition value, JavaScript RegExp source
+// as the backend evaluates it, rewritten to RE2 syntax.
+type ecmaScriptPattern struct {
+	// re2 parses wherever the source does and, barring the features in
+	// unsupported, matches what the source matches.
+	re2 string
+	// unsupported names ECMAScript features of the source that RE2 cannot
+	// evaluate. re2 stands each in with syntax that parses alike, so the
+	// translation still validates the pattern.
+	unsupported []string
+}
+
+// ecmaScriptSpace is the ECMAScript `\s` set, in class syntax. RE2's `\s`
+// is ASCII-only.
+const ecmaScriptSpace = `\t\n\v\f\r \x{a0}\x{1680}\x{2000}-\x{200a}\x{2028}\x{2029}\x{202f}\x{205f}\x{3000}\x{feff}`
+
+var ecmaScriptQuantifier = regexp.MustCompile(`^\{([0-9]+)(,([0-9]*))?\}`)
+
+// translateECMAScriptPattern rewrites pattern, read as a non-Unicode
+// ECMAScript RegExp with the Annex B extensions browsers and Node accept,
+// to RE2 syntax. It errors only on syntax ECMAScript rejects, which
+// includes RE2-only syntax such as inline flags and `(?P<name>`; anything
+// else that RE2 cannot parse is left for the caller's parse to report.
+func translateECMAScriptPattern(pattern string) (ecmaScriptPattern, error) {
+	var (
+		out     ecmaScriptPattern
+		b       strings.Builder
+		inClass bool
+		// afterClassEscape is set just past a `\d`-style escape in a
+		// class, where Annex B reads a following `-` literally.
+		afterClassEscape bool
+	)
+	unsupported := func(feature string) {
+		if !slices.Contains(out.unsupported, feature) {
+			out.unsupported = append(out.unsupported, feature)
+		}
+	}
+	groups := ecmaScriptGroupCount(pattern)
+	classEscapeAt := func(i int) bool {
+		return i+1 < len(pattern) && pattern[i] == '\\' && strings.IndexByte("dDwWsS", pattern[i+1]) >= 0
+	}
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		wasClassEscape := afterClassEscape
+		afterClassEscape = false
+		switch {
+		case c == '\\':
+			if i+1 == len(pattern) {
+				return out, errors.New("\\ at end of pattern")
+			}
+			i++
+			e := pattern[i]
+			switch {
+			case e >= utf8.RuneSelf:
+				// Identity escape of a non-ASCII character.
+				_, size := utf8.DecodeRuneInString(pattern[i:])
+				b.WriteString(pattern[i : i+size])
+				i += size - 1
+			case !inClass && e >= '1' && e <= '9' && leadingCount(pattern[i:]) <= uint64(groups):
+				for i+1 < len(pattern) && isDigit(pattern[i+1]) {
+					i++
+				}
+				b.WriteString("(?:)")
+				unsupported("backreferences")
+			case !inClass && e == 'k' && strings.HasPrefix(pattern[i+1:], "<"):
+				end := strings.IndexByte(pattern[i:], '>')
+				if end < 0 {
+					return out, errors.New("invalid named reference")
+				}
+				i += end
+				b.WriteString("(?:)")
+				unsupported("backreferences")
+			case e == 'c' && i+1 < len(pattern) && isASCIILetter(pattern[i+1]):
+				i++
+				fmt.Fprintf(&b, `\x{%x}`, pattern[i]%32)
+			case e == 'c':
+				// Annex B: a `\c` without a control letter is a literal
+				// backslash and `c`.
+				b.WriteString(`\\c`)
+			case e == 'u' && i+4 < len(pattern) && isHex(pattern[i+1:i+5]):
+				b.WriteString(`\x{` + pattern[i+1:i+5] + `}`)
+				i += 4
+			case e == 'x' && i+2 < len(pattern) && isHex(pattern[i+1:i+3]):
+				b.WriteString(`\x` + pattern[i+1:i+3])
+				i += 2
+			case inClass && e == 'b':
+				b.WriteString(`\x08`)
+			case e >= '0' && e <= '7':
+				// Annex B legacy octal escape, up to \377: `\0`, or a
+				// number past the pattern's group count.
+				n := int(e - '0')
+				for i+1 < len(pattern) && pattern[i+1] >= '0' && pattern[i+1] <= '7' && n*8+int(pattern[i+1]-'0') <= 0377 {
+					i++
+					n = n*8 + int(pattern[i]-'0')
+				}
+				fmt.Fprintf(&b, `\x{%x}`, n)
+			case e == 's' && inClass:
+				b.WriteString(ecmaScriptSpace)
+				afterClassEscape = true
+			case e == 's':
+				b.WriteString("[" + ecmaScriptSpace + "]")
+			case e == 'S' && !inClass:
+				b.WriteString("[^" + ecmaScriptSpace + "]")
+			case strings.IndexByte("dDwWS", e) >= 0:
+				b.WriteByte('\\')
+				b.WriteByte(e)
+				afterClassEscape = inClass
+			case strings.IndexByte("tnvfr", e) >= 0 || (!inClass && (e == 'b' || e == 'B')):
+				b.WriteByte('\\')
+				b.WriteByte(e)
+			case isASCIILetter(e) || isDigit(e):
+				// Identity escape: the character itself.
+				b.WriteByte(e)
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(e)
+			}
+		case inClass && c == ']':
+			inClass = false
+			b.WriteByte(c)
+		case inClass && c == '[':
+			b.WriteString(`\[`)
+		case inClass && c == '-' && (wasClassEscape || classEscapeAt(i+1)):
+			// Annex B: a class escape cannot end a range, so the `-` next
+			// to one is literal. RE2 rejects such ranges.
+			b.WriteString(`\-`)
+		case inClass:
+			b.WriteByte(c)
+		case c == '[':
+			switch {
+			case strings.HasPrefix(pattern[i:], "[^]"):
+				b.WriteString(`[\x00-\x{10ffff}]`)
+				i += 2
+			case strings.HasPrefix(pattern[i:], "[]"):
+				b.WriteString(`[^\x00-\x{10ffff}]`)
+				i++
+			default:
+				inClass = true
+				b.WriteByte(c)
+			}
+		case c == '.':
+			b.WriteString(`[^\n\r\x{2028}\x{2029}]`)
+		case c == '(' && strings.HasPrefix(pattern[i:], "(?"):
+			rest := pattern[i+2:]
+			switch {
+			case strings.HasPrefix(rest, ":"):
+				b.WriteString("(?:")
+				i += 2
+			case strings.HasPrefix(rest, "="), strings.HasPrefix(rest, "!"):
+				b.WriteString("(?:")
+				i += 2
+				unsupported("lookahead")
+			case strings.HasPrefix(rest, "<="), strings.HasPrefix(rest, "<!"):
+				b.WriteString("(?:")
+				i += 3
+				unsupported("lookbehind")
+			case strings.HasPrefix(rest, "<"):
+				// Named group. The name matters only to backreferences,
+				// which RE2 lacks anyway.
+				end := strings.IndexByte(rest, '>')
+				if end < 2 {
+					return out, fmt.Errorf("invalid group name at offset %d", i)
+				}
+				b.WriteByte('(')
+				i += 2 + end
+			default:
+				return out, fmt.Errorf("invalid group at offset %d: ECMAScript has no inline flags or (?P<name> groups", i)
+			}
+		case c == '{':
+			m := ecmaScriptQuantifier.FindStringSubmatch(pattern[i:])
+			if m == nil {
+				// Annex B: a brace that starts no quantifier is literal.
+				b.WriteString(`\{`)
+				continue
+			}
+			low, high := repeatCount(m[1]), repeatCount(m[1])
+			if m[3] != "" {
+				high = repeatCount(m[3])
+			}
+			if high < low {
+				return out, fmt.Errorf("numbers out of order in quantifier %s", m[0])
+			}
+			// RE2 caps counts at 1000; ECMAScript does not.
+			if low > 1000 || high > 1000 {
+				unsupported("repetition counts above 1000")
+				low, high = min(low, 1000), min(high, 1000)
+			}
+			fmt.Fprintf(&b, "{%d", low)
+			if m[2] != "" {
+				b.WriteByte(',')
+			}
+			if m[3] != "" {
+				fmt.Fprintf(&b, "%d", high)
+			}
+			b.WriteByte('}')
+			i += len(m[0]) - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+	out.re2 = b.String()
+	return out, nil
+}
+
+// ecmaScriptPatternError reports why the backend would reject pattern.
+// Go's parser does the work on the translated pattern.
+func ecmaScriptPatternError(pattern string) error {
+	translated, err := translateECMAScriptPattern(pattern)
+	if err != nil {
+		return err
+	}
+	_, err = syntax.Parse(translated.re2, syntax.Perl)
+	return err
+}
+
+// ecmaScriptGroupCount counts the capturing groups in pattern. Annex B
+// reads `\N` as a backreference only when N is at most this count.
+func ecmaScriptGroupCount(pattern string) int {
+	count, inClass := 0, false
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\':
+			i++
+		case c == '[':
+			inClass = true
+		case c == ']':
+			inClass = false
+		case c == '(' && !inClass:
+			rest := pattern[i+1:]
+			if !strings.HasPrefix(rest, "?") || (strings.HasPrefix(rest, "?<") && !strings.HasPrefix(rest, "?<=") && !strings.HasPrefix(rest, "?<!")) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// leadingCount parses the decimal digits s starts with.
+func leadingCount(s string) uint64 {
+	end := 0
+	for end < len(s) && isDigit(s[end]) {
+		end++
+	}
+	return repeatCount(s[:end])
+}
+
+// repeatCount parses a quantifier bound, saturating rather than failing on
+// overflow.
+func repeatCount(digits string) uint64 {
+	n, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return math.MaxUint64
+	}
+	return n
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+	return true
 }
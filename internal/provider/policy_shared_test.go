@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestECMAScriptPatternError(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   bool
+	}{
+		{`^rm\s+-rf`, true},
+		{`rm(?= -rf)`, true},
+		{`(?<!sudo )rm`, true},
+		{`(['"]).*\1`, true},
+		{`(?<q>['"]).*\k<q>`, true},
+		{`é|\x41|\cJ`, true},
+		{`[^]`, true},
+		{`\-\/\:`, true},
+		{`a{2000}`, true},
+		// Annex B extensions Node accepts.
+		{`\é`, true},
+		{`[\d-z]`, true},
+		{`[a-\w]`, true},
+		{`[\s-\d]`, true},
+		{`[[:alpha:]]`, true},
+		{`\c1`, true},
+		{`\u{4}`, true},
+		{`a{`, true},
+		{`{foo}`, true},
+		{`\_\8`, true},
+		{`[\1\B]`, true},
+		{`a{2000}(`, false},
+		{`a{3,2}`, false},
+		{`(?<>x)`, false},
+		{`(?i)rm`, false},
+		{`(?P<name>x)`, false},
+		{`[a-`, false},
+		{`(unclosed`, false},
+		{`*rm`, false},
+		{`rm\`, false},
+	}
+	for _, tc := range tests {
+		err := ecmaScriptPatternError(tc.pattern)
+		if (err == nil) != tc.valid {
+			t.Errorf("ecmaScriptPatternError(%q) = %v, want valid %v", tc.pattern, err, tc.valid)
+		}
+	}
+}
+
+func TestPolicyKeyInSchema(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal([]byte(`{
+		"type": "object",
+		"properties": {
+			"path": {"type": "string"},
+			"options": {"type": "object", "properties": {"mode": {"type": "string"}}, "additionalProperties": false},
+			"paths": {"type": "array", "items": {"type": "string"}},
+			"headers": {"type": "object", "additionalProperties": {"type": "string"}}
+		}
+	}`), &schema); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		key    string
+		valid  bool
+		closed bool
+	}{
+		{"path", true, false},
+		{"options.mode", true, false},
+		{"paths[0]", true, false},
+		{"headers.x-anything", true, false},
+		{"pth", false, false},
+		{"options.recursive", false, true},
+	}
+	for _, tc := range tests {
+		if _, ok, closed := policyKeyInSchema(schema, tc.key); ok != tc.valid || closed != tc.closed {
+			t.Errorf("policyKeyInSchema(%q) = %v, closed %v, want %v, closed %v", tc.key, ok, closed, tc.valid, tc.closed)
+		}
+	}
+	if known, _, _ := policyKeyInSchema(schema, "pth"); len(known) != 4 || known[0] != "headers" {
+		t.Errorf("known parameters = %q, want the four top-level properties sorted", known)
+	}
+}
+
+// TestPolicyConditionsValidator checks that condition errors land on the
+// offending list element.
+func TestPolicyConditionsValidator(t *testing.T) {
+	ps := newConfiguredProviderServer(t, newFixtureBackend(t, nil).URL)
+	ctx := t.Context()
+	schemaResp, err := ps.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.ResourceSchemas["archestra_tool_invocation_policy"].ValueType().(tftypes.Object)
+	config := validatorConfig(t, objType, `{"tool_id":"`+policySetToolA+`","action":"block_always","conditions":[`+
+		`{"key":"path","operator":"regex","value":"^/etc/"},`+
+		`{"key":"path","operator":"regex","value":"(?i)/etc/"},`+
+		`{"key":"path","operator":"startsWith","value":""}]}`, nil)
+
+	resp, err := ps.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: "archestra_tool_invocation_policy",
+		Config:   &config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"Invalid Regex":         tftypes.NewAttributePath().WithAttributeName("conditions").WithElementKeyInt(1).WithAttributeName("value").String(),
+		"Empty Condition Value": tftypes.NewAttributePath().WithAttributeName("conditions").WithElementKeyInt(2).WithAttributeName("value").String(),
+	}
+	if len(resp.Diagnostics) != len(want) {
+		t.Fatalf("diagnostics = %+v, want %d", resp.Diagnostics, len(want))
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Attribute == nil || want[d.Summary] != d.Attribute.String() {
+			t.Errorf("diagnostic %q at %v, want at %s", d.Summary, d.Attribute, want[d.Summary])
+		}
+	}
+}
+
+// TestToolInvocationPolicyUnknownConditionKey plans a policy whose second
+// condition names an argument the tool doesn't declare: an error when the
+// schema is closed, a warning when it leaves additionalProperties out.
+func TestToolInvocationPolicyUnknownConditionKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		additional   string
+		wantSummary  string
+		wantSeverity tfprotov6.DiagnosticSeverity
+	}{
+		{"closed schema", `,"additionalProperties":false`, "Unknown Condition Key", tfprotov6.DiagnosticSeverityError},
+		{"additionalProperties omitted", ``, "Undeclared Condition Key", tfprotov6.DiagnosticSeverityWarning},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			backend := newFixtureBackend(t, map[string]json.RawMessage{
+				"/api/tools": json.RawMessage(`[{"id":"` + policySetToolA + `","name":"filesystem__read_file","createdAt":"2026-10-01T00:00:00Z","updatedAt":"2026-10-01T00:00:00Z",` +
+					`"parameters":{"type":"object","properties":{"path":{"type":"string"}}` + tc.additional + `}}]`),
+			})
+			ps := newConfiguredProviderServer(t, backend.URL)
+			ctx := t.Context()
+			schemaResp, err := ps.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			objType := schemaResp.ResourceSchemas["archestra_tool_invocation_policy"].ValueType().(tftypes.Object)
+			raw := `{"tool_id":"` + policySetToolA + `","action":"block_always","conditions":[` +
+				`{"key":"path","operator":"startsWith","value":"/etc/"},{"key":"pth","operator":"equal","value":"/"}]}`
+			config := validatorConfig(t, objType, raw, nil)
+			proposed := validatorConfig(t, objType, raw, []string{"id"})
+			null, err := tfprotov6.NewDynamicValue(objType, tftypes.NewValue(objType, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := ps.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "archestra_tool_invocation_policy",
+				PriorState:       &null,
+				ProposedNewState: &proposed,
+				Config:           &config,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantPath := tftypes.NewAttributePath().WithAttributeName("conditions").WithElementKeyInt(1).WithAttributeName("key")
+			if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != tc.wantSummary ||
+				resp.Diagnostics[0].Severity != tc.wantSeverity || !resp.Diagnostics[0].Attribute.Equal(wantPath) {
+				t.Fatalf("diagnostics = %+v, want one %s at %s", resp.Diagnostics, tc.wantSummary, wantPath)
+			}
+		})
+	}
+}
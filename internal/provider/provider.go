@@ -3,12 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -18,12 +23,18 @@ import (
 
 // 2m is a backstop, not a throttle: the retry helper tops out near 100s of
 // cumulative wait, so this leaves headroom without papering over a hung backend.
+// A configured resource `timeouts` block replaces it (deadlineTransport).
 const defaultHTTPTimeout = 2 * time.Minute
 
 const envHTTPTimeout = "ARCHESTRA_HTTP_TIMEOUT"
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ provider.Provider = &ArchestraProvider{}
+var (
+	_ provider.Provider                  = &ArchestraProvider{}
+	_ provider.ProviderWithListResources = &ArchestraProvider{}
+	_ provider.ProviderWithFunctions     = &ArchestraProvider{}
+	_ provider.ProviderWithActions       = &ArchestraProvider{}
+)
 
 // ArchestraProvider defines the provider implementation.
 type ArchestraProvider struct {
@@ -35,8 +46,27 @@ type ArchestraProvider struct {
 
 // ArchestraProviderModel describes the provider data model.
 type ArchestraProviderModel struct {
-	BaseURL types.String `tfsdk:"base_url"`
-	APIKey  types.String `tfsdk:"api_key"`
+	BaseURL                   types.String `tfsdk:"base_url"`
+	APIKey                    types.String `tfsdk:"api_key"`
+	SkipCredentialsValidation types.Bool   `tfsdk:"skip_credentials_validation"`
+	DefaultLabels             types.Map    `tfsdk:"default_labels"`
+	OrganizationID            types.String `tfsdk:"organization_id"`
+}
+
+// ArchestraProviderData is handed to every resource and data source as
+// ResourceData / DataSourceData.
+type ArchestraProviderData struct {
+	Client *client.ClientWithResponses
+	// Permissions is nil when skip_credentials_validation bypassed the
+	// GetUserPermissions call; plan-time permission checks are skipped then.
+	Permissions UserPermissions
+	// DefaultLabels are merged into the labels of every labelled resource;
+	// see labels_shared.go.
+	DefaultLabels map[string]string
+	// OrganizationID is the organization the API key acts in; resources
+	// record it and refuse to cross it (see organization_shared.go). Empty
+	// when it could not be determined.
+	OrganizationID string
 }
 
 func (p *ArchestraProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -64,6 +94,28 @@ func (p *ArchestraProvider) Schema(ctx context.Context, req provider.SchemaReque
 				Optional:  true,
 				Sensitive: true,
 			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				MarkdownDescription: "Skip the authenticated credential check and backend version handshake the provider performs during configuration. " +
+					"By default a wrong `api_key` or `base_url` fails once, up front, instead of surfacing as a 401 on every resource. " +
+					"Set this when the backend is not reachable at plan time (for example, it is created in the same apply). " +
+					"Also reads from the `" + envSkipCredentialsValidation + "` environment variable.",
+				Optional: true,
+			},
+			"default_labels": schema.MapAttribute{
+				MarkdownDescription: "Labels applied to every labelled resource (`archestra_agent`, `archestra_llm_proxy`, `archestra_mcp_gateway`, `archestra_mcp_registry_catalog_item`). " +
+					"A resource's own `labels` win on key conflicts. The merged result is exposed as each resource's computed `effective_labels`; " +
+					"changing a default updates `effective_labels` without showing drift on `labels`.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "Organization this provider configuration is expected to manage. API keys are scoped to one organization, which the backend selects from the key; " +
+					"this attribute does not select an organization, it only asserts one. Setting it makes configure fail if `api_key` belongs to a different organization, " +
+					"or if the organization cannot be verified because the key lacks `organization: read`. To manage several organizations, declare one provider alias per organization, each with its own key. Every resource records its organization as `organization_id` " +
+					"and refuses to refresh or plan against another one, so state cannot silently cross organizations when keys are swapped. " +
+					"Also reads from the `" + envOrganizationID + "` environment variable.",
+				Optional: true,
+			},
 		},
 	}
 }
@@ -102,6 +154,24 @@ func (p *ArchestraProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if config.DefaultLabels.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_labels"),
+			"Unknown Archestra Default Labels",
+			"The provider cannot merge default labels into resources while default_labels is unknown. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.OrganizationID.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("organization_id"),
+			"Unknown Archestra Organization ID",
+			"The provider cannot verify the API key's organization while organization_id is unknown. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the "+envOrganizationID+" environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -131,10 +201,30 @@ func (p *ArchestraProvider) Configure(ctx context.Context, req provider.Configur
 		}
 	}
 
+	organizationID := config.OrganizationID.ValueString()
+	if organizationID == "" {
+		organizationID = os.Getenv(envOrganizationID)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	skipCredentialsValidation := config.SkipCredentialsValidation.ValueBool()
+	if config.SkipCredentialsValidation.IsNull() {
+		if raw := os.Getenv(envSkipCredentialsValidation); raw != "" {
+			v, err := strconv.ParseBool(raw)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid "+envSkipCredentialsValidation,
+					fmt.Sprintf("%s=%q is not a valid boolean: %s", envSkipCredentialsValidation, raw, err),
+				)
+				return
+			}
+			skipCredentialsValidation = v
+		}
+	}
+
 	httpClient, err := buildHTTPClient()
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid "+envHTTPTimeout, err.Error())
@@ -161,10 +251,38 @@ func (p *ArchestraProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	// Make the Archestra client available during DataSource and Resource
-	// type Configure methods.
-	resp.DataSourceData = apiClient
-	resp.ResourceData = apiClient
+	providerData := &ArchestraProviderData{Client: apiClient, OrganizationID: organizationID}
+	if !config.DefaultLabels.IsNull() {
+		resp.Diagnostics.Append(config.DefaultLabels.ElementsAs(ctx, &providerData.DefaultLabels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !skipCredentialsValidation {
+		permissions, diags := validateCredentials(ctx, apiClient, baseURL)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		providerData.Permissions = permissions
+
+		orgID, diags := resolveOrganization(ctx, apiClient, organizationID)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		providerData.OrganizationID = orgID
+
+		resp.Diagnostics.Append(checkBackendVersion(ctx, httpClient, baseURL)...)
+	}
+
+	// Make the Archestra client available during DataSource, Resource,
+	// ListResource and Action type Configure methods.
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.ListResourceData = providerData
+	resp.ActionData = providerData
 }
 
 func (p *ArchestraProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -172,6 +290,8 @@ func (p *ArchestraProvider) Resources(ctx context.Context) []func() resource.Res
 		NewAgentResource,
 		NewLlmProxyResource,
 		NewMcpGatewayResource,
+		NewProfileResource,
+		NewBuiltinAgentResource,
 		NewMCPServerResource,
 		NewMCPServerRegistryResource,
 		NewTrustedDataPolicyResource,
@@ -190,6 +310,45 @@ func (p *ArchestraProvider) Resources(ctx context.Context) []func() resource.Res
 		NewToolInvocationPolicyDefaultResource,
 		NewTrustedDataPolicyDefaultResource,
 		NewToolPolicyAutoConfigResource,
+		NewToolPolicySetResource,
+	}
+}
+
+func (p *ArchestraProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewAgentListResource,
+		NewLlmProxyListResource,
+		NewMcpGatewayListResource,
+		NewProfileListResource,
+		NewMCPServerListResource,
+		NewMCPServerRegistryListResource,
+		NewTrustedDataPolicyListResource,
+		NewToolInvocationPolicyListResource,
+		NewTeamListResource,
+		NewLimitListResource,
+		NewOptimizationRuleListResource,
+		NewIdentityProviderListResource,
+	}
+}
+
+func (p *ArchestraProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewLabelFilterFunction,
+		NewDelegationIDFunction,
+		NewToolIDsByNameFunction,
+		NewParseCronFunction,
+	}
+}
+
+func (p *ArchestraProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewScheduleTriggerRunAction,
+		NewMcpServerReinstallAction,
+		NewMcpServerReauthenticateAction,
+		NewTokenRotateAction,
+		NewEmbeddingConnectionCheckAction,
+		NewLlmModelsSyncAction,
+		NewAgentToolPoliciesAutoConfigureAction,
 	}
 }
 
@@ -202,6 +361,11 @@ func (p *ArchestraProvider) DataSources(ctx context.Context) []func() datasource
 		NewMCPServerToolDataSource,
 		NewMcpToolCallsDataSource,
 		NewTeamExternalGroupsDataSource,
+		NewUserPermissionsDataSource,
+		NewAgentEmailAddressDataSource,
+		NewLimitsDataSource,
+		NewToolPolicyEvaluationDataSource,
+		NewToolPolicyReplayDataSource,
 	}
 }
 
@@ -219,11 +383,46 @@ func buildHTTPClient() (*http.Client, error) {
 		return nil, err
 	}
 	return &http.Client{
-		Timeout:   timeout,
-		Transport: newHTTPTransport(),
+		Transport: &deadlineTransport{base: newHTTPTransport(), timeout: timeout},
 	}, nil
 }
 
+// deadlineTransport applies the per-request timeout only to requests whose
+// context carries no deadline of its own. A resource `timeouts` block
+// (timeoutContext) sets such a deadline, and it must be able to outlast
+// ARCHESTRA_HTTP_TIMEOUT — http.Client.Timeout would cap it regardless.
+// Like http.Client.Timeout, the budget covers reading the body: cancel
+// runs on Body.Close.
+type deadlineTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *deadlineTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if _, ok := req.Context().Deadline(); ok {
+		return t.base.RoundTrip(req)
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
 // http.Client treats Timeout == 0 as "no timeout" — the exact failure mode
 // this setting exists to prevent — so reject zero and negatives.
 func resolveHTTPTimeout(raw string) (time.Duration, error) {
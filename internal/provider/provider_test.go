@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -130,3 +132,40 @@ func TestBuildHTTPClient_WiredEndToEnd(t *testing.T) {
 		t.Errorf("request took %v; expected ~50ms timeout to fire", elapsed)
 	}
 }
+
+func TestBuildHTTPClient_ContextDeadlineOutlastsTimeout(t *testing.T) {
+	// A resource `timeouts` block sets a context deadline; it replaces
+	// ARCHESTRA_HTTP_TIMEOUT rather than being capped by it.
+	t.Setenv("ARCHESTRA_HTTP_TIMEOUT", "50ms")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(150 * time.Millisecond)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := buildHTTPClient()
+	if err != nil {
+		t.Fatalf("buildHTTPClient: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext: %v", err)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("request with a context deadline failed: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(body) != "ok" {
+		t.Errorf("body = %q, want %q", body, "ok")
+	}
+}
@@ -1,9 +1,7 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
@@ -19,28 +17,28 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 var (
-	_ resource.Resource                   = &AgentResource{}
-	_ resource.ResourceWithImportState    = &AgentResource{}
-	_ resource.ResourceWithValidateConfig = &AgentResource{}
+	_ resource.Resource                     = &AgentResource{}
+	_ resource.ResourceWithImportState      = &AgentResource{}
+	_ resource.ResourceWithIdentity         = &AgentResource{}
+	_ resource.ResourceWithConfigValidators = &AgentResource{}
+	_ resource.ResourceWithModifyPlan       = &AgentResource{}
 )
 
 func NewAgentResource() resource.Resource { return &AgentResource{} }
 
 type AgentResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 // AgentResourceModel is the schema for an internal Archestra agent (chat agent
 // with prompts, knowledge sources, optional email triggers, etc.).
 type AgentResourceModel struct {
-	ID                         types.String             `tfsdk:"id"`
-	Name                       types.String             `tfsdk:"name"`
-	Description                types.String             `tfsdk:"description"`
-	Icon                       types.String             `tfsdk:"icon"`
+	AgentCommonModel
+
 	SystemPrompt               types.String             `tfsdk:"system_prompt"`
 	LlmModel                   types.String             `tfsdk:"llm_model"`
 	LlmApiKeyId                types.String             `tfsdk:"llm_api_key_id"`
@@ -49,13 +47,11 @@ type AgentResourceModel struct {
 	IncomingEmailEnabled       types.Bool               `tfsdk:"incoming_email_enabled"`
 	IncomingEmailAllowedDomain types.String             `tfsdk:"incoming_email_allowed_domain"`
 	IncomingEmailSecurityMode  types.String             `tfsdk:"incoming_email_security_mode"`
-	ConsiderContextUntrusted   types.Bool               `tfsdk:"consider_context_untrusted"`
-	IsDefault                  types.Bool               `tfsdk:"is_default"`
+	IncomingEmailAddress       types.String             `tfsdk:"incoming_email_address"`
 	SuggestedPrompts           []SuggestedPromptModel   `tfsdk:"suggested_prompts"`
-	Labels                     []AgentLabelModel        `tfsdk:"labels"`
 	BuiltInAgentConfig         *BuiltInAgentConfigModel `tfsdk:"built_in_agent_config"`
-	Scope                      types.String             `tfsdk:"scope"`
-	Teams                      types.List               `tfsdk:"teams"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
 }
 
 func (r *AgentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -65,7 +61,7 @@ func (r *AgentResource) Metadata(_ context.Context, req resource.MetadataRequest
 func (r *AgentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Internal chat agent — system prompt + LLM, optionally augmented with knowledge bases, connectors, and email triggers.",
-		Attributes: map[string]schema.Attribute{
+		Attributes: withAttributes(map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "Agent identifier",
@@ -89,11 +85,11 @@ func (r *AgentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"llm_model": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Model ID used for LLM calls",
+				MarkdownDescription: "Model ID used for LLM calls. Requires `llm_api_key_id`.",
 			},
 			"llm_api_key_id": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "ID of the LLM provider API key the agent should use",
+				MarkdownDescription: "ID of the LLM provider API key the agent should use.",
 			},
 			"knowledge_base_ids": schema.ListAttribute{
 				Optional:            true,
@@ -112,7 +108,7 @@ func (r *AgentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			},
 			"incoming_email_allowed_domain": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Allowed sender domain when `incoming_email_security_mode = \"internal\"`",
+				MarkdownDescription: "Allowed sender domain when `incoming_email_security_mode = \"internal\"`. Only valid with `incoming_email_enabled = true`.",
 			},
 			"incoming_email_security_mode": schema.StringAttribute{
 				Optional:            true,
@@ -122,6 +118,12 @@ func (r *AgentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					stringvalidator.OneOf("private", "internal", "public"),
 				},
 			},
+			"incoming_email_address": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Address that invokes the agent by email, for MX records and forwarding rules. " +
+					"Null while `incoming_email_enabled` is false or the organization has no incoming-email provider configured.",
+				PlanModifiers: []planmodifier.String{UseStateUnlessChangedString(path.Root("incoming_email_enabled"))},
+			},
 			"consider_context_untrusted": schema.BoolAttribute{
 				Optional:            true,
 				Computed:            true,
@@ -156,6 +158,7 @@ func (r *AgentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
+			"effective_labels": effectiveLabelsSchemaAttribute(),
 			"labels": schema.SetNestedAttribute{
 				Optional:            true,
 				MarkdownDescription: "Key/value labels for organizing agents",
@@ -166,7 +169,8 @@ func (r *AgentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 					},
 				},
 			},
-		},
+			"organization_id": organizationIDSchemaAttribute(),
+		}, agentAuditSchemaAttributes()),
 		Blocks: map[string]schema.Block{
 			"built_in_agent_config": schema.SingleNestedBlock{
 				MarkdownDescription: "Built-in agent configuration. Discriminated by `name`.",
@@ -191,271 +195,124 @@ func (r *AgentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 	}
 }
 
+func (r *AgentResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("Agent identifier.")
+}
+
 func (r *AgentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
 }
 
-func (r *AgentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	// Run merge-patch with prior=null Object: every non-null plan attribute
-	// emits, which is exactly Create semantics.
-	priorNull := tftypes.NewValue(req.Plan.Schema.Type().TerraformType(ctx), nil)
-	patch := MergePatch(ctx, req.Plan.Raw, priorNull, agentAttrSpec, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	// agent_type isn't surfaced as a TF attribute (the resource is type-specific
-	// by construction); set it on the wire so the backend stores the right
-	// discriminator.
-	patch["agentType"] = "agent"
-
-	body, err := json.Marshal(patch)
-	if err != nil {
-		resp.Diagnostics.AddError("Marshal Error", fmt.Sprintf("unable to marshal merge patch: %s", err))
-		return
+func (r *AgentResource) kind() agentKind[AgentResourceModel] {
+	return agentKind[AgentResourceModel]{
+		agentType: "agent",
+		noun:      "agent",
+		attrSpec:  agentAttrSpec,
+		flatten: func(ctx context.Context, data *AgentResourceModel, got *agentAPIResponse, body []byte, diags *diag.Diagnostics) {
+			r.flattenAgentResponse(ctx, data, got, body, diags)
+			r.readIncomingEmailAddress(ctx, data, diags)
+		},
+		deleteForbidden: diag.NewErrorDiagnostic(
+			"Cannot Delete Built-In Agent",
+			"Built-in agents cannot be deleted from Archestra. Remove the resource from state with `terraform state rm` and manage the agent with `archestra_builtin_agent`, which adopts it and restores its pre-adoption values on destroy.",
+		),
 	}
-	LogPatch(ctx, "archestra_agent Create", patch, agentAttrSpec)
+}
 
-	apiResp, err := r.client.CreateAgentWithBodyWithResponse(ctx, "application/json", bytes.NewReader(body))
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create agent: %s", err))
-		return
-	}
-	if apiResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
-			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
-		)
-		return
-	}
+func (r *AgentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.kind().modifyPlan(ctx, r.providerData, req, resp)
+}
 
-	var data AgentResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	r.flattenAgentResponse(ctx, &data, apiResp.Body, &resp.Diagnostics)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+func (r *AgentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.kind().create(ctx, r.client, req, resp)
 }
 
 func (r *AgentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data AgentResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+	r.kind().read(ctx, r.providerData, r.client, req, resp)
+}
 
-	if data.ID.IsNull() || data.ID.ValueString() == "" {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-	id, err := uuid.Parse(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse agent ID: %s", err))
-		return
-	}
+func (r *AgentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.kind().update(ctx, r.client, req, resp)
+}
 
-	apiResp, err := r.client.GetAgentWithResponse(ctx, id)
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read agent, got error: %s", err))
-		return
-	}
-	if apiResp.JSON404 != nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-	if apiResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
-			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK, got status %d", apiResp.StatusCode()),
-		)
-		return
-	}
+func (r *AgentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	r.kind().delete(ctx, r.client, req, resp)
+}
 
-	r.flattenAgentResponse(ctx, &data, apiResp.Body, &resp.Diagnostics)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+func (r *AgentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importByNaturalKey(ctx, agentNameImport(r.client, client.GetAllAgentsParamsAgentTypeAgent, "agent"), req, resp)
 }
 
-func (r *AgentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var stateData AgentResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
+// ConfigValidators surfaces the cross-field rules the backend would otherwise
+// reject with a 400 at apply.
+func (r *AgentResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	validators := agentScopeValidators()
+	validators = append(validators, agentLLMValidators()...)
+	return append(validators, agentIncomingEmailValidators()...)
+}
 
-	id, err := uuid.Parse(stateData.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse agent ID: %s", err))
-		return
-	}
+// flattenAgentResponse maps an agent API response (decoded from the raw body
+// bytes) into the AgentResourceModel state.
+func (r *AgentResource) flattenAgentResponse(ctx context.Context, data *AgentResourceModel, got *agentAPIResponse, body []byte, diags *diag.Diagnostics) {
+	flattenAgentCommon(ctx, &data.AgentCommonModel, got, r.providerData.defaultLabels(), diags)
 
-	patch := MergePatch(ctx, req.Plan.Raw, req.State.Raw, agentAttrSpec, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	LogPatch(ctx, "archestra_agent Update", patch, agentAttrSpec)
+	optionalStringFromAPI(&data.SystemPrompt, got.SystemPrompt)
+	optionalStringFromAPI(&data.LlmModel, got.LlmModel)
+	optionalUUIDFromAPI(&data.LlmApiKeyId, got.LlmApiKeyId)
 
-	body, err := json.Marshal(patch)
-	if err != nil {
-		resp.Diagnostics.AddError("Marshal Error", fmt.Sprintf("unable to marshal merge patch: %s", err))
-		return
+	data.IncomingEmailEnabled = types.BoolValue(got.IncomingEmailEnabled)
+	optionalStringFromAPI(&data.IncomingEmailAllowedDomain, got.IncomingEmailAllowedDomain)
+	if got.IncomingEmailSecurityMode != "" {
+		data.IncomingEmailSecurityMode = types.StringValue(got.IncomingEmailSecurityMode)
+	} else if !data.IncomingEmailSecurityMode.IsNull() {
+		data.IncomingEmailSecurityMode = types.StringNull()
 	}
 
-	apiResp, err := r.client.UpdateAgentWithBodyWithResponse(ctx, id, "application/json", bytes.NewReader(body))
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update agent: %s", err))
-		return
-	}
-	if IsNotFound(apiResp) {
-		resp.Diagnostics.AddError(
-			"Resource Deleted Outside Terraform",
-			"The resource was deleted on the backend between refresh and apply. "+
-				"Re-run `terraform apply` — the next refresh drops it from state and the plan recreates it.",
-		)
-		return
-	}
-	if apiResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
-			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
-		)
-		return
-	}
+	data.SuggestedPrompts = suggestedPromptsFromAPI(got.SuggestedPrompts)
+	stringListFromAPI(ctx, &data.KnowledgeBaseIds, got.KnowledgeBaseIds, diags)
+	stringListFromAPI(ctx, &data.ConnectorIds, got.ConnectorIds, diags)
 
-	var data AgentResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	r.flattenAgentResponse(ctx, &data, apiResp.Body, &resp.Diagnostics)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	data.BuiltInAgentConfig = builtInAgentConfigFromResponse(body)
 }
 
-func (r *AgentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data AgentResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
+// readIncomingEmailAddress fills incoming_email_address from the agent's
+// email-address endpoint. The address is derived from the organization's
+// email provider and the agent ID, so it is not part of the agent record.
+func (r *AgentResource) readIncomingEmailAddress(ctx context.Context, data *AgentResourceModel, diags *diag.Diagnostics) {
+	data.IncomingEmailAddress = types.StringNull()
+	if diags.HasError() || !data.IncomingEmailEnabled.ValueBool() {
 		return
 	}
-
 	id, err := uuid.Parse(data.ID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse agent ID: %s", err))
+		diags.AddError("Invalid ID", fmt.Sprintf("Unable to parse agent ID: %s", err))
 		return
 	}
-
-	apiResp, err := r.client.DeleteAgentWithResponse(ctx, id)
+	apiResp, err := r.client.GetAgentEmailAddressWithResponse(ctx, id)
 	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete agent, got error: %s", err))
+		diags.AddError("API Error", fmt.Sprintf("Unable to read agent email address, got error: %s", err))
 		return
 	}
-	if apiResp.StatusCode() == 403 {
-		resp.Diagnostics.AddError(
-			"Cannot Delete Built-In Agent",
-			"Built-in agents cannot be deleted from Archestra. Remove the `built_in_agent_config` block and apply, then run `terraform destroy`.",
-		)
-		return
-	}
-	if apiResp.JSON200 == nil && apiResp.JSON404 == nil {
-		resp.Diagnostics.AddError(
+	if apiResp.JSON200 == nil {
+		diags.AddError(
 			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK or 404 Not Found, got status %d", apiResp.StatusCode()),
+			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
 		)
-	}
-}
-
-func (r *AgentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
-}
-
-// ValidateConfig enforces cross-field constraints the schema can't
-// express on its own — `scope = "team"` must come with a non-empty
-// `teams` list, and `incoming_email_security_mode = "internal"` must
-// come with `incoming_email_allowed_domain`. Both used to fail at
-// apply with a backend 400; this catches them at plan.
-func (r *AgentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data AgentResourceModel
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	if !data.Scope.IsNull() && !data.Scope.IsUnknown() && data.Scope.ValueString() == "team" {
-		if data.Teams.IsNull() || data.Teams.IsUnknown() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("teams"),
-				"Missing Required Attribute",
-				`teams must be set when scope = "team"`,
-			)
-		} else if len(data.Teams.Elements()) == 0 {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("teams"),
-				"Invalid Attribute Value",
-				`teams must contain at least one team ID when scope = "team"`,
-			)
-		}
-	}
-
-	if !data.IncomingEmailSecurityMode.IsNull() && !data.IncomingEmailSecurityMode.IsUnknown() &&
-		data.IncomingEmailSecurityMode.ValueString() == "internal" {
-		if data.IncomingEmailAllowedDomain.IsNull() || data.IncomingEmailAllowedDomain.IsUnknown() ||
-			data.IncomingEmailAllowedDomain.ValueString() == "" {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("incoming_email_allowed_domain"),
-				"Missing Required Attribute",
-				`incoming_email_allowed_domain must be set when incoming_email_security_mode = "internal"`,
-			)
-		}
-	}
-}
-
-// flattenAgentResponse maps an agent API response (decoded from the raw body
-// bytes) into the AgentResourceModel state.
-func (r *AgentResource) flattenAgentResponse(ctx context.Context, data *AgentResourceModel, body []byte, diags *diag.Diagnostics) {
-	resp := parseAgentResponse(body, diags)
-	if resp == nil {
 		return
 	}
-
-	data.ID = types.StringValue(resp.Id.String())
-	data.Name = types.StringValue(resp.Name)
-	optionalStringFromAPI(&data.Description, resp.Description)
-	optionalStringFromAPI(&data.Icon, resp.Icon)
-	optionalStringFromAPI(&data.SystemPrompt, resp.SystemPrompt)
-	optionalStringFromAPI(&data.LlmModel, resp.LlmModel)
-	optionalUUIDFromAPI(&data.LlmApiKeyId, resp.LlmApiKeyId)
-
-	data.IncomingEmailEnabled = types.BoolValue(resp.IncomingEmailEnabled)
-	optionalStringFromAPI(&data.IncomingEmailAllowedDomain, resp.IncomingEmailAllowedDomain)
-	if resp.IncomingEmailSecurityMode != "" {
-		data.IncomingEmailSecurityMode = types.StringValue(resp.IncomingEmailSecurityMode)
-	} else if !data.IncomingEmailSecurityMode.IsNull() {
-		data.IncomingEmailSecurityMode = types.StringNull()
-	}
-
-	data.ConsiderContextUntrusted = types.BoolValue(resp.ConsiderContextUntrusted)
-	data.IsDefault = types.BoolValue(resp.IsDefault)
-	data.Scope = types.StringValue(resp.Scope)
-	data.Teams = teamsListFromAPI(ctx, data.Teams, resp.Teams, diags)
-
-	data.SuggestedPrompts = suggestedPromptsFromAPI(resp.SuggestedPrompts)
-	stringListFromAPI(ctx, &data.KnowledgeBaseIds, resp.KnowledgeBaseIds, diags)
-	stringListFromAPI(ctx, &data.ConnectorIds, resp.ConnectorIds, diags)
-
-	data.Labels = flattenAgentLabels(data.Labels, resp.Labels)
-
-	data.BuiltInAgentConfig = builtInAgentConfigFromResponse(body)
+	data.IncomingEmailAddress = types.StringPointerValue(apiResp.JSON200.EmailAddress)
 }
 
 // AttrSpecs implements the resourceWithAttrSpec interface (see specdrift_test.go).
@@ -468,22 +325,19 @@ func (r *AgentResource) APIShape() any { return client.GetAgentResponse{} }
 // model on this resource:
 //   - agentType: discriminator the provider uses to split one backend table
 //     into three resources; not user-facing.
-//   - authorId/authorName/builtIn/organizationId/createdAt/updatedAt:
-//     audit/ownership metadata; could be added as Computed-only later if
-//     users ask, but no consumer has requested it yet.
+//   - builtIn: implied by built_in_agent_config; built-in agents are
+//     managed with archestra_builtin_agent.
 //   - suggestedPrompts/passthroughHeaders: llm_proxy / mcp_gateway-only
 //     wire fields; not present on the agent variant of the schema.
 //   - identityProviderId: gateway/proxy-only on the schema side (an agent
 //     never has one); the wire returns null for agent rows.
-//   - slug: auto-generated URL slug, not user-configurable.
 //   - tools: list of tool assignments managed by archestra_agent_tool —
 //     duplicating it here would create a phantom diff against the m2m
 //     relationship.
 func (r *AgentResource) KnownIntentionallySkipped() []string {
 	return []string{
-		"agentType", "authorId", "authorName", "builtIn", "organizationId",
-		"createdAt", "updatedAt", "suggestedPrompts", "passthroughHeaders",
-		"identityProviderId", "slug", "tools",
+		"agentType", "builtIn", "suggestedPrompts", "passthroughHeaders",
+		"identityProviderId", "tools",
 	}
 }
 
@@ -524,13 +378,10 @@ var agentAttrSpec = []AttrSpec{
 			{TFName: "summary_title", JSONName: "summaryTitle", Kind: Scalar},
 		},
 	},
-	{
-		TFName: "labels", JSONName: "labels", Kind: Set,
-		Children: []AttrSpec{
-			{TFName: "key", JSONName: "key", Kind: Scalar},
-			{TFName: "value", JSONName: "value", Kind: Scalar},
-		},
-	},
+	// `labels` merges with the provider's default_labels; effective_labels
+	// carries the wire field (see labels_shared.go).
+	{TFName: "labels", JSONName: "labels", Kind: Synthetic},
+	{TFName: "effective_labels", JSONName: "labels", Kind: Map, Encoder: encodeEffectiveLabels},
 	{
 		TFName: "built_in_agent_config", JSONName: "builtInAgentConfig", Kind: AtomicObject,
 		Children: []AttrSpec{
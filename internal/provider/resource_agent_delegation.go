@@ -19,7 +19,9 @@ import (
 )
 
 var _ resource.Resource = &AgentDelegationResource{}
+var _ resource.ResourceWithIdentity = &AgentDelegationResource{}
 var _ resource.ResourceWithImportState = &AgentDelegationResource{}
+var _ resource.ResourceWithModifyPlan = &AgentDelegationResource{}
 
 // The backend has no single-edge create endpoint — only a full-replace sync
 // (POST /api/agents/:id/delegations). Create therefore reads the current
@@ -47,13 +49,16 @@ func NewAgentDelegationResource() resource.Resource {
 }
 
 type AgentDelegationResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type AgentDelegationResourceModel struct {
 	ID            types.String `tfsdk:"id"`
 	AgentID       types.String `tfsdk:"agent_id"`
 	TargetAgentID types.String `tfsdk:"target_agent_id"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
 }
 
 func (r *AgentDelegationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -94,25 +99,39 @@ func (r *AgentDelegationResource) Schema(_ context.Context, _ resource.SchemaReq
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"organization_id": organizationIDSchemaAttribute(),
 		},
 	}
 }
 
+func (r *AgentDelegationResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = compositeIdentitySchema(map[string]string{
+		"agent_id":        "Delegating agent.",
+		"target_agent_id": "Agent delegated to.",
+	})
+}
+
 func (r *AgentDelegationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *AgentDelegationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "agent", SubResource: true}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 func (r *AgentDelegationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -163,6 +182,7 @@ func (r *AgentDelegationResource) Create(ctx context.Context, req resource.Creat
 
 	data.ID = types.StringValue(fmt.Sprintf("%s:%s", agentUUID, targetUUID))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *AgentDelegationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -173,6 +193,12 @@ func (r *AgentDelegationResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	parts := strings.Split(data.ID.ValueString(), ":")
 	if len(parts) != 2 {
 		resp.State.RemoveResource(ctx)
@@ -218,6 +244,8 @@ func (r *AgentDelegationResource) Read(ctx context.Context, req resource.ReadReq
 	for _, target := range *delResp.JSON200 {
 		if target.Id == targetUUID {
 			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+			syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 			return
 		}
 	}
@@ -234,6 +262,7 @@ func (r *AgentDelegationResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *AgentDelegationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -275,6 +304,10 @@ func (r *AgentDelegationResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (r *AgentDelegationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importFromIdentity(ctx, req, resp) {
+		setCompositeImportID(ctx, resp, ":", "agent_id", "target_agent_id")
+		return
+	}
 	parts := strings.Split(req.ID, ":")
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError(
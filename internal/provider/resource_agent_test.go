@@ -150,7 +150,7 @@ resource "archestra_agent" "builtin" {
 }
 
 // TestAccAgentResource_TeamScopeMissingTeams pins the cross-field
-// validator from ValidateConfig: scope = "team" without a teams list
+// validator from ConfigValidators: scope = "team" without a teams list
 // must error at plan time, not 400 at apply.
 func TestAccAgentResource_TeamScopeMissingTeams(t *testing.T) {
 	resource.Test(t, resource.TestCase{
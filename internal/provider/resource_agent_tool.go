@@ -9,6 +9,7 @@ import (
 
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -24,14 +25,18 @@ import (
 )
 
 var _ resource.Resource = &AgentToolResource{}
+var _ resource.ResourceWithIdentity = &AgentToolResource{}
 var _ resource.ResourceWithImportState = &AgentToolResource{}
+var _ resource.ResourceWithModifyPlan = &AgentToolResource{}
+var _ resource.ResourceWithMoveState = &AgentToolResource{}
 
 func NewAgentToolResource() resource.Resource {
 	return &AgentToolResource{}
 }
 
 type AgentToolResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type AgentToolResourceModel struct {
@@ -40,15 +45,19 @@ type AgentToolResourceModel struct {
 	ToolID                   types.String `tfsdk:"tool_id"`
 	McpServerID              types.String `tfsdk:"mcp_server_id"`
 	CredentialResolutionMode types.String `tfsdk:"credential_resolution_mode"`
+
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *AgentToolResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_agent_tool"
 }
 
-func (r *AgentToolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *AgentToolResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Assigns one tool to one agent. For bulk assignment of every tool from an install, use `archestra_agent_tool_batch` (one API call regardless of count).",
+		MarkdownDescription: "Assigns one tool to one agent. For bulk assignment of every tool from an install, use `archestra_agent_tool_batch` (one API call regardless of count).\n\n" +
+			"A `moved` block from a single-tool `archestra_agent_tool_batch` re-homes the assignment without recreating it.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -92,25 +101,48 @@ func (r *AgentToolResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					stringvalidator.OneOf("static", "dynamic", "enterprise_managed"),
 				},
 			},
+			"organization_id": organizationIDSchemaAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx, true),
 		},
 	}
 }
 
+func (r *AgentToolResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = compositeIdentitySchema(map[string]string{
+		"agent_id": "Agent the tool is assigned to.",
+		"tool_id":  "Assigned tool.",
+	})
+}
+
+func (r *AgentToolResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		stateMoverFrom("archestra_agent_tool_batch", moveAgentToolBatchToAgentTool),
+	}
+}
+
 func (r *AgentToolResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *AgentToolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "agent", SubResource: true}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 func (r *AgentToolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -120,6 +152,11 @@ func (r *AgentToolResource) Create(ctx context.Context, req resource.CreateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := timeoutContext(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	agentUUID, err := uuid.Parse(data.AgentID.ValueString())
 	if err != nil {
@@ -173,6 +210,7 @@ func (r *AgentToolResource) Create(ctx context.Context, req resource.CreateReque
 
 	data.ID = types.StringValue(fmt.Sprintf("%s:%s", data.AgentID.ValueString(), data.ToolID.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *AgentToolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -182,6 +220,17 @@ func (r *AgentToolResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := timeoutContext(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
 
 	parts := strings.Split(data.ID.ValueString(), ":")
 	if len(parts) != 2 {
@@ -215,6 +264,8 @@ func (r *AgentToolResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *AgentToolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -224,6 +275,11 @@ func (r *AgentToolResource) Update(ctx context.Context, req resource.UpdateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := timeoutContext(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	agentUUID, err := uuid.Parse(data.AgentID.ValueString())
 	if err != nil {
@@ -241,6 +297,12 @@ func (r *AgentToolResource) Update(ctx context.Context, req resource.UpdateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// Nothing on the wire changed (e.g. only `timeouts`).
+	if len(patch) == 0 {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
+		return
+	}
 	LogPatch(ctx, "archestra_agent_tool Update", patch, agentToolAttrSpec)
 
 	bodyBytes, err := json.Marshal(patch)
@@ -280,6 +342,7 @@ func (r *AgentToolResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *AgentToolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -289,6 +352,11 @@ func (r *AgentToolResource) Delete(ctx context.Context, req resource.DeleteReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := timeoutContext(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	agentUUID, err := uuid.Parse(data.AgentID.ValueString())
 	if err != nil {
@@ -318,6 +386,10 @@ func (r *AgentToolResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *AgentToolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importFromIdentity(ctx, req, resp) {
+		setCompositeImportID(ctx, resp, ":", "agent_id", "tool_id")
+		return
+	}
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
 
@@ -23,7 +23,10 @@ import (
 
 var (
 	_ resource.Resource                = &AgentToolBatchResource{}
+	_ resource.ResourceWithIdentity    = &AgentToolBatchResource{}
 	_ resource.ResourceWithImportState = &AgentToolBatchResource{}
+	_ resource.ResourceWithModifyPlan  = &AgentToolBatchResource{}
+	_ resource.ResourceWithMoveState   = &AgentToolBatchResource{}
 )
 
 func NewAgentToolBatchResource() resource.Resource {
@@ -31,7 +34,8 @@ func NewAgentToolBatchResource() resource.Resource {
 }
 
 type AgentToolBatchResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 // AgentToolBatchResourceModel models a one-shot bulk assignment of every
@@ -46,6 +50,8 @@ type AgentToolBatchResourceModel struct {
 	McpServerID              types.String `tfsdk:"mcp_server_id"`
 	ToolIDs                  types.Set    `tfsdk:"tool_ids"`
 	CredentialResolutionMode types.String `tfsdk:"credential_resolution_mode"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
 }
 
 func (r *AgentToolBatchResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -54,7 +60,8 @@ func (r *AgentToolBatchResource) Metadata(_ context.Context, req resource.Metada
 
 func (r *AgentToolBatchResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Bulk-assigns a set of tools from one MCP server installation onto one agent in a single backend round-trip. Authoritative over `(agent_id, mcp_server_id)` — don't mix with `archestra_agent_tool` for the same pair.",
+		MarkdownDescription: "Bulk-assigns a set of tools from one MCP server installation onto one agent in a single backend round-trip. Authoritative over `(agent_id, mcp_server_id)` — don't mix with `archestra_agent_tool` for the same pair.\n\n" +
+			"A `moved` block from an `archestra_agent_tool` with `mcp_server_id` set re-homes the assignment without recreating it; the next refresh picks up the installation's other tools already assigned to the agent.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -99,21 +106,41 @@ func (r *AgentToolBatchResource) Schema(_ context.Context, _ resource.SchemaRequ
 					stringvalidator.OneOf("static", "dynamic", "enterprise_managed"),
 				},
 			},
+			"organization_id": organizationIDSchemaAttribute(),
 		},
 	}
 }
 
+func (r *AgentToolBatchResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = compositeIdentitySchema(map[string]string{
+		"agent_id":      "Agent the tools are assigned to.",
+		"mcp_server_id": "MCP server installation the tools come from.",
+	})
+}
+
+func (r *AgentToolBatchResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		stateMoverFrom("archestra_agent_tool", moveAgentToolToBatch),
+	}
+}
+
 func (r *AgentToolBatchResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T", req.ProviderData))
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *AgentToolBatchResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "agent", SubResource: true}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 func (r *AgentToolBatchResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -135,6 +162,7 @@ func (r *AgentToolBatchResource) Create(ctx context.Context, req resource.Create
 
 	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", agentUUID, mcpUUID))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *AgentToolBatchResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -144,6 +172,12 @@ func (r *AgentToolBatchResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	if data.AgentID.IsNull() || data.AgentID.ValueString() == "" ||
 		data.McpServerID.IsNull() || data.McpServerID.ValueString() == "" {
 		resp.State.RemoveResource(ctx)
@@ -192,6 +226,8 @@ func (r *AgentToolBatchResource) Read(ctx context.Context, req resource.ReadRequ
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *AgentToolBatchResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -237,6 +273,7 @@ func (r *AgentToolBatchResource) Update(ctx context.Context, req resource.Update
 
 	plan.ID = state.ID
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *AgentToolBatchResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -274,6 +311,10 @@ func (r *AgentToolBatchResource) Delete(ctx context.Context, req resource.Delete
 
 func (r *AgentToolBatchResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Import ID format: "<agent_id>:<mcp_server_id>". Tool IDs come from Read().
+	if importFromIdentity(ctx, req, resp) {
+		setCompositeImportID(ctx, resp, ":", "agent_id", "mcp_server_id")
+		return
+	}
 	parts := strings.SplitN(req.ID, ":", 2)
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError("Invalid Import ID",
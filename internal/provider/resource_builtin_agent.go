@@ -0,0 +1,497 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ resource.Resource = &BuiltinAgentResource{}
+var _ resource.ResourceWithImportState = &BuiltinAgentResource{}
+var _ resource.ResourceWithIdentity = &BuiltinAgentResource{}
+var _ resource.ResourceWithModifyPlan = &BuiltinAgentResource{}
+var _ resource.ResourceWithConfigValidators = &BuiltinAgentResource{}
+
+// builtinAgentNames are the built-in agents the backend seeds in every
+// organization, keyed by builtInAgentConfig.name.
+var builtinAgentNames = []string{"policy-configuration-subagent", "dual-llm-main-agent", "dual-llm-quarantine-agent"}
+
+// builtinAgentPreAdoptionKey is the private-state key holding the merge
+// patch that puts the adopted fields back the way Terraform found them. The
+// backend has no reset endpoint and does not publish its seeded values, so
+// pre-adoption values are the only ones Delete can restore.
+const builtinAgentPreAdoptionKey = "pre_adoption"
+
+func NewBuiltinAgentResource() resource.Resource { return &BuiltinAgentResource{} }
+
+// BuiltinAgentResource manages the mutable fields of a built-in agent. The
+// backend seeds built-in agents and refuses to create or delete them, so
+// Create adopts the existing row and Delete restores the pre-adoption
+// values.
+type BuiltinAgentResource struct {
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
+}
+
+type BuiltinAgentResourceModel struct {
+	ID                           types.String `tfsdk:"id"`
+	Name                         types.String `tfsdk:"name"`
+	AgentName                    types.String `tfsdk:"agent_name"`
+	SystemPrompt                 types.String `tfsdk:"system_prompt"`
+	AutoConfigureOnToolDiscovery types.Bool   `tfsdk:"auto_configure_on_tool_discovery"`
+	MaxRounds                    types.Int64  `tfsdk:"max_rounds"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+func (r *BuiltinAgentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_builtin_agent"
+}
+
+func (r *BuiltinAgentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Tunes one of the built-in agents Archestra seeds in every organization. " +
+			"Built-in agents cannot be created or deleted, so this resource adopts the existing agent on create and only patches the attributes set in configuration. " +
+			"Omitted attributes keep their backend value.\n\n" +
+			"Destroying the resource restores the pre-adoption values: `system_prompt` and the built-in configuration go back to what they were when Terraform first read the agent, on create or import, and the agent stays in place.\n\n" +
+			"~> **Destroy does not reset to backend defaults.** The API has no reset operation and does not publish the seeded values. If the agent was edited, for example in the UI, before Terraform adopted it, destroying the resource brings those edits back rather than the seeded defaults.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Agent identifier",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Built-in agent to adopt: `policy-configuration-subagent`, `dual-llm-main-agent` or `dual-llm-quarantine-agent`. Changing it releases this agent and adopts the other.",
+				Validators:          []validator.String{stringvalidator.OneOf(builtinAgentNames...)},
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"agent_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Display name of the agent.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"system_prompt": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "System prompt of the agent.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"auto_configure_on_tool_discovery": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether newly discovered tools get their policies configured automatically. Only valid when `name = \"policy-configuration-subagent\"`.",
+				PlanModifiers:       []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+			"max_rounds": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Maximum question rounds between the main and quarantined LLM (1–20). Only valid when `name = \"dual-llm-main-agent\"`.",
+				Validators:          []validator.Int64{int64validator.Between(1, 20)},
+				PlanModifiers:       []planmodifier.Int64{int64planmodifier.UseStateForUnknown()},
+			},
+			"organization_id": organizationIDSchemaAttribute(),
+		},
+	}
+}
+
+func (r *BuiltinAgentResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("Agent identifier.")
+}
+
+func (r *BuiltinAgentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *BuiltinAgentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Adopting and releasing both update the existing agent.
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "agent", SubResource: true}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
+}
+
+func (r *BuiltinAgentResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		onlyWhen("auto_configure_on_tool_discovery", whenOneOf("name", "policy-configuration-subagent")),
+		onlyWhen("max_rounds", whenOneOf("name", "dual-llm-main-agent")),
+	}
+}
+
+func (r *BuiltinAgentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BuiltinAgentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ids, err := findBuiltinAgents(ctx, r.client, data.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to look up built-in agent: %s", err))
+		return
+	}
+	if len(ids) != 1 {
+		resp.Diagnostics.AddError(
+			"Built-In Agent Not Found",
+			fmt.Sprintf("Expected exactly one built-in agent %q in the organization, found %d.", data.Name.ValueString(), len(ids)),
+		)
+		return
+	}
+	id, err := uuid.Parse(ids[0])
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected API Response", fmt.Sprintf("Built-in agent %q has an invalid ID: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	current := r.get(ctx, id, &resp.Diagnostics)
+	if current == nil {
+		return
+	}
+	preAdoption, err := json.Marshal(builtinAgentPreAdoption(current))
+	if err != nil {
+		resp.Diagnostics.AddError("Marshal Error", fmt.Sprintf("unable to marshal pre-adoption values: %s", err))
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, builtinAgentPreAdoptionKey, preAdoption)...)
+
+	priorNull := tftypes.NewValue(req.Plan.Schema.Type().TerraformType(ctx), nil)
+	patch := MergePatch(ctx, req.Plan.Raw, priorNull, builtinAgentAttrSpec, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if cfg := builtinAgentConfigPatch(&data, nil); cfg != nil {
+		patch["builtInAgentConfig"] = cfg
+	}
+	body := current
+	if len(patch) > 0 {
+		LogPatch(ctx, "archestra_builtin_agent Create", patch, builtinAgentAttrSpec)
+		body = r.update(ctx, id, patch, &resp.Diagnostics)
+		if body == nil {
+			return
+		}
+	}
+
+	r.flatten(&data, body, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func (r *BuiltinAgentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BuiltinAgentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
+	id, err := uuid.Parse(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse agent ID: %s", err))
+		return
+	}
+
+	apiResp, err := r.client.GetAgentWithResponse(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read built-in agent, got error: %s", err))
+		return
+	}
+	if apiResp.JSON404 != nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if apiResp.JSON200 == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected API Response",
+			fmt.Sprintf("Expected 200 OK, got status %d", apiResp.StatusCode()),
+		)
+		return
+	}
+
+	// Imported agents were not adopted through Create; remember what they
+	// look like now so Delete has something to restore.
+	if preAdoption, d := req.Private.GetKey(ctx, builtinAgentPreAdoptionKey); !d.HasError() && preAdoption == nil {
+		if b, err := json.Marshal(builtinAgentPreAdoption(apiResp.Body)); err == nil {
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, builtinAgentPreAdoptionKey, b)...)
+		}
+	}
+
+	r.flatten(&data, apiResp.Body, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func (r *BuiltinAgentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var stateData, data BuiltinAgentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.Parse(stateData.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse agent ID: %s", err))
+		return
+	}
+
+	patch := MergePatch(ctx, req.Plan.Raw, req.State.Raw, builtinAgentAttrSpec, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if cfg := builtinAgentConfigPatch(&data, &stateData); cfg != nil {
+		patch["builtInAgentConfig"] = cfg
+	}
+	LogPatch(ctx, "archestra_builtin_agent Update", patch, builtinAgentAttrSpec)
+
+	body := r.update(ctx, id, patch, &resp.Diagnostics)
+	if body == nil {
+		return
+	}
+	r.flatten(&data, body, &resp.Diagnostics)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+// Delete restores the pre-adoption values of the fields Terraform adopted.
+// The agent itself stays: the backend does not delete built-in agents.
+func (r *BuiltinAgentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BuiltinAgentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.Parse(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse agent ID: %s", err))
+		return
+	}
+
+	preAdoption, d := req.Private.GetKey(ctx, builtinAgentPreAdoptionKey)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if preAdoption == nil {
+		resp.Diagnostics.AddWarning(
+			"Built-In Agent Not Restored",
+			"No record of the agent's pre-adoption values was found in state, so it was left as is and only removed from state.",
+		)
+		return
+	}
+	apiResp, err := r.client.UpdateAgentWithBodyWithResponse(ctx, id, "application/json", bytes.NewReader(preAdoption))
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to restore built-in agent: %s", err))
+		return
+	}
+	if apiResp.JSON200 == nil && apiResp.JSON404 == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected API Response",
+			fmt.Sprintf("Expected 200 OK or 404 Not Found, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
+		)
+	}
+}
+
+func (r *BuiltinAgentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importByNaturalKey(ctx, naturalKeyImport{
+		Prefix: "name",
+		Noun:   "built-in agent",
+		Lookup: func(ctx context.Context, name string) ([]string, error) {
+			return findBuiltinAgents(ctx, r.client, name)
+		},
+	}, req, resp)
+}
+
+func (r *BuiltinAgentResource) get(ctx context.Context, id uuid.UUID, diags *diag.Diagnostics) []byte {
+	apiResp, err := r.client.GetAgentWithResponse(ctx, id)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Unable to read built-in agent, got error: %s", err))
+		return nil
+	}
+	if apiResp.JSON200 == nil {
+		diags.AddError(
+			"Unexpected API Response",
+			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
+		)
+		return nil
+	}
+	return apiResp.Body
+}
+
+func (r *BuiltinAgentResource) update(ctx context.Context, id uuid.UUID, patch map[string]any, diags *diag.Diagnostics) []byte {
+	body, err := json.Marshal(patch)
+	if err != nil {
+		diags.AddError("Marshal Error", fmt.Sprintf("unable to marshal merge patch: %s", err))
+		return nil
+	}
+	apiResp, err := r.client.UpdateAgentWithBodyWithResponse(ctx, id, "application/json", bytes.NewReader(body))
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("Unable to update built-in agent: %s", err))
+		return nil
+	}
+	if apiResp.JSON200 == nil {
+		diags.AddError(
+			"Unexpected API Response",
+			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
+		)
+		return nil
+	}
+	return apiResp.Body
+}
+
+func (r *BuiltinAgentResource) flatten(data *BuiltinAgentResourceModel, body []byte, diags *diag.Diagnostics) {
+	resp := parseAgentResponse(body, diags)
+	if resp == nil {
+		return
+	}
+	cfg := builtInAgentConfigFromResponse(body)
+	if cfg == nil {
+		diags.AddError("Not a Built-In Agent",
+			fmt.Sprintf("Agent %s has no built-in agent configuration; manage it with archestra_agent instead.", resp.Id))
+		return
+	}
+
+	data.ID = types.StringValue(resp.Id.String())
+	data.Name = cfg.Name
+	data.AgentName = types.StringValue(resp.Name)
+	data.SystemPrompt = types.StringPointerValue(resp.SystemPrompt)
+	data.AutoConfigureOnToolDiscovery = cfg.AutoConfigureOnToolDiscovery
+	data.MaxRounds = cfg.MaxRounds
+}
+
+// findBuiltinAgents returns the IDs of the built-in agents whose
+// builtInAgentConfig.name is name.
+func findBuiltinAgents(ctx context.Context, apiClient *client.ClientWithResponses, name string) ([]string, error) {
+	scope := client.GetAllAgentsParamsScopeBuiltIn
+	apiResp, err := apiClient.GetAllAgentsWithResponse(ctx, &client.GetAllAgentsParams{Scope: &scope})
+	if err != nil {
+		return nil, err
+	}
+	if apiResp.JSON200 == nil {
+		return nil, fmt.Errorf("GetAllAgents: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+	}
+	var agents []json.RawMessage
+	if err := json.Unmarshal(apiResp.Body, &agents); err != nil {
+		return nil, err
+	}
+	var ids []string
+	for _, raw := range agents {
+		cfg := builtInAgentConfigFromResponse(raw)
+		if cfg == nil || cfg.Name.ValueString() != name {
+			continue
+		}
+		var a struct {
+			Id string `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &a); err != nil {
+			return nil, err
+		}
+		ids = append(ids, a.Id)
+	}
+	return ids, nil
+}
+
+// builtinAgentPreAdoption builds the merge patch that restores the adopted
+// fields of the agent in body. builtInAgentConfig is a single jsonb column,
+// so it is restored whole.
+func builtinAgentPreAdoption(body []byte) map[string]any {
+	var fields struct {
+		SystemPrompt       *string          `json:"systemPrompt"`
+		BuiltInAgentConfig *json.RawMessage `json:"builtInAgentConfig"`
+	}
+	_ = json.Unmarshal(body, &fields)
+	out := map[string]any{"systemPrompt": fields.SystemPrompt}
+	if fields.BuiltInAgentConfig != nil {
+		out["builtInAgentConfig"] = fields.BuiltInAgentConfig
+	}
+	return out
+}
+
+// builtinAgentConfigPatch encodes builtInAgentConfig when the plan changes
+// one of its fields, or nil when nothing changed. prior is nil on Create.
+// The column is atomic, so the whole object is sent; unknown fields are left
+// to the backend.
+func builtinAgentConfigPatch(plan, prior *BuiltinAgentResourceModel) map[string]any {
+	cfg := map[string]any{}
+	if v := plan.AutoConfigureOnToolDiscovery; !v.IsNull() && !v.IsUnknown() {
+		cfg["autoConfigureOnToolDiscovery"] = v.ValueBool()
+	}
+	if v := plan.MaxRounds; !v.IsNull() && !v.IsUnknown() {
+		cfg["maxRounds"] = v.ValueInt64()
+	}
+	if len(cfg) == 0 {
+		return nil
+	}
+	if prior != nil && plan.AutoConfigureOnToolDiscovery.Equal(prior.AutoConfigureOnToolDiscovery) && plan.MaxRounds.Equal(prior.MaxRounds) {
+		return nil
+	}
+	cfg["name"] = plan.Name.ValueString()
+	return encodeBuiltInAgentConfig(cfg).(map[string]any)
+}
+
+// AttrSpecs implements resourceWithAttrSpec — activates the schema↔AttrSpec
+// drift lint for this resource.
+func (r *BuiltinAgentResource) AttrSpecs() []AttrSpec { return builtinAgentAttrSpec }
+
+func (r *BuiltinAgentResource) APIShape() any { return client.GetAgentResponse{} }
+
+// KnownIntentionallySkipped — wire fields not modeled on
+// archestra_builtin_agent. Only the prompt and the built-in config are
+// tunable here; everything else about a built-in agent is seeded by the
+// backend. builtInAgentConfig is flattened into name,
+// auto_configure_on_tool_discovery and max_rounds.
+func (r *BuiltinAgentResource) KnownIntentionallySkipped() []string {
+	return []string{
+		"agentType", "authorId", "authorName", "builtIn", "organizationId",
+		"createdAt", "updatedAt", "builtInAgentConfig", "suggestedPrompts",
+		"description", "icon", "llmModel", "llmApiKeyId", "identityProviderId",
+		"incomingEmailEnabled", "incomingEmailAllowedDomain", "incomingEmailSecurityMode",
+		"considerContextUntrusted", "toolExposureMode", "isDefault", "scope",
+		"teams", "labels", "passthroughHeaders", "knowledgeBaseIds", "connectorIds",
+		"slug", "tools",
+	}
+}
+
+// builtinAgentAttrSpec declares the wire shape for `archestra_builtin_agent`.
+// Only system_prompt maps onto a column directly; the built-in config fields
+// share the atomic builtInAgentConfig jsonb and are encoded by
+// builtinAgentConfigPatch.
+var builtinAgentAttrSpec = []AttrSpec{
+	{TFName: "name", Kind: Synthetic},
+	{TFName: "system_prompt", JSONName: "systemPrompt", Kind: Scalar},
+	{TFName: "auto_configure_on_tool_discovery", Kind: Synthetic},
+	{TFName: "max_rounds", Kind: Synthetic},
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestBuiltinAgentAdoptAndRestore drives archestra_builtin_agent through
+// create and destroy against a fake backend: create adopts the seeded agent
+// and patches only the configured field, destroy puts back the pre-adoption values
+// instead of deleting.
+func TestBuiltinAgentAdoptAndRestore(t *testing.T) {
+	const seeded = `{"id":"` + moveAgentID + `","name":"Policy Configuration","agentType":"agent","builtIn":true,"scope":"built_in",` +
+		`"systemPrompt":"seeded prompt","builtInAgentConfig":{"name":"policy-configuration-subagent","autoConfigureOnToolDiscovery":false}}`
+	const adopted = `{"id":"` + moveAgentID + `","name":"Policy Configuration","agentType":"agent","builtIn":true,"scope":"built_in",` +
+		`"systemPrompt":"seeded prompt","builtInAgentConfig":{"name":"policy-configuration-subagent","autoConfigureOnToolDiscovery":true}}`
+
+	var puts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/agents/all":
+			if r.URL.Query().Get("scope") != "built_in" {
+				t.Errorf("GetAllAgents scope = %q, want built_in", r.URL.Query().Get("scope"))
+			}
+			_, _ = w.Write([]byte(`[{"id":"11111111-1111-4111-8111-111111111111","name":"Main","builtInAgentConfig":{"name":"dual-llm-main-agent","maxRounds":5}},` + seeded + `]`))
+		case "GET /api/agents/" + moveAgentID:
+			_, _ = w.Write([]byte(seeded))
+		case "PUT /api/agents/" + moveAgentID:
+			body, _ := io.ReadAll(r.Body)
+			puts = append(puts, string(body))
+			_, _ = w.Write([]byte(adopted))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	ps := newConfiguredProviderServer(t, server.URL)
+	ctx := t.Context()
+
+	schemaResp, err := ps.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.ResourceSchemas["archestra_builtin_agent"].ValueType().(tftypes.Object)
+	config := validatorConfig(t, objType, `{"name":"policy-configuration-subagent","auto_configure_on_tool_discovery":true}`, nil)
+	planned := validatorConfig(t, objType, `{"name":"policy-configuration-subagent","auto_configure_on_tool_discovery":true}`,
+		[]string{"id", "agent_name", "system_prompt", "max_rounds", "organization_id"})
+	null, err := tfprotov6.NewDynamicValue(objType, tftypes.NewValue(objType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := ps.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "archestra_builtin_agent",
+		PriorState:   &null,
+		PlannedState: &planned,
+		Config:       &config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ApplyResourceChange (create)", created.Diagnostics)
+	if len(puts) != 1 || !jsonEqual(t, []byte(puts[0]), []byte(`{"builtInAgentConfig":{"name":"policy-configuration-subagent","autoConfigureOnToolDiscovery":true}}`)) {
+		t.Fatalf("create sent %q", puts)
+	}
+
+	deleted, err := ps.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       "archestra_builtin_agent",
+		PriorState:     created.NewState,
+		PlannedState:   &null,
+		Config:         &null,
+		PlannedPrivate: created.Private,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ApplyResourceChange (delete)", deleted.Diagnostics)
+	want := `{"systemPrompt":"seeded prompt","builtInAgentConfig":{"name":"policy-configuration-subagent","autoConfigureOnToolDiscovery":false}}`
+	if len(puts) != 2 || !jsonEqual(t, []byte(puts[1]), []byte(want)) {
+		t.Fatalf("destroy sent %q, want %s", puts[1:], want)
+	}
+}
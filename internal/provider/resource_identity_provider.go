@@ -9,7 +9,6 @@ import (
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -25,7 +24,9 @@ import (
 var (
 	_ resource.Resource                   = &IdentityProviderResource{}
 	_ resource.ResourceWithImportState    = &IdentityProviderResource{}
+	_ resource.ResourceWithIdentity       = &IdentityProviderResource{}
 	_ resource.ResourceWithValidateConfig = &IdentityProviderResource{}
+	_ resource.ResourceWithModifyPlan     = &IdentityProviderResource{}
 )
 
 func NewIdentityProviderResource() resource.Resource {
@@ -34,7 +35,8 @@ func NewIdentityProviderResource() resource.Resource {
 
 // IdentityProviderResource manages identity providers (OIDC or SAML).
 type IdentityProviderResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type IdentityProviderResourceModel struct {
@@ -56,6 +58,8 @@ type OidcConfigModel struct {
 	DiscoveryEndpoint            types.String                       `tfsdk:"discovery_endpoint"`
 	ClientID                     types.String                       `tfsdk:"client_id"`
 	ClientSecret                 types.String                       `tfsdk:"client_secret"`
+	ClientSecretWO               types.String                       `tfsdk:"client_secret_wo"`
+	ClientSecretWOVersion        types.Int64                        `tfsdk:"client_secret_wo_version"`
 	AuthorizationEndpoint        types.String                       `tfsdk:"authorization_endpoint"`
 	TokenEndpoint                types.String                       `tfsdk:"token_endpoint"`
 	UserInfoEndpoint             types.String                       `tfsdk:"user_info_endpoint"`
@@ -212,10 +216,12 @@ func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.Sche
 			"oidc_config": schema.SingleNestedBlock{
 				MarkdownDescription: "OIDC configuration (cannot be set with saml_config).",
 				Attributes: map[string]schema.Attribute{
-					"issuer":             schema.StringAttribute{Optional: true, MarkdownDescription: "OIDC issuer URL."},
-					"discovery_endpoint": schema.StringAttribute{Optional: true, MarkdownDescription: "Discovery endpoint (.well-known)."},
-					"client_id":          schema.StringAttribute{Optional: true, MarkdownDescription: "OIDC client ID."},
-					"client_secret":      schema.StringAttribute{Optional: true, Sensitive: true, MarkdownDescription: "OIDC client secret."},
+					"issuer":                   schema.StringAttribute{Optional: true, MarkdownDescription: "OIDC issuer URL."},
+					"discovery_endpoint":       schema.StringAttribute{Optional: true, MarkdownDescription: "Discovery endpoint (.well-known)."},
+					"client_id":                schema.StringAttribute{Optional: true, MarkdownDescription: "OIDC client ID."},
+					"client_secret":            schema.StringAttribute{Optional: true, Sensitive: true, MarkdownDescription: "OIDC client secret."},
+					"client_secret_wo":         writeOnlyAttribute("client_secret", "OIDC client secret."),
+					"client_secret_wo_version": writeOnlyVersionAttribute("client_secret"),
 					// Backend auto-derives the four endpoints + skip_discovery /
 					// token_endpoint_authentication from the discovery URL when the
 					// user doesn't override. Optional+Computed+UseStateForUnknown so
@@ -373,21 +379,30 @@ func (r *IdentityProviderResource) Schema(ctx context.Context, req resource.Sche
 	}
 }
 
+func (r *IdentityProviderResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("Identity provider identifier.")
+}
+
 func (r *IdentityProviderResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	apiClient, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = apiClient
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *IdentityProviderResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "identityProvider"}, req, resp)
 }
 
 func (r *IdentityProviderResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -397,8 +412,12 @@ func (r *IdentityProviderResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	priorNull := tftypes.NewValue(req.Plan.Schema.Type().TerraformType(ctx), nil)
-	patch := MergePatch(ctx, req.Plan.Raw, priorNull, identityProviderAttrSpec, &resp.Diagnostics)
+	plan, prior, err := injectWriteOnly(req.Config.Raw, req.Plan.Raw, tftypes.NewValue(req.Plan.Schema.Type().TerraformType(ctx), nil), identityProviderAttrSpec)
+	if err != nil {
+		resp.Diagnostics.AddError("Write-Only Attribute Error", err.Error())
+		return
+	}
+	patch := MergePatch(ctx, plan, prior, identityProviderAttrSpec, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -434,6 +453,7 @@ func (r *IdentityProviderResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *IdentityProviderResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -443,6 +463,12 @@ func (r *IdentityProviderResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	apiResp, err := r.client.GetIdentityProviderWithResponse(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read identity provider: %s", err))
@@ -475,6 +501,8 @@ func (r *IdentityProviderResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *IdentityProviderResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -486,7 +514,12 @@ func (r *IdentityProviderResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	patch := MergePatch(ctx, req.Plan.Raw, req.State.Raw, identityProviderAttrSpec, &resp.Diagnostics)
+	planRaw, priorRaw, err := injectWriteOnly(req.Config.Raw, req.Plan.Raw, req.State.Raw, identityProviderAttrSpec)
+	if err != nil {
+		resp.Diagnostics.AddError("Write-Only Attribute Error", err.Error())
+		return
+	}
+	patch := MergePatch(ctx, planRaw, priorRaw, identityProviderAttrSpec, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -527,6 +560,7 @@ func (r *IdentityProviderResource) Update(ctx context.Context, req resource.Upda
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *IdentityProviderResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -547,8 +581,29 @@ func (r *IdentityProviderResource) Delete(ctx context.Context, req resource.Dele
 	}
 }
 
+// ImportState accepts the identity provider's ID or `provider_id:<providerId>`
+// (the slug shown in the SSO callback URL).
 func (r *IdentityProviderResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByNaturalKey(ctx, naturalKeyImport{
+		Prefix: "provider_id",
+		Noun:   "identity provider",
+		Lookup: func(ctx context.Context, providerID string) ([]string, error) {
+			apiResp, err := r.client.GetIdentityProvidersWithResponse(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if apiResp.JSON200 == nil {
+				return nil, fmt.Errorf("GetIdentityProviders: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+			}
+			var ids []string
+			for _, idp := range *apiResp.JSON200 {
+				if idp.ProviderId == providerID {
+					ids = append(ids, idp.Id)
+				}
+			}
+			return ids, nil
+		},
+	}, req, resp)
 }
 
 // ValidateConfig enforces the oidc_config XOR saml_config constraint at
@@ -593,7 +648,7 @@ func classifyOidcConfig(cfg *OidcConfigModel) (set, unknown bool) {
 	if cfg == nil {
 		return false, false
 	}
-	for _, f := range []types.String{cfg.Issuer, cfg.DiscoveryEndpoint, cfg.ClientID, cfg.ClientSecret} {
+	for _, f := range []types.String{cfg.Issuer, cfg.DiscoveryEndpoint, cfg.ClientID, cfg.ClientSecret, cfg.ClientSecretWO} {
 		if f.IsUnknown() {
 			unknown = true
 		}
@@ -717,6 +772,8 @@ var identityProviderAttrSpec = []AttrSpec{
 			{TFName: "discovery_endpoint", JSONName: "discoveryEndpoint", Kind: Scalar},
 			{TFName: "client_id", JSONName: "clientId", Kind: Scalar},
 			{TFName: "client_secret", JSONName: "clientSecret", Kind: Scalar, Sensitive: true},
+			{TFName: "client_secret_wo", JSONName: "clientSecret", Kind: Scalar, Sensitive: true, WriteOnlyVersion: "client_secret_wo_version"},
+			{TFName: "client_secret_wo_version", Kind: Synthetic},
 			{TFName: "authorization_endpoint", JSONName: "authorizationEndpoint", Kind: Scalar},
 			{TFName: "token_endpoint", JSONName: "tokenEndpoint", Kind: Scalar},
 			{TFName: "user_info_endpoint", JSONName: "userInfoEndpoint", Kind: Scalar},
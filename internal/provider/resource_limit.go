@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -24,7 +25,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &LimitResource{}
 var _ resource.ResourceWithImportState = &LimitResource{}
-var _ resource.ResourceWithValidateConfig = &LimitResource{}
+var _ resource.ResourceWithIdentity = &LimitResource{}
+var _ resource.ResourceWithConfigValidators = &LimitResource{}
+var _ resource.ResourceWithModifyPlan = &LimitResource{}
 
 func NewLimitResource() resource.Resource {
 	return &LimitResource{}
@@ -32,7 +35,8 @@ func NewLimitResource() resource.Resource {
 
 // LimitResource defines the resource implementation.
 type LimitResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 // LimitResourceModel describes the resource data model.
@@ -45,6 +49,15 @@ type LimitResourceModel struct {
 	Model         types.List   `tfsdk:"model"`
 	ToolName      types.String `tfsdk:"tool_name"`
 	MCPServerName types.String `tfsdk:"mcp_server_name"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
+
+	CurrentUsage       types.Float64 `tfsdk:"current_usage"`
+	Remaining          types.Float64 `tfsdk:"remaining"`
+	UtilizationPercent types.Float64 `tfsdk:"utilization_percent"`
+	LastCleanup        types.String  `tfsdk:"last_cleanup"`
 }
 
 func (r *LimitResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -101,105 +114,49 @@ func (r *LimitResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Required when limit_type is 'mcp_server_calls' or 'tool_calls'. Name of the MCP server.",
 				Optional:            true,
 			},
+			"organization_id": organizationIDSchemaAttribute(),
+			"created_at":      createdAtSchemaAttribute(),
+			"updated_at":      updatedAtSchemaAttribute(),
+			"current_usage": schema.Float64Attribute{
+				Computed: true,
+				MarkdownDescription: "Usage counted against `limit_value` since `last_cleanup`, as of the last refresh. " +
+					"The backend reports usage for `token_cost` limits only (the summed cost across `model`); null for call limits.",
+			},
+			"remaining": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "`limit_value` minus `current_usage`, floored at 0. Null when `current_usage` is.",
+			},
+			"utilization_percent": schema.Float64Attribute{
+				Computed: true,
+				MarkdownDescription: "`current_usage` as a percentage of `limit_value`, rounded to two decimals; exceeds 100 once the limit is overrun. " +
+					"Null when `current_usage` is. Suited to `check` blocks that alarm before the limit is hit.",
+			},
+			"last_cleanup": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the cleanup scheduler last reset this limit's usage (RFC 3339, UTC); see `archestra_organization_settings.limit_cleanup_interval`. Null until the first cleanup.",
+			},
 		},
 	}
 }
 
-func (r *LimitResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
-	var data LimitResourceModel
-
-	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	if data.LimitType.IsUnknown() {
-		return
+// ConfigValidators pairs each limit_type with the scoping attributes it
+// needs: token_cost limits name models, call limits name an MCP server and,
+// for tool_calls, a tool.
+func (r *LimitResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	tokenCost := whenOneOf("limit_type", "token_cost")
+	calls := whenOneOf("limit_type", "mcp_server_calls", "tool_calls")
+	return []resource.ConfigValidator{
+		requiredWhen("model", tokenCost),
+		onlyWhen("model", tokenCost),
+		requiredWhen("mcp_server_name", calls),
+		onlyWhen("mcp_server_name", calls),
+		requiredWhen("tool_name", whenOneOf("limit_type", "tool_calls")),
+		onlyWhen("tool_name", calls),
 	}
+}
 
-	limitType := data.LimitType.ValueString()
-
-	// Required-when checks below skip Unknown values: when a field is
-	// referenced from another resource (e.g.,
-	// `mcp_server_name = archestra_mcp_server_installation.foo.name`),
-	// the value is Unknown at plan-time until the referenced resource
-	// finalizes. Erroring on Unknown produces a false "X is required"
-	// even though X is set — the framework re-validates after Unknowns
-	// resolve.
-	switch limitType {
-	case "token_cost":
-		if data.Model.IsNull() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("model"),
-				"Missing Required Attribute",
-				"limit_type = 'token_cost' requires `model`. Set `model = [\"<model-id>\", ...]` or change `limit_type`.",
-			)
-		} else if !data.Model.IsUnknown() {
-			var models []string
-			data.Model.ElementsAs(ctx, &models, false)
-			if len(models) == 0 {
-				resp.Diagnostics.AddAttributeError(
-					path.Root("model"),
-					"Invalid Attribute Value",
-					"`model` must contain at least one value when limit_type = 'token_cost'.",
-				)
-			}
-		}
-		if !data.MCPServerName.IsNull() && !data.MCPServerName.IsUnknown() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("mcp_server_name"),
-				"Invalid Attribute Combination",
-				"`mcp_server_name` must not be set when limit_type = 'token_cost'.",
-			)
-		}
-		if !data.ToolName.IsNull() && !data.ToolName.IsUnknown() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("tool_name"),
-				"Invalid Attribute Combination",
-				"`tool_name` must not be set when limit_type = 'token_cost'.",
-			)
-		}
-
-	case "mcp_server_calls":
-		if data.MCPServerName.IsNull() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("mcp_server_name"),
-				"Missing Required Attribute",
-				"limit_type = 'mcp_server_calls' requires `mcp_server_name`. Set `mcp_server_name = \"<server>\"` or change `limit_type`.",
-			)
-		}
-		if !data.Model.IsNull() && !data.Model.IsUnknown() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("model"),
-				"Invalid Attribute Combination",
-				"`model` must not be set when limit_type = 'mcp_server_calls'.",
-			)
-		}
-
-	case "tool_calls":
-		if data.MCPServerName.IsNull() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("mcp_server_name"),
-				"Missing Required Attribute",
-				"limit_type = 'tool_calls' requires `mcp_server_name`. Set `mcp_server_name = \"<server>\"` or change `limit_type`.",
-			)
-		}
-		if data.ToolName.IsNull() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("tool_name"),
-				"Missing Required Attribute",
-				"limit_type = 'tool_calls' requires `tool_name`. Set `tool_name = \"<tool>\"` or change `limit_type`.",
-			)
-		}
-		if !data.Model.IsNull() && !data.Model.IsUnknown() {
-			resp.Diagnostics.AddAttributeError(
-				path.Root("model"),
-				"Invalid Attribute Combination",
-				"`model` must not be set when limit_type = 'tool_calls'.",
-			)
-		}
-	}
+func (r *LimitResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("Limit identifier.")
 }
 
 func (r *LimitResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -207,16 +164,22 @@ func (r *LimitResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *LimitResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "limit"}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 func (r *LimitResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -256,6 +219,8 @@ func (r *LimitResource) Create(ctx context.Context, req resource.CreateRequest,
 	data.EntityType = types.StringValue(string(apiResp.JSON200.EntityType))
 	data.LimitType = types.StringValue(string(apiResp.JSON200.LimitType))
 	data.LimitValue = types.Int64Value(int64(apiResp.JSON200.LimitValue))
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 
 	if apiResp.JSON200.Model != nil && len(*apiResp.JSON200.Model) > 0 {
 		modelList, diags := types.ListValueFrom(ctx, types.StringType, *apiResp.JSON200.Model)
@@ -271,7 +236,9 @@ func (r *LimitResource) Create(ctx context.Context, req resource.CreateRequest,
 		data.MCPServerName = types.StringValue(*apiResp.JSON200.McpServerName)
 	}
 
+	r.readLimitUsageAfterWrite(ctx, &data, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *LimitResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -283,6 +250,12 @@ func (r *LimitResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	id, err := uuid.Parse(data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse limit ID: %s", err))
@@ -312,6 +285,8 @@ func (r *LimitResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.EntityType = types.StringValue(string(apiResp.JSON200.EntityType))
 	data.LimitType = types.StringValue(string(apiResp.JSON200.LimitType))
 	data.LimitValue = types.Int64Value(int64(apiResp.JSON200.LimitValue))
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 
 	if apiResp.JSON200.Model != nil && len(*apiResp.JSON200.Model) > 0 {
 		modelList, diags := types.ListValueFrom(ctx, types.StringType, *apiResp.JSON200.Model)
@@ -331,7 +306,13 @@ func (r *LimitResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		data.MCPServerName = types.StringNull()
 	}
 
+	if err := r.readLimitUsage(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("API Error", err.Error())
+		return
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *LimitResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -384,6 +365,8 @@ func (r *LimitResource) Update(ctx context.Context, req resource.UpdateRequest,
 	data.EntityType = types.StringValue(string(apiResp.JSON200.EntityType))
 	data.LimitType = types.StringValue(string(apiResp.JSON200.LimitType))
 	data.LimitValue = types.Int64Value(int64(apiResp.JSON200.LimitValue))
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 
 	if apiResp.JSON200.Model != nil && len(*apiResp.JSON200.Model) > 0 {
 		modelList, diags := types.ListValueFrom(ctx, types.StringType, *apiResp.JSON200.Model)
@@ -403,7 +386,9 @@ func (r *LimitResource) Update(ctx context.Context, req resource.UpdateRequest,
 		data.MCPServerName = types.StringNull()
 	}
 
+	r.readLimitUsageAfterWrite(ctx, &data, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *LimitResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -437,5 +422,48 @@ func (r *LimitResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *LimitResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+}
+
+// readLimitUsage fills the usage attributes from GetLimits, the only
+// endpoint that reports usage, filtered down to this limit's entity and type.
+// They stay null when the fetch fails; the error is for the caller to
+// report.
+func (r *LimitResource) readLimitUsage(ctx context.Context, data *LimitResourceModel) error {
+	data.CurrentUsage, data.Remaining, data.UtilizationPercent = limitUsage(0, nil)
+	data.LastCleanup = types.StringNull()
+	entityID := data.EntityID.ValueString()
+	entityType := client.GetLimitsParamsEntityType(data.EntityType.ValueString())
+	limitType := client.GetLimitsParamsLimitType(data.LimitType.ValueString())
+	apiResp, err := r.client.GetLimitsWithResponse(ctx, &client.GetLimitsParams{
+		EntityId:   &entityID,
+		EntityType: &entityType,
+		LimitType:  &limitType,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to read limit usage, got error: %w", err)
+	}
+	if apiResp.JSON200 == nil {
+		return fmt.Errorf("unable to read limit usage: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+	}
+	for _, l := range *apiResp.JSON200 {
+		if l.Id.String() != data.ID.ValueString() {
+			continue
+		}
+		data.CurrentUsage, data.Remaining, data.UtilizationPercent = limitUsage(l.LimitValue, l.ModelUsage)
+		if l.LastCleanup != nil {
+			data.LastCleanup = timestampValue(*l.LastCleanup)
+		}
+		return nil
+	}
+	return nil
+}
+
+// readLimitUsageAfterWrite is readLimitUsage for Create and Update: the
+// write already succeeded, so a failed usage fetch must not taint the
+// resource or fail the apply. Usage stays null until the next refresh.
+func (r *LimitResource) readLimitUsageAfterWrite(ctx context.Context, data *LimitResourceModel, diags *diag.Diagnostics) {
+	if err := r.readLimitUsage(ctx, data); err != nil {
+		diags.AddWarning("Limit Usage Unavailable", fmt.Sprintf("The limit was saved, but its usage could not be read; usage attributes stay null until the next refresh: %s", err))
+	}
 }
@@ -151,7 +151,7 @@ resource "archestra_limit" "test" {
 `, entityID, entityType, limitValue, mcpServerName, toolName)
 }
 
-// TestAccLimitResource_McpServerNameReference pins the ValidateConfig
+// TestAccLimitResource_McpServerNameReference pins the ConfigValidators
 // IsUnknown handling. When a user wires `mcp_server_name` from another
 // resource (e.g., `archestra_mcp_server_installation.foo.name`), the
 // value is Unknown at plan-time until that resource is created. The
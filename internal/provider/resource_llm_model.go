@@ -19,14 +19,17 @@ import (
 )
 
 var _ resource.Resource = &LlmModelResource{}
+var _ resource.ResourceWithIdentity = &LlmModelResource{}
 var _ resource.ResourceWithImportState = &LlmModelResource{}
+var _ resource.ResourceWithModifyPlan = &LlmModelResource{}
 
 func NewLlmModelResource() resource.Resource {
 	return &LlmModelResource{}
 }
 
 type LlmModelResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type LlmModelResourceModel struct {
@@ -44,6 +47,8 @@ type LlmModelResourceModel struct {
 	PricePerMillionOutput       types.String `tfsdk:"price_per_million_output"`
 	IsCustomPrice               types.Bool   `tfsdk:"is_custom_price"`
 	PriceSource                 types.String `tfsdk:"price_source"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
 }
 
 func (r *LlmModelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -131,25 +136,36 @@ func (r *LlmModelResource) Schema(ctx context.Context, req resource.SchemaReques
 				MarkdownDescription: "Source of the current pricing: `custom`, `models_dev`, or `default`",
 				Computed:            true,
 			},
+			"organization_id": organizationIDSchemaAttribute(),
 		},
 	}
 }
 
+func (r *LlmModelResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("LLM model identifier.")
+}
+
 func (r *LlmModelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *LlmModelResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "tokenPrice", SubResource: true}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 func (r *LlmModelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -227,6 +243,7 @@ func (r *LlmModelResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *LlmModelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -237,12 +254,20 @@ func (r *LlmModelResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	r.readModelState(ctx, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *LlmModelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -265,6 +290,7 @@ func (r *LlmModelResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 	if len(patch) == 0 {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 		return
 	}
 	LogPatch(ctx, "archestra_llm_model Update", patch, llmModelAttrSpec)
@@ -299,6 +325,7 @@ func (r *LlmModelResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *LlmModelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -328,6 +355,10 @@ func (r *LlmModelResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 func (r *LlmModelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importFromIdentity(ctx, req, resp) {
+		return
+	}
+
 	// Import by model_id string (e.g., "gpt-4o"), not UUID
 	modelID := req.ID
 
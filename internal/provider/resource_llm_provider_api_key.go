@@ -22,20 +22,25 @@ import (
 )
 
 var _ resource.Resource = &LLMProviderApiKeyResource{}
+var _ resource.ResourceWithIdentity = &LLMProviderApiKeyResource{}
 var _ resource.ResourceWithImportState = &LLMProviderApiKeyResource{}
+var _ resource.ResourceWithModifyPlan = &LLMProviderApiKeyResource{}
 
 func NewLLMProviderApiKeyResource() resource.Resource {
 	return &LLMProviderApiKeyResource{}
 }
 
 type LLMProviderApiKeyResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type LLMProviderApiKeyResourceModel struct {
 	ID                    types.String `tfsdk:"id"`
 	Name                  types.String `tfsdk:"name"`
 	ApiKey                types.String `tfsdk:"api_key"`
+	ApiKeyWO              types.String `tfsdk:"api_key_wo"`
+	ApiKeyWOVersion       types.Int64  `tfsdk:"api_key_wo_version"`
 	LLMProvider           types.String `tfsdk:"llm_provider"`
 	IsOrganizationDefault types.Bool   `tfsdk:"is_organization_default"`
 	BaseUrl               types.String `tfsdk:"base_url"`
@@ -43,6 +48,8 @@ type LLMProviderApiKeyResourceModel struct {
 	TeamID                types.String `tfsdk:"team_id"`
 	VaultSecretPath       types.String `tfsdk:"vault_secret_path"`
 	VaultSecretKey        types.String `tfsdk:"vault_secret_key"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
 }
 
 func (r *LLMProviderApiKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -76,6 +83,13 @@ func (r *LLMProviderApiKeyResource) Schema(ctx context.Context, req resource.Sch
 					),
 				},
 			},
+			"api_key_wo": writeOnlyAttribute("api_key", "The API key value.",
+				stringvalidator.ConflictsWith(
+					path.MatchRoot("vault_secret_path"),
+					path.MatchRoot("vault_secret_key"),
+				),
+			),
+			"api_key_wo_version": writeOnlyVersionAttribute("api_key"),
 			"llm_provider": schema.StringAttribute{
 				MarkdownDescription: "LLM provider for this API key",
 				Required:            true,
@@ -136,7 +150,7 @@ func (r *LLMProviderApiKeyResource) Schema(ctx context.Context, req resource.Sch
 				Optional:            true,
 				Validators: []validator.String{
 					stringvalidator.AlsoRequires(path.MatchRoot("vault_secret_key")),
-					stringvalidator.ConflictsWith(path.MatchRoot("api_key")),
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key"), path.MatchRoot("api_key_wo")),
 				},
 			},
 			"vault_secret_key": schema.StringAttribute{
@@ -144,28 +158,39 @@ func (r *LLMProviderApiKeyResource) Schema(ctx context.Context, req resource.Sch
 				Optional:            true,
 				Validators: []validator.String{
 					stringvalidator.AlsoRequires(path.MatchRoot("vault_secret_path")),
-					stringvalidator.ConflictsWith(path.MatchRoot("api_key")),
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key"), path.MatchRoot("api_key_wo")),
 				},
 			},
+			"organization_id": organizationIDSchemaAttribute(),
 		},
 	}
 }
 
+func (r *LLMProviderApiKeyResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("LLM provider API key identifier.")
+}
+
 func (r *LLMProviderApiKeyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *LLMProviderApiKeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "chatSettings", SubResource: true}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 func (r *LLMProviderApiKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -177,8 +202,12 @@ func (r *LLMProviderApiKeyResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
-	prior := tftypes.NewValue(req.Plan.Raw.Type(), nil)
-	patch := MergePatch(ctx, req.Plan.Raw, prior, llmProviderApiKeyAttrSpec, &resp.Diagnostics)
+	plan, prior, err := injectWriteOnly(req.Config.Raw, req.Plan.Raw, tftypes.NewValue(req.Plan.Raw.Type(), nil), llmProviderApiKeyAttrSpec)
+	if err != nil {
+		resp.Diagnostics.AddError("Write-Only Attribute Error", err.Error())
+		return
+	}
+	patch := MergePatch(ctx, plan, prior, llmProviderApiKeyAttrSpec, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -221,6 +250,7 @@ func (r *LLMProviderApiKeyResource) Create(ctx context.Context, req resource.Cre
 	// they are preserved from plan and will be read back via Read.
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *LLMProviderApiKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -232,6 +262,12 @@ func (r *LLMProviderApiKeyResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	id, err := uuid.Parse(data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse LLM provider API key ID: %s", err))
@@ -286,6 +322,8 @@ func (r *LLMProviderApiKeyResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *LLMProviderApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -303,12 +341,18 @@ func (r *LLMProviderApiKeyResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	patch := MergePatch(ctx, req.Plan.Raw, req.State.Raw, llmProviderApiKeyAttrSpec, &resp.Diagnostics)
+	plan, prior, err := injectWriteOnly(req.Config.Raw, req.Plan.Raw, req.State.Raw, llmProviderApiKeyAttrSpec)
+	if err != nil {
+		resp.Diagnostics.AddError("Write-Only Attribute Error", err.Error())
+		return
+	}
+	patch := MergePatch(ctx, plan, prior, llmProviderApiKeyAttrSpec, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	if len(patch) == 0 {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 		return
 	}
 	LogPatch(ctx, "archestra_llm_provider_api_key Update", patch, llmProviderApiKeyAttrSpec)
@@ -357,6 +401,7 @@ func (r *LLMProviderApiKeyResource) Update(ctx context.Context, req resource.Upd
 	// they are preserved from plan and will be read back via Read.
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *LLMProviderApiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -390,5 +435,5 @@ func (r *LLMProviderApiKeyResource) Delete(ctx context.Context, req resource.Del
 }
 
 func (r *LLMProviderApiKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
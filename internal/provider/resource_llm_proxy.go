@@ -1,16 +1,12 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
-	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,35 +14,33 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 var _ resource.Resource = &LlmProxyResource{}
 var _ resource.ResourceWithImportState = &LlmProxyResource{}
+var _ resource.ResourceWithIdentity = &LlmProxyResource{}
+var _ resource.ResourceWithModifyPlan = &LlmProxyResource{}
+var _ resource.ResourceWithConfigValidators = &LlmProxyResource{}
 
 func NewLlmProxyResource() resource.Resource { return &LlmProxyResource{} }
 
 type LlmProxyResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 // LlmProxyResourceModel is the schema for an Archestra LLM proxy. The proxy
 // fronts an upstream LLM provider and optionally enforces JWT auth via an
 // identity provider.
 type LlmProxyResourceModel struct {
-	ID                       types.String      `tfsdk:"id"`
-	Name                     types.String      `tfsdk:"name"`
-	Description              types.String      `tfsdk:"description"`
-	Icon                     types.String      `tfsdk:"icon"`
-	LlmModel                 types.String      `tfsdk:"llm_model"`
-	LlmApiKeyId              types.String      `tfsdk:"llm_api_key_id"`
-	PassthroughHeaders       types.List        `tfsdk:"passthrough_headers"`
-	IdentityProviderId       types.String      `tfsdk:"identity_provider_id"`
-	ConsiderContextUntrusted types.Bool        `tfsdk:"consider_context_untrusted"`
-	IsDefault                types.Bool        `tfsdk:"is_default"`
-	Scope                    types.String      `tfsdk:"scope"`
-	Teams                    types.List        `tfsdk:"teams"`
-	Labels                   []AgentLabelModel `tfsdk:"labels"`
+	AgentCommonModel
+
+	LlmModel           types.String `tfsdk:"llm_model"`
+	LlmApiKeyId        types.String `tfsdk:"llm_api_key_id"`
+	PassthroughHeaders types.List   `tfsdk:"passthrough_headers"`
+	IdentityProviderId types.String `tfsdk:"identity_provider_id"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
 }
 
 func (r *LlmProxyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -56,7 +50,7 @@ func (r *LlmProxyResource) Metadata(_ context.Context, req resource.MetadataRequ
 func (r *LlmProxyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Passthrough proxy to an upstream LLM provider, with optional inbound JWT auth via `identity_provider_id`.",
-		Attributes: map[string]schema.Attribute{
+		Attributes: withAttributes(map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "LLM proxy identifier",
@@ -67,11 +61,11 @@ func (r *LlmProxyResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 			"icon":        schema.StringAttribute{Optional: true, MarkdownDescription: "Emoji or base64 image data URL"},
 			"llm_model": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Upstream LLM model ID",
+				MarkdownDescription: "Upstream LLM model ID. Requires `llm_api_key_id`.",
 			},
 			"llm_api_key_id": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "ID of the upstream LLM provider API key",
+				MarkdownDescription: "ID of the upstream LLM provider API key.",
 			},
 			"passthrough_headers": schema.ListAttribute{
 				Optional:            true,
@@ -106,6 +100,7 @@ func (r *LlmProxyResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				MarkdownDescription: "Team IDs this proxy is assigned to. Required when `scope = \"team\"`. Removing from configuration clears the assignment on next apply.",
 				PlanModifiers:       []planmodifier.List{EmptyListOnConfigNull()},
 			},
+			"effective_labels": effectiveLabelsSchemaAttribute(),
 			"labels": schema.SetNestedAttribute{
 				Optional:            true,
 				MarkdownDescription: "Key/value labels for organizing proxies",
@@ -116,209 +111,70 @@ func (r *LlmProxyResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 					},
 				},
 			},
-		},
+			"organization_id": organizationIDSchemaAttribute(),
+		}, agentAuditSchemaAttributes()),
 	}
 }
 
+func (r *LlmProxyResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("LLM proxy identifier.")
+}
+
 func (r *LlmProxyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
 }
 
-func (r *LlmProxyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	priorNull := tftypes.NewValue(req.Plan.Schema.Type().TerraformType(ctx), nil)
-	patch := MergePatch(ctx, req.Plan.Raw, priorNull, llmProxyAttrSpec, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	patch["agentType"] = "llm_proxy"
-	LogPatch(ctx, "archestra_llm_proxy Create", patch, llmProxyAttrSpec)
-
-	body, err := json.Marshal(patch)
-	if err != nil {
-		resp.Diagnostics.AddError("Marshal Error", fmt.Sprintf("unable to marshal merge patch: %s", err))
-		return
-	}
+func (r *LlmProxyResource) kind() agentKind[LlmProxyResourceModel] {
+	return agentKind[LlmProxyResourceModel]{agentType: "llm_proxy", noun: "LLM proxy", attrSpec: llmProxyAttrSpec, flatten: r.flatten}
+}
 
-	apiResp, err := r.client.CreateAgentWithBodyWithResponse(ctx, "application/json", bytes.NewReader(body))
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create LLM proxy: %s", err))
-		return
-	}
-	if apiResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
-			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
-		)
-		return
-	}
+func (r *LlmProxyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.kind().modifyPlan(ctx, r.providerData, req, resp)
+}
 
-	var data LlmProxyResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	r.flatten(ctx, &data, apiResp.Body, &resp.Diagnostics)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+func (r *LlmProxyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.kind().create(ctx, r.client, req, resp)
 }
 
 func (r *LlmProxyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data LlmProxyResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	id, err := uuid.Parse(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse LLM proxy ID: %s", err))
-		return
-	}
-
-	apiResp, err := r.client.GetAgentWithResponse(ctx, id)
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read LLM proxy, got error: %s", err))
-		return
-	}
-	if apiResp.JSON404 != nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-	if apiResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
-			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK, got status %d", apiResp.StatusCode()),
-		)
-		return
-	}
-
-	r.flatten(ctx, &data, apiResp.Body, &resp.Diagnostics)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	r.kind().read(ctx, r.providerData, r.client, req, resp)
 }
 
 func (r *LlmProxyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var stateData LlmProxyResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	id, err := uuid.Parse(stateData.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse LLM proxy ID: %s", err))
-		return
-	}
-
-	patch := MergePatch(ctx, req.Plan.Raw, req.State.Raw, llmProxyAttrSpec, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	LogPatch(ctx, "archestra_llm_proxy Update", patch, llmProxyAttrSpec)
-
-	body, err := json.Marshal(patch)
-	if err != nil {
-		resp.Diagnostics.AddError("Marshal Error", fmt.Sprintf("unable to marshal merge patch: %s", err))
-		return
-	}
-
-	apiResp, err := r.client.UpdateAgentWithBodyWithResponse(ctx, id, "application/json", bytes.NewReader(body))
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update LLM proxy: %s", err))
-		return
-	}
-	if IsNotFound(apiResp) {
-		resp.Diagnostics.AddError(
-			"Resource Deleted Outside Terraform",
-			"The resource was deleted on the backend between refresh and apply. "+
-				"Re-run `terraform apply` — the next refresh drops it from state and the plan recreates it.",
-		)
-		return
-	}
-	if apiResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
-			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
-		)
-		return
-	}
-
-	var data LlmProxyResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	r.flatten(ctx, &data, apiResp.Body, &resp.Diagnostics)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	r.kind().update(ctx, r.client, req, resp)
 }
 
 func (r *LlmProxyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data LlmProxyResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	id, err := uuid.Parse(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse LLM proxy ID: %s", err))
-		return
-	}
+	r.kind().delete(ctx, r.client, req, resp)
+}
 
-	apiResp, err := r.client.DeleteAgentWithResponse(ctx, id)
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete LLM proxy, got error: %s", err))
-		return
-	}
-	if apiResp.JSON200 == nil && apiResp.JSON404 == nil {
-		resp.Diagnostics.AddError(
-			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK or 404 Not Found, got status %d", apiResp.StatusCode()),
-		)
-	}
+func (r *LlmProxyResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return append(agentScopeValidators(), agentLLMValidators()...)
 }
 
 func (r *LlmProxyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByNaturalKey(ctx, agentNameImport(r.client, client.GetAllAgentsParamsAgentTypeLlmProxy, "LLM proxy"), req, resp)
 }
 
-func (r *LlmProxyResource) flatten(ctx context.Context, data *LlmProxyResourceModel, body []byte, diags *diag.Diagnostics) {
-	resp := parseAgentResponse(body, diags)
-	if resp == nil {
-		return
-	}
-
-	data.ID = types.StringValue(resp.Id.String())
-	data.Name = types.StringValue(resp.Name)
-	optionalStringFromAPI(&data.Description, resp.Description)
-	optionalStringFromAPI(&data.Icon, resp.Icon)
-	optionalStringFromAPI(&data.LlmModel, resp.LlmModel)
-	optionalUUIDFromAPI(&data.LlmApiKeyId, resp.LlmApiKeyId)
-	optionalStringFromAPI(&data.IdentityProviderId, resp.IdentityProviderId)
-
-	if resp.PassthroughHeaders != nil {
-		list, d := types.ListValueFrom(ctx, types.StringType, *resp.PassthroughHeaders)
-		diags.Append(d...)
-		data.PassthroughHeaders = list
-	} else if !data.PassthroughHeaders.IsNull() {
-		data.PassthroughHeaders = types.ListNull(types.StringType)
-	}
-
-	data.ConsiderContextUntrusted = types.BoolValue(resp.ConsiderContextUntrusted)
-	data.IsDefault = types.BoolValue(resp.IsDefault)
-	data.Scope = types.StringValue(resp.Scope)
-	data.Teams = teamsListFromAPI(ctx, data.Teams, resp.Teams, diags)
+func (r *LlmProxyResource) flatten(ctx context.Context, data *LlmProxyResourceModel, got *agentAPIResponse, _ []byte, diags *diag.Diagnostics) {
+	flattenAgentCommon(ctx, &data.AgentCommonModel, got, r.providerData.defaultLabels(), diags)
 
-	data.Labels = flattenAgentLabels(data.Labels, resp.Labels)
+	optionalStringFromAPI(&data.LlmModel, got.LlmModel)
+	optionalUUIDFromAPI(&data.LlmApiKeyId, got.LlmApiKeyId)
+	optionalStringFromAPI(&data.IdentityProviderId, got.IdentityProviderId)
+	passthroughHeadersFromAPI(ctx, &data.PassthroughHeaders, got.PassthroughHeaders, diags)
 }
 
 // AttrSpecs implements resourceWithAttrSpec — activates the schema↔AttrSpec
@@ -329,25 +185,22 @@ func (r *LlmProxyResource) APIShape() any { return client.GetAgentResponse{} }
 
 // KnownIntentionallySkipped — wire fields not modeled on archestra_llm_proxy:
 //   - agentType: discriminator (see archestra_agent).
-//   - authorId/authorName/builtIn/organizationId/createdAt/updatedAt: audit
-//     metadata not surfaced today.
+//   - builtIn: proxies are never built in.
 //   - builtInAgentConfig/knowledgeBaseIds/connectorIds/suggestedPrompts/
 //     systemPrompt/llmModel/llmApiKeyId/incomingEmail*/icon: agent-only
 //     wire fields; the llm_proxy variant of the schema doesn't expose
 //     them and the wire returns null for proxy rows.
 //   - isDefault/scope/teams/considerContextUntrusted: agent-scope-and-
 //     visibility fields that the proxy doesn't use.
-//   - slug: auto-generated URL slug.
 //   - tools: read-only list of tool assignments managed via
 //     archestra_agent_tool; duplicating it would create a phantom diff.
 func (r *LlmProxyResource) KnownIntentionallySkipped() []string {
 	return []string{
-		"agentType", "authorId", "authorName", "builtIn", "organizationId",
-		"createdAt", "updatedAt", "builtInAgentConfig", "knowledgeBaseIds",
+		"agentType", "builtIn", "builtInAgentConfig", "knowledgeBaseIds",
 		"connectorIds", "suggestedPrompts", "systemPrompt", "llmModel",
 		"llmApiKeyId", "isDefault", "scope", "teams", "considerContextUntrusted",
 		"incomingEmailEnabled", "incomingEmailAllowedDomain",
-		"incomingEmailSecurityMode", "icon", "slug", "tools",
+		"incomingEmailSecurityMode", "icon", "tools",
 	}
 }
 
@@ -368,11 +221,8 @@ var llmProxyAttrSpec = []AttrSpec{
 	{TFName: "is_default", JSONName: "isDefault", Kind: Scalar},
 	{TFName: "scope", JSONName: "scope", Kind: Scalar},
 	{TFName: "teams", JSONName: "teams", Kind: List},
-	{
-		TFName: "labels", JSONName: "labels", Kind: Set,
-		Children: []AttrSpec{
-			{TFName: "key", JSONName: "key", Kind: Scalar},
-			{TFName: "value", JSONName: "value", Kind: Scalar},
-		},
-	},
+	// `labels` merges with the provider's default_labels; effective_labels
+	// carries the wire field (see labels_shared.go).
+	{TFName: "labels", JSONName: "labels", Kind: Synthetic},
+	{TFName: "effective_labels", JSONName: "labels", Kind: Map, Encoder: encodeEffectiveLabels},
 }
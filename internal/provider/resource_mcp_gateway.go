@@ -1,16 +1,12 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
-	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,36 +14,34 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 var _ resource.Resource = &McpGatewayResource{}
 var _ resource.ResourceWithImportState = &McpGatewayResource{}
+var _ resource.ResourceWithIdentity = &McpGatewayResource{}
+var _ resource.ResourceWithModifyPlan = &McpGatewayResource{}
+var _ resource.ResourceWithConfigValidators = &McpGatewayResource{}
 
 func NewMcpGatewayResource() resource.Resource { return &McpGatewayResource{} }
 
 type McpGatewayResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 // McpGatewayResourceModel is the schema for an Archestra MCP gateway. The
 // gateway exposes a unified MCP endpoint backed by tool installations and
 // optional knowledge sources.
 type McpGatewayResourceModel struct {
-	ID                       types.String      `tfsdk:"id"`
-	Name                     types.String      `tfsdk:"name"`
-	Description              types.String      `tfsdk:"description"`
-	Icon                     types.String      `tfsdk:"icon"`
-	KnowledgeBaseIds         types.List        `tfsdk:"knowledge_base_ids"`
-	ConnectorIds             types.List        `tfsdk:"connector_ids"`
-	PassthroughHeaders       types.List        `tfsdk:"passthrough_headers"`
-	IdentityProviderId       types.String      `tfsdk:"identity_provider_id"`
-	ConsiderContextUntrusted types.Bool        `tfsdk:"consider_context_untrusted"`
-	ToolExposureMode         types.String      `tfsdk:"tool_exposure_mode"`
-	IsDefault                types.Bool        `tfsdk:"is_default"`
-	Scope                    types.String      `tfsdk:"scope"`
-	Teams                    types.List        `tfsdk:"teams"`
-	Labels                   []AgentLabelModel `tfsdk:"labels"`
+	AgentCommonModel
+
+	KnowledgeBaseIds   types.List   `tfsdk:"knowledge_base_ids"`
+	ConnectorIds       types.List   `tfsdk:"connector_ids"`
+	PassthroughHeaders types.List   `tfsdk:"passthrough_headers"`
+	IdentityProviderId types.String `tfsdk:"identity_provider_id"`
+	ToolExposureMode   types.String `tfsdk:"tool_exposure_mode"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
 }
 
 func (r *McpGatewayResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -57,7 +51,7 @@ func (r *McpGatewayResource) Metadata(_ context.Context, req resource.MetadataRe
 func (r *McpGatewayResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Unified MCP endpoint that aggregates installed tools and (optionally) knowledge sources, with optional inbound JWT auth via `identity_provider_id`.",
-		Attributes: map[string]schema.Attribute{
+		Attributes: withAttributes(map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "MCP gateway identifier",
@@ -117,6 +111,7 @@ func (r *McpGatewayResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				MarkdownDescription: "Team IDs this gateway is assigned to. Required when `scope = \"team\"`. Removing from configuration clears the assignment on next apply.",
 				PlanModifiers:       []planmodifier.List{EmptyListOnConfigNull()},
 			},
+			"effective_labels": effectiveLabelsSchemaAttribute(),
 			"labels": schema.SetNestedAttribute{
 				Optional:            true,
 				MarkdownDescription: "Key/value labels for organizing gateways",
@@ -127,211 +122,71 @@ func (r *McpGatewayResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					},
 				},
 			},
-		},
+			"organization_id": organizationIDSchemaAttribute(),
+		}, agentAuditSchemaAttributes()),
 	}
 }
 
+func (r *McpGatewayResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("MCP gateway identifier.")
+}
+
 func (r *McpGatewayResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
 }
 
-func (r *McpGatewayResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	priorNull := tftypes.NewValue(req.Plan.Schema.Type().TerraformType(ctx), nil)
-	patch := MergePatch(ctx, req.Plan.Raw, priorNull, mcpGatewayAttrSpec, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	patch["agentType"] = "mcp_gateway"
-	LogPatch(ctx, "archestra_mcp_gateway Create", patch, mcpGatewayAttrSpec)
-
-	body, err := json.Marshal(patch)
-	if err != nil {
-		resp.Diagnostics.AddError("Marshal Error", fmt.Sprintf("unable to marshal merge patch: %s", err))
-		return
-	}
+func (r *McpGatewayResource) kind() agentKind[McpGatewayResourceModel] {
+	return agentKind[McpGatewayResourceModel]{agentType: "mcp_gateway", noun: "MCP gateway", attrSpec: mcpGatewayAttrSpec, flatten: r.flatten}
+}
 
-	apiResp, err := r.client.CreateAgentWithBodyWithResponse(ctx, "application/json", bytes.NewReader(body))
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to create MCP gateway: %s", err))
-		return
-	}
-	if apiResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
-			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
-		)
-		return
-	}
+func (r *McpGatewayResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.kind().modifyPlan(ctx, r.providerData, req, resp)
+}
 
-	var data McpGatewayResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	r.flatten(ctx, &data, apiResp.Body, &resp.Diagnostics)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+func (r *McpGatewayResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.kind().create(ctx, r.client, req, resp)
 }
 
 func (r *McpGatewayResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var data McpGatewayResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	id, err := uuid.Parse(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse MCP gateway ID: %s", err))
-		return
-	}
-
-	apiResp, err := r.client.GetAgentWithResponse(ctx, id)
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read MCP gateway, got error: %s", err))
-		return
-	}
-	if apiResp.JSON404 != nil {
-		resp.State.RemoveResource(ctx)
-		return
-	}
-	if apiResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
-			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK, got status %d", apiResp.StatusCode()),
-		)
-		return
-	}
-
-	r.flatten(ctx, &data, apiResp.Body, &resp.Diagnostics)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	r.kind().read(ctx, r.providerData, r.client, req, resp)
 }
 
 func (r *McpGatewayResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var stateData McpGatewayResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &stateData)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	id, err := uuid.Parse(stateData.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse MCP gateway ID: %s", err))
-		return
-	}
-
-	patch := MergePatch(ctx, req.Plan.Raw, req.State.Raw, mcpGatewayAttrSpec, &resp.Diagnostics)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	LogPatch(ctx, "archestra_mcp_gateway Update", patch, mcpGatewayAttrSpec)
-
-	body, err := json.Marshal(patch)
-	if err != nil {
-		resp.Diagnostics.AddError("Marshal Error", fmt.Sprintf("unable to marshal merge patch: %s", err))
-		return
-	}
-
-	apiResp, err := r.client.UpdateAgentWithBodyWithResponse(ctx, id, "application/json", bytes.NewReader(body))
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to update MCP gateway: %s", err))
-		return
-	}
-	if IsNotFound(apiResp) {
-		resp.Diagnostics.AddError(
-			"Resource Deleted Outside Terraform",
-			"The resource was deleted on the backend between refresh and apply. "+
-				"Re-run `terraform apply` — the next refresh drops it from state and the plan recreates it.",
-		)
-		return
-	}
-	if apiResp.JSON200 == nil {
-		resp.Diagnostics.AddError(
-			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body)),
-		)
-		return
-	}
-
-	var data McpGatewayResourceModel
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	r.flatten(ctx, &data, apiResp.Body, &resp.Diagnostics)
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	r.kind().update(ctx, r.client, req, resp)
 }
 
 func (r *McpGatewayResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	var data McpGatewayResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
-	id, err := uuid.Parse(data.ID.ValueString())
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse MCP gateway ID: %s", err))
-		return
-	}
+	r.kind().delete(ctx, r.client, req, resp)
+}
 
-	apiResp, err := r.client.DeleteAgentWithResponse(ctx, id)
-	if err != nil {
-		resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to delete MCP gateway, got error: %s", err))
-		return
-	}
-	if apiResp.JSON200 == nil && apiResp.JSON404 == nil {
-		resp.Diagnostics.AddError(
-			"Unexpected API Response",
-			fmt.Sprintf("Expected 200 OK or 404 Not Found, got status %d", apiResp.StatusCode()),
-		)
-	}
+func (r *McpGatewayResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return agentScopeValidators()
 }
 
 func (r *McpGatewayResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByNaturalKey(ctx, agentNameImport(r.client, client.GetAllAgentsParamsAgentTypeMcpGateway, "MCP gateway"), req, resp)
 }
 
-func (r *McpGatewayResource) flatten(ctx context.Context, data *McpGatewayResourceModel, body []byte, diags *diag.Diagnostics) {
-	resp := parseAgentResponse(body, diags)
-	if resp == nil {
-		return
-	}
-
-	data.ID = types.StringValue(resp.Id.String())
-	data.Name = types.StringValue(resp.Name)
-	optionalStringFromAPI(&data.Description, resp.Description)
-	optionalStringFromAPI(&data.Icon, resp.Icon)
-	optionalStringFromAPI(&data.IdentityProviderId, resp.IdentityProviderId)
-
-	stringListFromAPI(ctx, &data.KnowledgeBaseIds, resp.KnowledgeBaseIds, diags)
-	stringListFromAPI(ctx, &data.ConnectorIds, resp.ConnectorIds, diags)
-
-	if resp.PassthroughHeaders != nil {
-		list, d := types.ListValueFrom(ctx, types.StringType, *resp.PassthroughHeaders)
-		diags.Append(d...)
-		data.PassthroughHeaders = list
-	} else if !data.PassthroughHeaders.IsNull() {
-		data.PassthroughHeaders = types.ListNull(types.StringType)
-	}
-
-	data.ConsiderContextUntrusted = types.BoolValue(resp.ConsiderContextUntrusted)
-	optionalStringFromAPI(&data.ToolExposureMode, resp.ToolExposureMode)
-	data.IsDefault = types.BoolValue(resp.IsDefault)
-	data.Scope = types.StringValue(resp.Scope)
-	data.Teams = teamsListFromAPI(ctx, data.Teams, resp.Teams, diags)
+func (r *McpGatewayResource) flatten(ctx context.Context, data *McpGatewayResourceModel, got *agentAPIResponse, _ []byte, diags *diag.Diagnostics) {
+	flattenAgentCommon(ctx, &data.AgentCommonModel, got, r.providerData.defaultLabels(), diags)
 
-	data.Labels = flattenAgentLabels(data.Labels, resp.Labels)
+	optionalStringFromAPI(&data.IdentityProviderId, got.IdentityProviderId)
+	stringListFromAPI(ctx, &data.KnowledgeBaseIds, got.KnowledgeBaseIds, diags)
+	stringListFromAPI(ctx, &data.ConnectorIds, got.ConnectorIds, diags)
+	passthroughHeadersFromAPI(ctx, &data.PassthroughHeaders, got.PassthroughHeaders, diags)
+	optionalStringFromAPI(&data.ToolExposureMode, got.ToolExposureMode)
 }
 
 // AttrSpecs implements resourceWithAttrSpec — activates the schema↔AttrSpec
@@ -341,20 +196,19 @@ func (r *McpGatewayResource) AttrSpecs() []AttrSpec { return mcpGatewayAttrSpec
 func (r *McpGatewayResource) APIShape() any { return client.GetAgentResponse{} }
 
 // KnownIntentionallySkipped — wire fields not modeled on archestra_mcp_gateway.
-// Same agent-table discriminator + audit-field situation as archestra_llm_proxy.
+// Same agent-table discriminator + builtIn situation as archestra_llm_proxy.
 // Fields excluded here belong to archestra_agent (system_prompt,
 // built_in_agent_config, llm config, incoming email, suggested prompts) or
 // aren't relevant to a gateway (isDefault, scope, teams,
-// considerContextUntrusted). slug + tools follow the same convention as
-// the other agent-table resources.
+// considerContextUntrusted). tools follows the same convention as the
+// other agent-table resources.
 func (r *McpGatewayResource) KnownIntentionallySkipped() []string {
 	return []string{
-		"agentType", "authorId", "authorName", "builtIn", "organizationId",
-		"createdAt", "updatedAt", "builtInAgentConfig", "suggestedPrompts",
+		"agentType", "builtIn", "builtInAgentConfig", "suggestedPrompts",
 		"systemPrompt", "llmModel", "llmApiKeyId", "isDefault", "scope",
 		"teams", "considerContextUntrusted", "incomingEmailEnabled",
 		"incomingEmailAllowedDomain", "incomingEmailSecurityMode", "icon",
-		"slug", "tools",
+		"tools",
 	}
 }
 
@@ -376,11 +230,8 @@ var mcpGatewayAttrSpec = []AttrSpec{
 	{TFName: "is_default", JSONName: "isDefault", Kind: Scalar},
 	{TFName: "scope", JSONName: "scope", Kind: Scalar},
 	{TFName: "teams", JSONName: "teams", Kind: List},
-	{
-		TFName: "labels", JSONName: "labels", Kind: Set,
-		Children: []AttrSpec{
-			{TFName: "key", JSONName: "key", Kind: Scalar},
-			{TFName: "value", JSONName: "value", Kind: Scalar},
-		},
-	},
+	// `labels` merges with the provider's default_labels; effective_labels
+	// carries the wire field (see labels_shared.go).
+	{TFName: "labels", JSONName: "labels", Kind: Synthetic},
+	{TFName: "effective_labels", JSONName: "labels", Kind: Map, Encoder: encodeEffectiveLabels},
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -28,7 +29,10 @@ import (
 var (
 	_ resource.Resource                   = &MCPServerRegistryResource{}
 	_ resource.ResourceWithImportState    = &MCPServerRegistryResource{}
+	_ resource.ResourceWithIdentity       = &MCPServerRegistryResource{}
 	_ resource.ResourceWithValidateConfig = &MCPServerRegistryResource{}
+	_ resource.ResourceWithModifyPlan     = &MCPServerRegistryResource{}
+	_ resource.ResourceWithUpgradeState   = &MCPServerRegistryResource{}
 )
 
 func NewMCPServerRegistryResource() resource.Resource {
@@ -36,7 +40,8 @@ func NewMCPServerRegistryResource() resource.Resource {
 }
 
 type MCPServerRegistryResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type MCPServerRegistryResourceModel struct {
@@ -58,6 +63,7 @@ type MCPServerRegistryResourceModel struct {
 	Scope               types.String `tfsdk:"scope"`
 	Teams               types.List   `tfsdk:"teams"`
 	Labels              types.List   `tfsdk:"labels"`
+	EffectiveLabels     types.Map    `tfsdk:"effective_labels"`
 
 	ClientSecretId             types.String `tfsdk:"client_secret_id"`
 	LocalConfigSecretId        types.String `tfsdk:"local_config_secret_id"`
@@ -69,6 +75,9 @@ type MCPServerRegistryResourceModel struct {
 	EnterpriseManagedConfig *EnterpriseManagedConfigModel `tfsdk:"enterprise_managed_config"`
 
 	UserConfig types.Map `tfsdk:"user_config"`
+
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 // UserConfigFieldModel mirrors a single entry in the `userConfig` map on an MCP catalog item.
@@ -126,17 +135,21 @@ var envVariableAttrTypes = map[string]attr.Type{
 }
 
 var ipSecretAttrTypes = map[string]attr.Type{
-	"source":   types.StringType,
-	"name":     types.StringType,
-	"server":   types.StringType,
-	"username": types.StringType,
-	"password": types.StringType,
-	"email":    types.StringType,
+	"source":              types.StringType,
+	"name":                types.StringType,
+	"server":              types.StringType,
+	"username":            types.StringType,
+	"password":            types.StringType,
+	"password_wo":         types.StringType,
+	"password_wo_version": types.Int64Type,
+	"email":               types.StringType,
 }
 
 var oauthConfigAttrTypes = map[string]attr.Type{
 	"client_id":                  types.StringType,
 	"client_secret":              types.StringType,
+	"client_secret_wo":           types.StringType,
+	"client_secret_wo_version":   types.Int64Type,
 	"redirect_uris":              types.ListType{ElemType: types.StringType},
 	"scopes":                     types.ListType{ElemType: types.StringType},
 	"default_scopes":             types.ListType{ElemType: types.StringType},
@@ -237,12 +250,14 @@ type EnvironmentVariableModel struct {
 }
 
 type ImagePullSecretModel struct {
-	Source   types.String `tfsdk:"source"`
-	Name     types.String `tfsdk:"name"`
-	Server   types.String `tfsdk:"server"`
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
-	Email    types.String `tfsdk:"email"`
+	Source            types.String `tfsdk:"source"`
+	Name              types.String `tfsdk:"name"`
+	Server            types.String `tfsdk:"server"`
+	Username          types.String `tfsdk:"username"`
+	Password          types.String `tfsdk:"password"`
+	PasswordWO        types.String `tfsdk:"password_wo"`
+	PasswordWOVersion types.Int64  `tfsdk:"password_wo_version"`
+	Email             types.String `tfsdk:"email"`
 }
 
 type EnvFromModel struct {
@@ -259,6 +274,8 @@ type RemoteConfigModel struct {
 type OAuthConfigModel struct {
 	ClientID                 types.String `tfsdk:"client_id"`
 	ClientSecret             types.String `tfsdk:"client_secret"`
+	ClientSecretWO           types.String `tfsdk:"client_secret_wo"`
+	ClientSecretWOVersion    types.Int64  `tfsdk:"client_secret_wo_version"`
 	RedirectURIs             types.List   `tfsdk:"redirect_uris"`
 	Scopes                   types.List   `tfsdk:"scopes"`
 	DefaultScopes            types.List   `tfsdk:"default_scopes"`
@@ -293,6 +310,7 @@ func (r *MCPServerRegistryResource) Metadata(ctx context.Context, req resource.M
 
 func (r *MCPServerRegistryResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Catalog entry / template for an MCP server in the Private MCP Registry. The catalog item alone doesn't run anything; pair it with `archestra_mcp_server_installation` to run an instance.",
 
 		Attributes: map[string]schema.Attribute{
@@ -468,6 +486,8 @@ func (r *MCPServerRegistryResource) Schema(ctx context.Context, req resource.Sch
 									Optional:            true,
 									Sensitive:           true,
 								},
+								"password_wo":         writeOnlyAttribute("password", "Registry password (for `source = credentials`)."),
+								"password_wo_version": writeOnlyVersionAttribute("password"),
 								"email": schema.StringAttribute{
 									MarkdownDescription: "Registry email (optional for `source = credentials`).",
 									Optional:            true,
@@ -498,6 +518,8 @@ func (r *MCPServerRegistryResource) Schema(ctx context.Context, req resource.Sch
 								Optional:            true,
 								Sensitive:           true,
 							},
+							"client_secret_wo":         writeOnlyAttribute("client_secret", "OAuth Client Secret."),
+							"client_secret_wo_version": writeOnlyVersionAttribute("client_secret"),
 							"redirect_uris": schema.ListAttribute{
 								MarkdownDescription: "Comma-separated list of redirect URIs",
 								Required:            true,
@@ -648,6 +670,7 @@ func (r *MCPServerRegistryResource) Schema(ctx context.Context, req resource.Sch
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"effective_labels": effectiveLabelsSchemaAttribute(),
 			"labels": schema.ListNestedAttribute{
 				MarkdownDescription: "Labels for the MCP server catalog item",
 				Optional:            true,
@@ -778,25 +801,49 @@ func (r *MCPServerRegistryResource) Schema(ctx context.Context, req resource.Sch
 					},
 				},
 			},
+			"organization_id": organizationIDSchemaAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx, true),
 		},
 	}
 }
 
+func (r *MCPServerRegistryResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("Catalog item identifier.")
+}
+
 func (r *MCPServerRegistryResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *MCPServerRegistryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "internalMcpCatalog"}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var labels types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planEffectiveLabels(ctx, r.providerData, labels, resp)
 }
 
 // AttrSpecs implements resourceWithAttrSpec for the merge-patch drift check.
@@ -838,9 +885,17 @@ func (r *MCPServerRegistryResource) Create(ctx context.Context, req resource.Cre
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := timeoutContext(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	plan := req.Plan.Raw
-	prior := tftypes.NewValue(plan.Type(), nil)
+	plan, prior, err := injectWriteOnly(req.Config.Raw, req.Plan.Raw, tftypes.NewValue(req.Plan.Raw.Type(), nil), catalogItemAttrSpec)
+	if err != nil {
+		resp.Diagnostics.AddError("Write-Only Attribute Error", err.Error())
+		return
+	}
 	patch := MergePatch(ctx, plan, prior, catalogItemAttrSpec, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -900,6 +955,7 @@ func (r *MCPServerRegistryResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *MCPServerRegistryResource) mapGetResponseToState(ctx context.Context, data *MCPServerRegistryResourceModel, apiResp *client.GetInternalMcpCatalogItemResponse, diags *diag.Diagnostics) {
@@ -942,9 +998,9 @@ func (r *MCPServerRegistryResource) mapGetResponseToState(ctx context.Context, d
 	}
 
 	data.Teams = mapCatalogTeams(apiResp)
-	data.Labels = mapCatalogLabels(apiResp)
+	data.Labels, data.EffectiveLabels = splitLabelList(ctx, mapCatalogLabels(apiResp), data.Labels, r.providerData.defaultLabels(), diags)
 	data.LocalConfig = mapCatalogLocalConfig(ctx, apiResp, data.LocalConfig)
-	data.RemoteConfig = mapCatalogRemoteConfig(apiResp)
+	data.RemoteConfig = mapCatalogRemoteConfig(apiResp, data.RemoteConfig)
 	data.AuthFields = mapCatalogAuthFields(apiResp)
 }
 
@@ -1155,7 +1211,8 @@ func mapCatalogLocalConfig(ctx context.Context, apiResp *client.GetInternalMcpCa
 
 // mapCatalogImagePullSecrets parses imagePullSecrets from the raw response
 // body (the generated union type has unexported fields), and preserves
-// passwords from prior state — the backend never echoes them back.
+// passwords (and password_wo_version) from prior state — the backend never
+// echoes them back.
 func mapCatalogImagePullSecrets(ctx context.Context, body []byte, prior types.Object) types.List {
 	objType := types.ObjectType{AttrTypes: ipSecretAttrTypes}
 
@@ -1178,14 +1235,14 @@ func mapCatalogImagePullSecrets(ctx context.Context, body []byte, prior types.Ob
 		return types.ListNull(objType)
 	}
 
-	priorPasswords := make(map[string]types.String)
+	priorPasswords := make(map[string]ImagePullSecretModel)
 	if !prior.IsNull() && !prior.IsUnknown() {
 		var priorLC LocalConfigModel
 		if d := prior.As(ctx, &priorLC, basetypes.ObjectAsOptions{}); !d.HasError() && !priorLC.ImagePullSecrets.IsNull() && !priorLC.ImagePullSecrets.IsUnknown() {
 			var priorIPS []ImagePullSecretModel
 			if d := priorLC.ImagePullSecrets.ElementsAs(ctx, &priorIPS, false); !d.HasError() {
 				for _, p := range priorIPS {
-					priorPasswords[p.Server.ValueString()+"|"+p.Username.ValueString()] = p.Password
+					priorPasswords[p.Server.ValueString()+"|"+p.Username.ValueString()] = p
 				}
 			}
 		}
@@ -1193,17 +1250,22 @@ func mapCatalogImagePullSecrets(ctx context.Context, body []byte, prior types.Ob
 
 	values := make([]attr.Value, 0, len(*rawResp.LocalConfig.ImagePullSecrets))
 	for _, ips := range *rawResp.LocalConfig.ImagePullSecrets {
-		password := types.StringNull()
-		if prev, ok := priorPasswords[ips.Server+"|"+ips.Username]; ok && !prev.IsNull() {
-			password = prev
+		password, passwordWOVersion := types.StringNull(), types.Int64Null()
+		if prev, ok := priorPasswords[ips.Server+"|"+ips.Username]; ok {
+			if !prev.Password.IsNull() {
+				password = prev.Password
+			}
+			passwordWOVersion = prev.PasswordWOVersion
 		}
 		fields := map[string]attr.Value{
-			"source":   types.StringValue(ips.Source),
-			"name":     strOrNull(ips.Name),
-			"server":   strOrNull(ips.Server),
-			"username": strOrNull(ips.Username),
-			"password": password,
-			"email":    strOrNull(ips.Email),
+			"source":              types.StringValue(ips.Source),
+			"name":                strOrNull(ips.Name),
+			"server":              strOrNull(ips.Server),
+			"username":            strOrNull(ips.Username),
+			"password":            password,
+			"password_wo":         types.StringNull(),
+			"password_wo_version": passwordWOVersion,
+			"email":               strOrNull(ips.Email),
 		}
 		elem, _ := types.ObjectValue(ipSecretAttrTypes, fields)
 		values = append(values, elem)
@@ -1212,13 +1274,19 @@ func mapCatalogImagePullSecrets(ctx context.Context, body []byte, prior types.Ob
 	return out
 }
 
-func mapCatalogRemoteConfig(apiResp *client.GetInternalMcpCatalogItemResponse) types.Object {
+func mapCatalogRemoteConfig(apiResp *client.GetInternalMcpCatalogItemResponse, prior types.Object) types.Object {
 	if string(apiResp.JSON200.ServerType) != "remote" || apiResp.JSON200.ServerUrl == nil {
 		return types.ObjectNull(remoteConfigAttrTypes)
 	}
+	priorOC := types.ObjectNull(oauthConfigAttrTypes)
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if oc, ok := prior.Attributes()["oauth_config"].(types.Object); ok {
+			priorOC = oc
+		}
+	}
 	obj := map[string]attr.Value{
 		"url":          types.StringValue(*apiResp.JSON200.ServerUrl),
-		"oauth_config": mapCatalogOauthConfig(apiResp),
+		"oauth_config": mapCatalogOauthConfig(apiResp, priorOC),
 	}
 	out, _ := types.ObjectValue(remoteConfigAttrTypes, obj)
 	return out
@@ -1226,8 +1294,10 @@ func mapCatalogRemoteConfig(apiResp *client.GetInternalMcpCatalogItemResponse) t
 
 // mapCatalogOauthConfig flattens the OAuth sub-object. client_secret is
 // faithfully echoed by the backend on read (extracted to clientSecretId on
-// write, then rehydrated), so GET is the source of truth.
-func mapCatalogOauthConfig(apiResp *client.GetInternalMcpCatalogItemResponse) types.Object {
+// write, then rehydrated), so GET is the source of truth — except while the
+// prior oauth_config sends it through client_secret_wo, when the echo is
+// kept out of state.
+func mapCatalogOauthConfig(apiResp *client.GetInternalMcpCatalogItemResponse, prior types.Object) types.Object {
 	oc := apiResp.JSON200.OauthConfig
 	if oc == nil {
 		return types.ObjectNull(oauthConfigAttrTypes)
@@ -1235,6 +1305,8 @@ func mapCatalogOauthConfig(apiResp *client.GetInternalMcpCatalogItemResponse) ty
 	obj := map[string]attr.Value{
 		"client_id":                  types.StringValue(oc.ClientId),
 		"client_secret":              stringValueOrNull(oc.ClientSecret),
+		"client_secret_wo":           types.StringNull(),
+		"client_secret_wo_version":   types.Int64Null(),
 		"redirect_uris":              types.ListNull(types.StringType),
 		"scopes":                     types.ListNull(types.StringType),
 		"default_scopes":             types.ListNull(types.StringType),
@@ -1254,6 +1326,12 @@ func mapCatalogOauthConfig(apiResp *client.GetInternalMcpCatalogItemResponse) ty
 		"streamable_http_port":       types.Int64Null(),
 		"grant_type":                 types.StringNull(),
 	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if v, ok := prior.Attributes()["client_secret_wo_version"].(types.Int64); ok && !v.IsNull() {
+			obj["client_secret"] = types.StringNull()
+			obj["client_secret_wo_version"] = v
+		}
+	}
 	if oc.GrantType != nil {
 		obj["grant_type"] = types.StringValue(string(*oc.GrantType))
 	}
@@ -1291,6 +1369,17 @@ func (r *MCPServerRegistryResource) Read(ctx context.Context, req resource.ReadR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := timeoutContext(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
 
 	// Empty/null ID means the resource has not been created yet (e.g. upjet
 	// seeds Terraform state with id="" before the first reconcile). Treat as
@@ -1334,6 +1423,8 @@ func (r *MCPServerRegistryResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *MCPServerRegistryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -1342,6 +1433,11 @@ func (r *MCPServerRegistryResource) Update(ctx context.Context, req resource.Upd
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := timeoutContext(ctx, data.Timeouts.Update, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	serverID, err := uuid.Parse(data.ID.ValueString())
 	if err != nil {
@@ -1349,8 +1445,11 @@ func (r *MCPServerRegistryResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
-	plan := req.Plan.Raw
-	prior := req.State.Raw
+	plan, prior, err := injectWriteOnly(req.Config.Raw, req.Plan.Raw, req.State.Raw, catalogItemAttrSpec)
+	if err != nil {
+		resp.Diagnostics.AddError("Write-Only Attribute Error", err.Error())
+		return
+	}
 	patch := MergePatch(ctx, plan, prior, catalogItemAttrSpec, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
@@ -1404,6 +1503,7 @@ func (r *MCPServerRegistryResource) Update(ctx context.Context, req resource.Upd
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 	readReq := resource.ReadRequest{State: resp.State}
 	readResp := resource.ReadResponse{State: resp.State}
 	r.Read(ctx, readReq, &readResp)
@@ -1417,6 +1517,11 @@ func (r *MCPServerRegistryResource) Delete(ctx context.Context, req resource.Del
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := timeoutContext(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Parse UUID from state
 	serverID, err := uuid.Parse(data.ID.ValueString())
@@ -1442,8 +1547,28 @@ func (r *MCPServerRegistryResource) Delete(ctx context.Context, req resource.Del
 	}
 }
 
+// ImportState accepts the catalog item's UUID or `name:<name>`.
 func (r *MCPServerRegistryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByNaturalKey(ctx, naturalKeyImport{
+		Prefix: "name",
+		Noun:   "catalog item",
+		Lookup: func(ctx context.Context, name string) ([]string, error) {
+			apiResp, err := r.client.GetInternalMcpCatalogWithResponse(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if apiResp.JSON200 == nil {
+				return nil, fmt.Errorf("GetInternalMcpCatalog: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+			}
+			var ids []string
+			for _, item := range *apiResp.JSON200 {
+				if item.Name == name {
+					ids = append(ids, item.Id.String())
+				}
+			}
+			return ids, nil
+		},
+	}, req, resp)
 }
 
 // ValidateConfig enforces the local_config XOR remote_config constraint
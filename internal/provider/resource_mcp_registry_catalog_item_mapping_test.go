@@ -345,14 +345,14 @@ func TestMapCatalogRemoteConfig(t *testing.T) {
 
 	t.Run("not remote returns null", func(t *testing.T) {
 		body := `{"serverType":"local","serverUrl":"https://x.example.com"}`
-		got := mapCatalogRemoteConfig(parseCatalogResp(t, body))
+		got := mapCatalogRemoteConfig(parseCatalogResp(t, body), types.ObjectNull(remoteConfigAttrTypes))
 		if !got.IsNull() {
 			t.Errorf("expected null, got %v", got)
 		}
 	})
 
 	t.Run("remote without serverUrl returns null", func(t *testing.T) {
-		got := mapCatalogRemoteConfig(parseCatalogResp(t, `{"serverType":"remote"}`))
+		got := mapCatalogRemoteConfig(parseCatalogResp(t, `{"serverType":"remote"}`), types.ObjectNull(remoteConfigAttrTypes))
 		if !got.IsNull() {
 			t.Errorf("expected null, got %v", got)
 		}
@@ -360,7 +360,7 @@ func TestMapCatalogRemoteConfig(t *testing.T) {
 
 	t.Run("remote without oauth — oauth_config null", func(t *testing.T) {
 		body := `{"serverType":"remote","serverUrl":"https://x.example.com"}`
-		got := mapCatalogRemoteConfig(parseCatalogResp(t, body))
+		got := mapCatalogRemoteConfig(parseCatalogResp(t, body), types.ObjectNull(remoteConfigAttrTypes))
 		if got.IsNull() {
 			t.Fatal("got null, want populated")
 		}
@@ -378,7 +378,7 @@ func TestMapCatalogOauthConfig(t *testing.T) {
 	t.Parallel()
 
 	t.Run("nil returns ObjectNull", func(t *testing.T) {
-		got := mapCatalogOauthConfig(parseCatalogResp(t, `{}`))
+		got := mapCatalogOauthConfig(parseCatalogResp(t, `{}`), types.ObjectNull(oauthConfigAttrTypes))
 		if !got.IsNull() {
 			t.Errorf("expected null, got %v", got)
 		}
@@ -397,7 +397,7 @@ func TestMapCatalogOauthConfig(t *testing.T) {
 			"streamable_http_port":8443,
 			"generic_oauth":true
 		}}`
-		got := mapCatalogOauthConfig(parseCatalogResp(t, body))
+		got := mapCatalogOauthConfig(parseCatalogResp(t, body), types.ObjectNull(oauthConfigAttrTypes))
 		if got.IsNull() {
 			t.Fatal("got null, want populated")
 		}
@@ -484,12 +484,14 @@ func mustPriorLocalConfigWithIPS(t *testing.T, server, username, password string
 	ipsObjType := types.ObjectType{AttrTypes: ipSecretAttrTypes}
 
 	ipsElem, _ := types.ObjectValue(ipSecretAttrTypes, map[string]attr.Value{
-		"source":   types.StringValue("inline"),
-		"name":     types.StringNull(),
-		"server":   types.StringValue(server),
-		"username": types.StringValue(username),
-		"password": types.StringValue(password),
-		"email":    types.StringNull(),
+		"source":              types.StringValue("inline"),
+		"name":                types.StringNull(),
+		"server":              types.StringValue(server),
+		"username":            types.StringValue(username),
+		"password":            types.StringValue(password),
+		"password_wo":         types.StringNull(),
+		"password_wo_version": types.Int64Null(),
+		"email":               types.StringNull(),
 	})
 	ipsList, d := types.ListValue(ipsObjType, []attr.Value{ipsElem})
 	if d.HasError() {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -19,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -30,6 +32,7 @@ import (
 
 // Backend's K8s readiness budget alone is 120s (`waitForDeploymentReady(60, 2000)`
 // in platform `routes/mcp-server.ts`); 5 min covers slow nodes + image pulls.
+// A `timeouts.create` overrides it for images that take longer.
 const (
 	mcpServerInstallTimeout = 5 * time.Minute
 	mcpServerInstallPoll    = 2 * time.Second
@@ -59,7 +62,9 @@ func decideInstallStatus(s string) installStatusDecision {
 
 var _ resource.Resource = &MCPServerResource{}
 var _ resource.ResourceWithImportState = &MCPServerResource{}
+var _ resource.ResourceWithIdentity = &MCPServerResource{}
 var _ resource.ResourceWithValidateConfig = &MCPServerResource{}
+var _ resource.ResourceWithModifyPlan = &MCPServerResource{}
 
 // installRequestBody wraps the generated install body with fields the
 // checked-in generated client predates. Embedding keeps every generated
@@ -91,23 +96,26 @@ func NewMCPServerResource() resource.Resource {
 }
 
 type MCPServerResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type MCPServerResourceModel struct {
-	ID                types.String `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	DisplayName       types.String `tfsdk:"display_name"`
-	CatalogID         types.String `tfsdk:"catalog_id"`
-	Scope             types.String `tfsdk:"scope"`
-	TeamID            types.String `tfsdk:"team_id"`
-	EnvironmentValues types.Map    `tfsdk:"environment_values"`
-	UserConfigValues  types.Map    `tfsdk:"user_config_values"`
-	SecretID          types.String `tfsdk:"secret_id"`
-	AccessToken       types.String `tfsdk:"access_token"`
-	ServiceAccount    types.String `tfsdk:"service_account"`
-	IsByosVault       types.Bool   `tfsdk:"is_byos_vault"`
-	AgentIDs          types.List   `tfsdk:"agent_ids"`
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	DisplayName          types.String `tfsdk:"display_name"`
+	CatalogID            types.String `tfsdk:"catalog_id"`
+	Scope                types.String `tfsdk:"scope"`
+	TeamID               types.String `tfsdk:"team_id"`
+	EnvironmentValues    types.Map    `tfsdk:"environment_values"`
+	UserConfigValues     types.Map    `tfsdk:"user_config_values"`
+	SecretID             types.String `tfsdk:"secret_id"`
+	AccessToken          types.String `tfsdk:"access_token"`
+	AccessTokenWO        types.String `tfsdk:"access_token_wo"`
+	AccessTokenWOVersion types.Int64  `tfsdk:"access_token_wo_version"`
+	ServiceAccount       types.String `tfsdk:"service_account"`
+	IsByosVault          types.Bool   `tfsdk:"is_byos_vault"`
+	AgentIDs             types.List   `tfsdk:"agent_ids"`
 	// Tools is a Computed list — the slice form ([]struct) can't represent
 	// the plan-time "unknown" marker the framework needs before Create
 	// runs, so this stays a types.List wrapping mcpServerToolObjectType.
@@ -119,6 +127,9 @@ type MCPServerResourceModel struct {
 	// instead of either a `data "archestra_mcp_server_tool"` block or a
 	// `for/if` HCL expression over the list.
 	ToolIDByName types.Map `tfsdk:"tool_id_by_name"`
+
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 // mcpServerToolObjectType is the per-element shape of the `tools`
@@ -232,6 +243,15 @@ func (r *MCPServerResource) Schema(ctx context.Context, req resource.SchemaReque
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"access_token_wo": writeOnlyAttribute("access_token", "Personal access token for the MCP server."),
+			// Installations cannot be updated, so rotating the token means
+			// reinstalling: the version forces replacement.
+			"access_token_wo_version": func() schema.Int64Attribute {
+				a := writeOnlyVersionAttribute("access_token")
+				a.MarkdownDescription += " Changing it reinstalls the MCP server."
+				a.PlanModifiers = []planmodifier.Int64{int64planmodifier.RequiresReplace()}
+				return a
+			}(),
 			"service_account": schema.StringAttribute{
 				MarkdownDescription: "Kubernetes service account override for the MCP server pod",
 				Optional:            true,
@@ -310,6 +330,10 @@ func (r *MCPServerResource) Schema(ctx context.Context, req resource.SchemaReque
 				Computed:            true,
 				ElementType:         types.StringType,
 			},
+			"organization_id": organizationIDSchemaAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx, false),
 		},
 	}
 }
@@ -349,21 +373,31 @@ func (r *MCPServerResource) ValidateConfig(ctx context.Context, req resource.Val
 	}
 }
 
+func (r *MCPServerResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("MCP server installation identifier.")
+}
+
 func (r *MCPServerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *MCPServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "mcpServer"}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 func (r *MCPServerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -372,6 +406,11 @@ func (r *MCPServerResource) Create(ctx context.Context, req resource.CreateReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := timeoutContext(ctx, data.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Create request body using generated type
 	requestBody := client.InstallMcpServerJSONRequestBody{
@@ -422,6 +461,18 @@ func (r *MCPServerResource) Create(ctx context.Context, req resource.CreateReque
 		requestBody.AccessToken = &token
 	}
 
+	// Write-only: only config carries the value. Create is the one call
+	// that sends it, so the version needs no comparison here.
+	var accessTokenWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("access_token_wo"), &accessTokenWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !accessTokenWO.IsNull() && !accessTokenWO.IsUnknown() {
+		token := accessTokenWO.ValueString()
+		requestBody.AccessToken = &token
+	}
+
 	if !data.ServiceAccount.IsNull() && !data.ServiceAccount.IsUnknown() {
 		sa := data.ServiceAccount.ValueString()
 		requestBody.ServiceAccount = &sa
@@ -504,6 +555,7 @@ func (r *MCPServerResource) Create(ctx context.Context, req resource.CreateReque
 	data.ToolIDByName = toolIDsByName
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *MCPServerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -512,6 +564,14 @@ func (r *MCPServerResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := timeoutContext(ctx, data.Timeouts.Read, &resp.Diagnostics)
+	defer cancel()
+
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
 
 	if data.ID.IsNull() || data.ID.ValueString() == "" {
 		resp.State.RemoveResource(ctx)
@@ -549,6 +609,10 @@ func (r *MCPServerResource) Read(ctx context.Context, req resource.ReadRequest,
 	// Map response to Terraform state.
 	// Note: Keep user's configured name, set display_name to the API-returned name
 	data.DisplayName = types.StringValue(apiResp.JSON200.Name)
+	if data.Name.IsNull() {
+		// Imported by identity: no user-configured name to keep.
+		data.Name = data.DisplayName
+	}
 	data.CatalogID = types.StringValue(apiResp.JSON200.CatalogId.String())
 	data.Scope = types.StringValue(scopeFromResponseBody(apiResp.Body))
 
@@ -593,16 +657,23 @@ func (r *MCPServerResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *MCPServerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// NOTE: The Archestra API does not support updating MCP servers.
-	// Updates will trigger resource replacement (delete + create).
-	// This function should never be called due to RequiresReplace plan modifiers on all attributes.
-	resp.Diagnostics.AddError(
-		"Update Not Supported",
-		"MCP server updates are not supported by the API. This should have triggered a replacement.",
-	)
+	// The Archestra API does not support updating MCP servers: every wire
+	// attribute is RequiresReplace, so the only in-place change is the
+	// `timeouts` block. Persist it onto the prior state.
+	var state, plan MCPServerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *MCPServerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -611,6 +682,8 @@ func (r *MCPServerResource) Delete(ctx context.Context, req resource.DeleteReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx, cancel := timeoutContext(ctx, data.Timeouts.Delete, &resp.Diagnostics)
+	defer cancel()
 
 	// Parse UUID from state
 	serverID, err := uuid.Parse(data.ID.ValueString())
@@ -642,7 +715,15 @@ func (r *MCPServerResource) Delete(ctx context.Context, req resource.DeleteReque
 // `display_name` is, with a possible uniqueness suffix), so bare-UUID
 // import would leave `name` null and force destroy+recreate on the
 // next plan. The composite carries the user's intended name explicitly.
+//
+// Identity-based import (and `terraform query` results) carry only the
+// UUID; Read then adopts the backend `display_name` as `name`, which is
+// right unless the backend added a uniqueness suffix.
 func (r *MCPServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID == "" {
+		resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+		return
+	}
 	parts := strings.SplitN(req.ID, ":", 2)
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError(
@@ -670,7 +751,13 @@ func (r *MCPServerResource) waitForServerTools(ctx context.Context, serverID str
 		return nullList, nullMap, diags
 	}
 
+	// A configured create timeout has already bounded ctx; wait until it
+	// rather than the default budget.
 	deadline := time.Now().Add(mcpServerInstallTimeout)
+	if d, ok := ctx.Deadline(); ok {
+		deadline = d
+	}
+	budget := time.Until(deadline).Round(time.Second)
 	for {
 		statusResp, err := r.client.GetMcpServerInstallationStatusWithResponse(ctx, serverUUID)
 		if err != nil {
@@ -709,10 +796,13 @@ func (r *MCPServerResource) waitForServerTools(ctx context.Context, serverID str
 			return nullList, nullMap, diags
 		}
 
-		if time.Now().After(deadline) {
+		// Stop before the next poll would overrun the deadline: with a
+		// configured timeout, ctx expires there and the status call would
+		// fail instead of producing this warning.
+		if time.Now().Add(mcpServerInstallPoll).After(deadline) {
 			diags.AddWarning("Tools not ready",
-				fmt.Sprintf("timeout after %s waiting for MCP server installation to reach success/error (last status: %q). Tools will appear on the next refresh.",
-					mcpServerInstallTimeout, statusResp.JSON200.LocalInstallationStatus))
+				fmt.Sprintf("timeout after %s waiting for MCP server installation to reach success/error (last status: %q). Tools will appear on the next refresh; raise `timeouts.create` for slow image pulls.",
+					budget, statusResp.JSON200.LocalInstallationStatus))
 			return nullList, nullMap, diags
 		}
 		select {
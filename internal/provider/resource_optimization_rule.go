@@ -5,6 +5,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
 	"github.com/google/uuid"
@@ -27,6 +29,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &OptimizationRuleResource{}
 var _ resource.ResourceWithImportState = &OptimizationRuleResource{}
+var _ resource.ResourceWithIdentity = &OptimizationRuleResource{}
+var _ resource.ResourceWithModifyPlan = &OptimizationRuleResource{}
+var _ resource.ResourceWithConfigValidators = &OptimizationRuleResource{}
 
 func NewOptimizationRuleResource() resource.Resource {
 	return &OptimizationRuleResource{}
@@ -34,7 +39,8 @@ func NewOptimizationRuleResource() resource.Resource {
 
 // OptimizationRuleResource defines the resource implementation.
 type OptimizationRuleResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 // OptimizationRuleConditionModel represents a single condition.
@@ -52,6 +58,8 @@ type OptimizationRuleResourceModel struct {
 	TargetModel types.String `tfsdk:"target_model"`
 	Enabled     types.Bool   `tfsdk:"enabled"`
 	Conditions  types.List   `tfsdk:"conditions"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
 }
 
 func (r *OptimizationRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,7 +68,9 @@ func (r *OptimizationRuleResource) Metadata(ctx context.Context, req resource.Me
 
 func (r *OptimizationRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages cost optimization rules in Archestra.",
+		MarkdownDescription: "Manages cost optimization rules in Archestra.\n\n" +
+			"~> **No rule priority.** The optimization rule API has no priority or ordering field, so the provider cannot order an entity's rules. " +
+			"When several enabled rules of one entity match a request, the backend decides which applies; keep an entity's rules non-overlapping.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -81,20 +91,11 @@ func (r *OptimizationRuleResource) Schema(ctx context.Context, req resource.Sche
 				MarkdownDescription: "Entity ID this rule applies to",
 				Required:            true,
 			},
-			// TODO(backend): the OneOf below only validates that the value
-			// is in the platform's accepted enum — it doesn't check that
-			// the user actually has a configured key for that provider.
-			// A rule for `anthropic` on a backend with zero anthropic
-			// credentials creates successfully and fails silently at
-			// LLM-call time. Right fix is in the platform repo: reject
-			// `POST /optimization-rules` with a 4xx when no provider key
-			// exists for the requested provider. A provider-side
-			// ModifyPlan pre-flight (mirroring resource_team.go's TOON
-			// guard) is the alternative if the backend can't change.
-			// Until either lands, the MarkdownDescription warns users
-			// that mismatches surface at runtime, not at apply.
+			// The OneOf below only validates the platform's enum; ModifyPlan
+			// checks the provider has a configured key and the model is in
+			// its synced model list (see checkTargetModel).
 			"llm_provider": schema.StringAttribute{
-				MarkdownDescription: "LLM provider this rule routes against. Must match a provider you have configured via `archestra_llm_provider_api_key.llm_provider` — the same 17-provider enum the backend accepts (anthropic, azure, bedrock, cerebras, cohere, deepseek, gemini, groq, minimax, mistral, ollama, openai, openrouter, perplexity, vllm, xai, zhipuai). The provider does **not** verify a key exists for the value you set; mismatches surface at LLM-call time, not at apply.",
+				MarkdownDescription: "LLM provider this rule routes against. Must match a provider you have configured via `archestra_llm_provider_api_key.llm_provider` — the same 17-provider enum the backend accepts (anthropic, azure, bedrock, cerebras, cohere, deepseek, gemini, groq, minimax, mistral, ollama, openai, openrouter, perplexity, vllm, xai, zhipuai). Plan fails when `target_model` is not among the provider's synced models. A provider with no key yet only warns at plan, since the key may be created in the same apply, and fails at apply if the key still does not exist.",
 				Required:            true,
 				Validators: []validator.String{
 					stringvalidator.OneOf(
@@ -119,7 +120,7 @@ func (r *OptimizationRuleResource) Schema(ctx context.Context, req resource.Sche
 				},
 			},
 			"target_model": schema.StringAttribute{
-				MarkdownDescription: "Target model to switch to",
+				MarkdownDescription: "Target model to switch to. Must be one of the models synced for `llm_provider`, as listed by the backend's model catalog.",
 				Required:            true,
 			},
 			"enabled": schema.BoolAttribute{
@@ -129,7 +130,7 @@ func (r *OptimizationRuleResource) Schema(ctx context.Context, req resource.Sche
 				Default:             booldefault.StaticBool(true),
 			},
 			"conditions": schema.ListNestedAttribute{
-				MarkdownDescription: "Conditions that trigger the optimization",
+				MarkdownDescription: "Conditions that trigger the optimization. Each condition sets exactly one of `max_length` or `has_tools`.",
 				Required:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -147,25 +148,152 @@ func (r *OptimizationRuleResource) Schema(ctx context.Context, req resource.Sche
 					},
 				},
 			},
+			"organization_id": organizationIDSchemaAttribute(),
 		},
 	}
 }
 
+// ConfigValidators checks each condition against the wire union it is sent
+// as: `{maxLength}` or `{hasTools}`. With both set only one survives the
+// round trip and the next plan shows drift; with neither the backend rejects
+// the rule.
+func (r *OptimizationRuleResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		exactlyOneOfInElements("conditions", "max_length", "has_tools"),
+	}
+}
+
+func (r *OptimizationRuleResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("Optimization rule identifier.")
+}
+
 func (r *OptimizationRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *OptimizationRuleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "limit"}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
+	r.checkTargetModel(ctx, req, resp)
+}
+
+// checkTargetModel is the plan-time pre-flight for llm_provider and
+// target_model; the backend accepts any value in its enum and a rule routing
+// to a provider with no key or an unknown model fails at LLM-call time.
+// It runs only when either attribute changes. A missing key is a warning
+// here, since the key may be created in the same apply; Create and Update
+// turn it into an error through requireProviderKey. An empty model list
+// means the key's models have not synced yet, so the model is not checked.
+func (r *OptimizationRuleResource) checkTargetModel(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+	var plan, state OptimizationRuleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() || plan.LLMProvider.IsUnknown() || plan.TargetModel.IsUnknown() {
+		return
+	}
+	if plan.LLMProvider.Equal(state.LLMProvider) && plan.TargetModel.Equal(state.TargetModel) {
+		return
+	}
+	llmProvider := plan.LLMProvider.ValueString()
+
+	hasKey, err := r.providerHasKey(ctx, llmProvider)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("llm_provider pre-flight: %s", err))
+		return
+	}
+	if !hasKey {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("llm_provider"),
+			"No API Key for Provider",
+			fmt.Sprintf("No `archestra_llm_provider_api_key` exists for %q yet. If one is created in this apply, add it to this rule's `depends_on`; "+
+				"otherwise the apply fails, since the backend would accept a rule that can never route.", llmProvider),
+		)
+		return
+	}
+
+	modelsResp, err := r.client.GetModelsWithApiKeysWithResponse(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("target_model pre-flight: unable to list models: %s", err))
+		return
+	}
+	if modelsResp.JSON200 == nil {
+		resp.Diagnostics.AddError("API Error", fmt.Sprintf("target_model pre-flight: GetModelsWithApiKeys returned status %d: %s", modelsResp.StatusCode(), string(modelsResp.Body)))
+		return
+	}
+	var synced []string
+	for _, m := range *modelsResp.JSON200 {
+		if string(m.Provider) != llmProvider {
+			continue
+		}
+		if m.ModelId == plan.TargetModel.ValueString() {
+			return
+		}
+		synced = append(synced, m.ModelId)
+	}
+	if len(synced) == 0 {
+		tflog.Debug(ctx, "no synced models for provider; skipping target_model check", map[string]any{"llm_provider": llmProvider})
+		return
+	}
+	sort.Strings(synced)
+	resp.Diagnostics.AddAttributeError(
+		path.Root("target_model"),
+		"Unknown Target Model",
+		fmt.Sprintf("%q is not among the models synced for %q: %s. Check the model ID, or sync the provider's models in the Archestra UI.",
+			plan.TargetModel.ValueString(), llmProvider, strings.Join(synced, ", ")),
+	)
+}
+
+// requireProviderKey is the apply-time half of checkTargetModel: by the time
+// the rule is written, any key created in the same apply exists.
+func (r *OptimizationRuleResource) requireProviderKey(ctx context.Context, llmProvider string, diags *diag.Diagnostics) {
+	hasKey, err := r.providerHasKey(ctx, llmProvider)
+	if err != nil {
+		diags.AddError("API Error", fmt.Sprintf("llm_provider pre-flight: %s", err))
+		return
+	}
+	if !hasKey {
+		diags.AddAttributeError(
+			path.Root("llm_provider"),
+			"No API Key for Provider",
+			fmt.Sprintf("No `archestra_llm_provider_api_key` exists for %q. Create one first, or add the one in this configuration to the rule's `depends_on`.", llmProvider),
+		)
+	}
+}
+
+func (r *OptimizationRuleResource) providerHasKey(ctx context.Context, llmProvider string) (bool, error) {
+	p := client.GetLlmProviderApiKeysParamsProvider(llmProvider)
+	apiResp, err := r.client.GetLlmProviderApiKeysWithResponse(ctx, &client.GetLlmProviderApiKeysParams{Provider: &p})
+	if err != nil {
+		return false, fmt.Errorf("unable to list LLM provider API keys: %w", err)
+	}
+	if apiResp.JSON200 == nil {
+		return false, fmt.Errorf("GetLlmProviderApiKeys returned status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+	}
+	for _, k := range *apiResp.JSON200 {
+		if string(k.Provider) == llmProvider {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 func (r *OptimizationRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -184,6 +312,11 @@ func (r *OptimizationRuleResource) Create(ctx context.Context, req resource.Crea
 	}
 	LogPatch(ctx, "archestra_optimization_rule Create", patch, optimizationRuleAttrSpec)
 
+	r.requireProviderKey(ctx, data.LLMProvider.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	jsonBody, err := json.Marshal(patch)
 	if err != nil {
 		resp.Diagnostics.AddError("Marshal Error", fmt.Sprintf("Unable to marshal request body: %s", err))
@@ -212,6 +345,7 @@ func (r *OptimizationRuleResource) Create(ctx context.Context, req resource.Crea
 	data.Enabled = types.BoolValue(apiResp.JSON200.Enabled)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *OptimizationRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -223,6 +357,12 @@ func (r *OptimizationRuleResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	ruleID := data.ID.ValueString()
 
 	// TODO(backend): expose `GET /api/optimization-rules/{id}` so Read
@@ -354,6 +494,8 @@ func (r *OptimizationRuleResource) Read(ctx context.Context, req resource.ReadRe
 	data.Conditions = condList
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 // flattenOptimizationConditions parses the wire union back into the HCL list.
@@ -422,6 +564,13 @@ func (r *OptimizationRuleResource) Update(ctx context.Context, req resource.Upda
 	}
 	LogPatch(ctx, "archestra_optimization_rule Update", patch, optimizationRuleAttrSpec)
 
+	if _, ok := patch["provider"]; ok {
+		r.requireProviderKey(ctx, data.LLMProvider.ValueString(), &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	jsonBody, err := json.Marshal(patch)
 	if err != nil {
 		resp.Diagnostics.AddError("Marshal Error", fmt.Sprintf("Unable to marshal request body: %s", err))
@@ -457,6 +606,7 @@ func (r *OptimizationRuleResource) Update(ctx context.Context, req resource.Upda
 	data.Enabled = types.BoolValue(apiResp.JSON200.Enabled)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *OptimizationRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -490,5 +640,5 @@ func (r *OptimizationRuleResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *OptimizationRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
@@ -1,9 +1,13 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -14,12 +18,12 @@ func TestAccOptimizationRuleResource(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
-				Config: testAccOptimizationRuleResourceConfig("openai", "gpt-4o-mini", 500),
+				Config: testAccOptimizationRuleResourceConfig("ollama", "llama3", 500),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttrSet("archestra_optimization_rule.test", "entity_id"),
 					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "entity_type", "organization"),
-					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "llm_provider", "openai"),
-					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "target_model", "gpt-4o-mini"),
+					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "llm_provider", "ollama"),
+					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "target_model", "llama3"),
 					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "enabled", "true"),
 					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "conditions.0.max_length", "500"),
 					resource.TestCheckResourceAttrSet("archestra_optimization_rule.test", "id"),
@@ -34,9 +38,9 @@ func TestAccOptimizationRuleResource(t *testing.T) {
 			},
 			// Update and Read testing
 			{
-				Config: testAccOptimizationRuleResourceConfig("openai", "gpt-3.5-turbo", 1000),
+				Config: testAccOptimizationRuleResourceConfig("ollama", "llama3", 1000),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "target_model", "gpt-3.5-turbo"),
+					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "target_model", "llama3"),
 					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "conditions.0.max_length", "1000"),
 				),
 			},
@@ -52,10 +56,10 @@ func TestAccOptimizationRuleResourceWithHasTools(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create with has_tools condition
 			{
-				Config: testAccOptimizationRuleResourceConfigWithHasTools("anthropic", "claude-3-haiku-20240307", false),
+				Config: testAccOptimizationRuleResourceConfigWithHasTools("ollama", "llama3", false),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "llm_provider", "anthropic"),
-					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "target_model", "claude-3-haiku-20240307"),
+					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "llm_provider", "ollama"),
+					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "target_model", "llama3"),
 					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "conditions.0.has_tools", "false"),
 					resource.TestCheckResourceAttrSet("archestra_optimization_rule.test", "id"),
 				),
@@ -72,9 +76,9 @@ func TestAccOptimizationRuleResourceDisabled(t *testing.T) {
 		Steps: []resource.TestStep{
 			// Create with enabled = false
 			{
-				Config: testAccOptimizationRuleResourceConfigDisabled("gemini", "gemini-1.5-flash", 200),
+				Config: testAccOptimizationRuleResourceConfigDisabled("ollama", "llama3", 200),
 				Check: resource.ComposeAggregateTestCheckFunc(
-					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "llm_provider", "gemini"),
+					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "llm_provider", "ollama"),
 					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "enabled", "false"),
 					resource.TestCheckResourceAttrSet("archestra_optimization_rule.test", "id"),
 				),
@@ -87,8 +91,9 @@ func TestAccOptimizationRuleResourceDisabled(t *testing.T) {
 func testAccOptimizationRuleResourceConfig(provider, targetModel string, maxLength int) string {
 	return fmt.Sprintf(`
 resource "archestra_organization_settings" "test" {}
-
+%[4]s
 resource "archestra_optimization_rule" "test" {
+  depends_on   = [archestra_llm_provider_api_key.rule]
   entity_id    = archestra_organization_settings.test.id
   entity_type  = "organization"
   llm_provider = %[1]q
@@ -100,14 +105,15 @@ resource "archestra_optimization_rule" "test" {
     }
   ]
 }
-`, provider, targetModel, maxLength)
+`, provider, targetModel, maxLength, testAccOptimizationRuleProviderKey)
 }
 
 func testAccOptimizationRuleResourceConfigWithHasTools(provider, targetModel string, hasTools bool) string {
 	return fmt.Sprintf(`
 resource "archestra_organization_settings" "test" {}
-
+%[4]s
 resource "archestra_optimization_rule" "test" {
+  depends_on   = [archestra_llm_provider_api_key.rule]
   entity_id    = archestra_organization_settings.test.id
   entity_type  = "organization"
   llm_provider = %[1]q
@@ -119,14 +125,15 @@ resource "archestra_optimization_rule" "test" {
     }
   ]
 }
-`, provider, targetModel, hasTools)
+`, provider, targetModel, hasTools, testAccOptimizationRuleProviderKey)
 }
 
 func testAccOptimizationRuleResourceConfigDisabled(provider, targetModel string, maxLength int) string {
 	return fmt.Sprintf(`
 resource "archestra_organization_settings" "test" {}
-
+%[4]s
 resource "archestra_optimization_rule" "test" {
+  depends_on   = [archestra_llm_provider_api_key.rule]
   entity_id    = archestra_organization_settings.test.id
   entity_type  = "organization"
   llm_provider = %[1]q
@@ -138,9 +145,21 @@ resource "archestra_optimization_rule" "test" {
     }
   ]
 }
-`, provider, targetModel, maxLength)
+`, provider, targetModel, maxLength, testAccOptimizationRuleProviderKey)
 }
 
+// testAccOptimizationRuleProviderKey gives the rules under test a key to
+// pass the llm_provider pre-flight. The stack's only reachable provider is
+// the Ollama mock, which serves a single model, llama3.
+const testAccOptimizationRuleProviderKey = `
+resource "archestra_llm_provider_api_key" "rule" {
+  name              = "Optimization Rule Test Key"
+  llm_provider      = "ollama"
+  vault_secret_path = "secret/data/test/ollama"
+  vault_secret_key  = "api_key"
+}
+`
+
 // TestAccOptimizationRuleResource_OllamaProvider pins that the
 // `llm_provider` validator accepts the full backend enum, not just the
 // three providers it was originally hardcoded with (openai / anthropic
@@ -153,13 +172,79 @@ func TestAccOptimizationRuleResource_OllamaProvider(t *testing.T) {
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccOptimizationRuleResourceConfig("ollama", "qwen2.5:7b", 500),
+				Config: testAccOptimizationRuleResourceConfig("ollama", "llama3", 500),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "llm_provider", "ollama"),
-					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "target_model", "qwen2.5:7b"),
+					resource.TestCheckResourceAttr("archestra_optimization_rule.test", "target_model", "llama3"),
 					resource.TestCheckResourceAttrSet("archestra_optimization_rule.test", "id"),
 				),
 			},
 		},
 	})
 }
+
+func TestOptimizationRuleTargetModelPreflight(t *testing.T) {
+	ollamaKey := json.RawMessage(`[{"id":"8d4f1a52-6c3e-4f0b-9a77-2b1e5c9d0f31","name":"Local Ollama","provider":"ollama","scope":"org",` +
+		`"isPrimary":true,"isSystem":false,"organizationId":"org","createdAt":"2026-09-01T00:00:00Z","updatedAt":"2026-09-01T00:00:00Z"}]`)
+	ollamaModels := json.RawMessage(`[{"id":"0b7e2f3c-1d4a-4c5e-8f9a-6b2c3d4e5f60","modelId":"llama3","provider":"ollama","externalId":"ollama/llama3",` +
+		`"apiKeys":[],"priceSource":"default","createdAt":"2026-09-01T00:00:00Z","updatedAt":"2026-09-01T00:00:00Z","lastSyncedAt":"2026-09-01T00:00:00Z"}]`)
+
+	cases := []struct {
+		name        string
+		keys        json.RawMessage
+		models      json.RawMessage
+		targetModel string
+		wantError   string
+		wantWarning string
+	}{
+		{name: "synced model", keys: ollamaKey, models: ollamaModels, targetModel: "llama3"},
+		{name: "unknown model", keys: ollamaKey, models: ollamaModels, targetModel: "llama-3", wantError: "Unknown Target Model"},
+		{name: "models not synced yet", keys: ollamaKey, models: json.RawMessage(`[]`), targetModel: "llama-3"},
+		{name: "no key", keys: json.RawMessage(`[]`), targetModel: "llama3", wantWarning: "No API Key for Provider"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			routes := map[string]json.RawMessage{"/api/llm-provider-api-keys": tc.keys}
+			if tc.models != nil {
+				routes["/api/llm-models"] = tc.models
+			}
+			ps := newConfiguredProviderServer(t, newFixtureBackend(t, routes).URL)
+
+			schemaResp, err := ps.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			objType := schemaResp.ResourceSchemas["archestra_optimization_rule"].ValueType().(tftypes.Object)
+			config := validatorConfig(t, objType, `{"entity_type":"organization","entity_id":"org","llm_provider":"ollama",`+
+				`"target_model":"`+tc.targetModel+`","conditions":[{"max_length":500,"has_tools":null}]}`, nil)
+			prior, err := tfprotov6.NewDynamicValue(objType, tftypes.NewValue(objType, nil))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := ps.PlanResourceChange(t.Context(), &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "archestra_optimization_rule",
+				PriorState:       &prior,
+				ProposedNewState: &config,
+				Config:           &config,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var errs, warnings []string
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					errs = append(errs, d.Summary)
+				} else {
+					warnings = append(warnings, d.Summary)
+				}
+			}
+			if got := strings.Join(errs, "; "); got != tc.wantError {
+				t.Errorf("errors: got %q, want %q", got, tc.wantError)
+			}
+			if got := strings.Join(warnings, "; "); got != tc.wantWarning {
+				t.Errorf("warnings: got %q, want %q", got, tc.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.Resource = &ProfileResource{}
+var _ resource.ResourceWithImportState = &ProfileResource{}
+var _ resource.ResourceWithIdentity = &ProfileResource{}
+var _ resource.ResourceWithModifyPlan = &ProfileResource{}
+var _ resource.ResourceWithConfigValidators = &ProfileResource{}
+
+func NewProfileResource() resource.Resource { return &ProfileResource{} }
+
+type ProfileResource struct {
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
+}
+
+// ProfileResourceModel is the schema for an Archestra profile, the agent
+// type that predates the agent / LLM proxy / MCP gateway split and still
+// serves both the proxy and the MCP endpoint for existing clients.
+type ProfileResourceModel struct {
+	AgentCommonModel
+
+	PassthroughHeaders types.List   `tfsdk:"passthrough_headers"`
+	IdentityProviderId types.String `tfsdk:"identity_provider_id"`
+	ToolExposureMode   types.String `tfsdk:"tool_exposure_mode"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+func (r *ProfileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_profile"
+}
+
+func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Profile, the agent type that predates the split into `archestra_agent`, `archestra_llm_proxy` and `archestra_mcp_gateway`. " +
+			"Profiles still back existing LLM proxy and MCP clients; use this resource to keep them under Terraform until they are migrated. " +
+			"Only agents of type `profile` can be managed or imported here.",
+		Attributes: withAttributes(map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "profile identifier",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"name":        schema.StringAttribute{Required: true, MarkdownDescription: "Profile name"},
+			"description": schema.StringAttribute{Optional: true, MarkdownDescription: "Human-readable description"},
+			"icon":        schema.StringAttribute{Optional: true, MarkdownDescription: "Emoji or base64 image data URL"},
+			"passthrough_headers": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Allowlist of HTTP header names to forward from profile requests to downstream MCP servers",
+			},
+			"identity_provider_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Identity provider used to validate inbound JWTs. Reference an `archestra_identity_provider`. Omit to disable JWT auth.",
+			},
+			"tool_exposure_mode": schema.StringAttribute{
+				Optional:   true,
+				Computed:   true,
+				Validators: []validator.String{stringvalidator.OneOf("full", "search_and_run_only")},
+				MarkdownDescription: "How assigned tools are exposed to clients. `full` lists every assigned tool; " +
+					"`search_and_run_only` (progressive tool loading) lists only `search_tools` and `run_tool` and " +
+					"resolves the rest on demand, which keeps a large tool set out of the client's context window.",
+			},
+			"consider_context_untrusted": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the profile context is treated as untrusted",
+			},
+			"is_default": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether this is the default profile",
+			},
+			"scope": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("org"),
+				MarkdownDescription: "Ownership scope: `personal`, `team`, or `org` (default: `org`).",
+				Validators:          []validator.String{stringvalidator.OneOf("personal", "team", "org")},
+			},
+			"teams": schema.ListAttribute{
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Team IDs this profile is assigned to. Required when `scope = \"team\"`. Removing from configuration clears the assignment on next apply.",
+				PlanModifiers:       []planmodifier.List{EmptyListOnConfigNull()},
+			},
+			"effective_labels": effectiveLabelsSchemaAttribute(),
+			"labels": schema.SetNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Key/value labels for organizing profiles",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key":   schema.StringAttribute{Required: true},
+						"value": schema.StringAttribute{Required: true},
+					},
+				},
+			},
+			"organization_id": organizationIDSchemaAttribute(),
+		}, agentAuditSchemaAttributes()),
+	}
+}
+
+func (r *ProfileResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("profile identifier.")
+}
+
+func (r *ProfileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *ProfileResource) kind() agentKind[ProfileResourceModel] {
+	return agentKind[ProfileResourceModel]{agentType: "profile", noun: "profile", attrSpec: profileAttrSpec, flatten: r.flatten}
+}
+
+func (r *ProfileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	r.kind().modifyPlan(ctx, r.providerData, req, resp)
+}
+
+func (r *ProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	r.kind().create(ctx, r.client, req, resp)
+}
+
+func (r *ProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	r.kind().read(ctx, r.providerData, r.client, req, resp)
+}
+
+func (r *ProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	r.kind().update(ctx, r.client, req, resp)
+}
+
+func (r *ProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	r.kind().delete(ctx, r.client, req, resp)
+}
+
+func (r *ProfileResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return agentScopeValidators()
+}
+
+func (r *ProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	importByNaturalKey(ctx, agentNameImport(r.client, client.GetAllAgentsParamsAgentTypeProfile, "profile"), req, resp)
+}
+
+func (r *ProfileResource) flatten(ctx context.Context, data *ProfileResourceModel, got *agentAPIResponse, _ []byte, diags *diag.Diagnostics) {
+	flattenAgentCommon(ctx, &data.AgentCommonModel, got, r.providerData.defaultLabels(), diags)
+
+	optionalStringFromAPI(&data.IdentityProviderId, got.IdentityProviderId)
+	passthroughHeadersFromAPI(ctx, &data.PassthroughHeaders, got.PassthroughHeaders, diags)
+	optionalStringFromAPI(&data.ToolExposureMode, got.ToolExposureMode)
+}
+
+// AttrSpecs implements resourceWithAttrSpec — activates the schema↔AttrSpec
+// drift lint for this resource.
+func (r *ProfileResource) AttrSpecs() []AttrSpec { return profileAttrSpec }
+
+func (r *ProfileResource) APIShape() any { return client.GetAgentResponse{} }
+
+// KnownIntentionallySkipped — wire fields not modeled on archestra_profile.
+// Agent-only fields (system prompt, built-in config, LLM defaults, incoming
+// email, suggested prompts) and the knowledge sources the split types added
+// stay with archestra_agent and archestra_mcp_gateway; builtIn and tools
+// follow the other agent-table resources.
+func (r *ProfileResource) KnownIntentionallySkipped() []string {
+	return []string{
+		"agentType", "builtIn", "builtInAgentConfig", "suggestedPrompts",
+		"systemPrompt", "llmModel", "llmApiKeyId", "isDefault", "scope",
+		"teams", "considerContextUntrusted", "incomingEmailEnabled",
+		"incomingEmailAllowedDomain", "incomingEmailSecurityMode", "icon",
+		"tools", "knowledgeBaseIds", "connectorIds",
+	}
+}
+
+// profileAttrSpec declares the wire shape for `archestra_profile`: the
+// columns a profile shares with archestra_mcp_gateway, minus the knowledge
+// sources. passthrough_headers is a Postgres `text[]`, atomic on the wire.
+var profileAttrSpec = []AttrSpec{
+	{TFName: "name", JSONName: "name", Kind: Scalar},
+	{TFName: "description", JSONName: "description", Kind: Scalar},
+	{TFName: "icon", JSONName: "icon", Kind: Scalar},
+	{TFName: "passthrough_headers", JSONName: "passthroughHeaders", Kind: List},
+	{TFName: "identity_provider_id", JSONName: "identityProviderId", Kind: Scalar},
+	{TFName: "consider_context_untrusted", JSONName: "considerContextUntrusted", Kind: Scalar},
+	{TFName: "tool_exposure_mode", JSONName: "toolExposureMode", Kind: Scalar},
+	{TFName: "is_default", JSONName: "isDefault", Kind: Scalar},
+	{TFName: "scope", JSONName: "scope", Kind: Scalar},
+	{TFName: "teams", JSONName: "teams", Kind: List},
+	// `labels` merges with the provider's default_labels; effective_labels
+	// carries the wire field (see labels_shared.go).
+	{TFName: "labels", JSONName: "labels", Kind: Synthetic},
+	{TFName: "effective_labels", JSONName: "labels", Kind: Map, Encoder: encodeEffectiveLabels},
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccProfileResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProfileResourceConfig("tf-acc-profile", ""),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("archestra_profile.test", tfjsonpath.New("name"), knownvalue.StringExact("tf-acc-profile")),
+					statecheck.ExpectKnownValue("archestra_profile.test", tfjsonpath.New("scope"), knownvalue.StringExact("org")),
+				},
+			},
+			{
+				ResourceName:      "archestra_profile.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccProfileResourceConfig("tf-acc-profile-renamed", "updated"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("archestra_profile.test", tfjsonpath.New("name"), knownvalue.StringExact("tf-acc-profile-renamed")),
+					statecheck.ExpectKnownValue("archestra_profile.test", tfjsonpath.New("description"), knownvalue.StringExact("updated")),
+				},
+			},
+		},
+	})
+}
+
+func testAccProfileResourceConfig(name, description string) string {
+	desc := ""
+	if description != "" {
+		desc = fmt.Sprintf("description = %q", description)
+	}
+	return fmt.Sprintf(`
+resource "archestra_profile" "test" {
+  name = %q
+  %s
+  labels = [
+    { key = "migration", value = "pending" }
+  ]
+}
+`, name, desc)
+}
+
+// TestAgentTypeCheckedOnRead pins the agentType discriminator: the agent
+// family shares one table, so an ID of the wrong type must fail refresh (and
+// with it import) instead of being adopted.
+func TestAgentTypeCheckedOnRead(t *testing.T) {
+	tests := []struct {
+		resourceType string
+		agentType    string
+		wantErr      string
+	}{
+		{resourceType: "archestra_profile", agentType: "profile"},
+		{resourceType: "archestra_mcp_gateway", agentType: "mcp_gateway"},
+		{resourceType: "archestra_llm_proxy", agentType: "llm_proxy"},
+		{resourceType: "archestra_agent", agentType: "agent"},
+		{resourceType: "archestra_profile", agentType: "mcp_gateway", wantErr: "manage it with archestra_mcp_gateway"},
+		{resourceType: "archestra_mcp_gateway", agentType: "profile", wantErr: "manage it with archestra_profile"},
+		{resourceType: "archestra_llm_proxy", agentType: "agent", wantErr: "manage it with archestra_agent"},
+		{resourceType: "archestra_agent", agentType: "llm_proxy", wantErr: "manage it with archestra_llm_proxy"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.resourceType+"/"+tc.agentType, func(t *testing.T) {
+			server := newFixtureBackend(t, map[string]json.RawMessage{
+				"/api/agents/" + moveAgentID: json.RawMessage(`{"id":"` + moveAgentID + `","name":"legacy","agentType":"` + tc.agentType +
+					`","scope":"org","teams":[],"labels":[],"isDefault":false,"considerContextUntrusted":false}`),
+			})
+			ps := newConfiguredProviderServer(t, server.URL)
+
+			schemaResp, err := ps.GetProviderSchema(t.Context(), &tfprotov6.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			stateType := schemaResp.ResourceSchemas[tc.resourceType].ValueType().(tftypes.Object)
+			state := validatorConfig(t, stateType, `{"id":"`+moveAgentID+`"}`, nil)
+
+			resp, err := ps.ReadResource(t.Context(), &tfprotov6.ReadResourceRequest{TypeName: tc.resourceType, CurrentState: &state})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.wantErr == "" {
+				failOnDiagnostics(t, "ReadResource", resp.Diagnostics)
+				return
+			}
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError && strings.Contains(d.Detail, tc.wantErr) {
+					return
+				}
+			}
+			t.Errorf("expected an error containing %q, got %v", tc.wantErr, resp.Diagnostics)
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 var (
 	_ resource.Resource                = &TeamResource{}
 	_ resource.ResourceWithImportState = &TeamResource{}
+	_ resource.ResourceWithIdentity    = &TeamResource{}
+	_ resource.ResourceWithModifyPlan  = &TeamResource{}
 )
 
 func NewTeamResource() resource.Resource {
@@ -28,7 +30,8 @@ func NewTeamResource() resource.Resource {
 }
 
 type TeamResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type TeamMemberModel struct {
@@ -44,6 +47,8 @@ type TeamResourceModel struct {
 	CreatedBy                types.String      `tfsdk:"created_by"`
 	ConvertToolResultsToToon types.Bool        `tfsdk:"convert_tool_results_to_toon"`
 	Members                  []TeamMemberModel `tfsdk:"members"`
+	CreatedAt                types.String      `tfsdk:"created_at"`
+	UpdatedAt                types.String      `tfsdk:"updated_at"`
 }
 
 func (r *TeamResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -107,25 +112,36 @@ func (r *TeamResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					},
 				},
 			},
+			"created_at": createdAtSchemaAttribute(),
+			"updated_at": updatedAtSchemaAttribute(),
 		},
 	}
 }
 
+func (r *TeamResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("Team identifier.")
+}
+
 func (r *TeamResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *TeamResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "team"}, req, resp)
 }
 
 // validateTeamToonPrecondition checks org `compression_scope == "team"`
@@ -217,6 +233,8 @@ func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, r
 	data.Name = types.StringValue(apiResp.JSON200.Name)
 	data.OrganizationID = types.StringValue(apiResp.JSON200.OrganizationId)
 	data.CreatedBy = types.StringValue(apiResp.JSON200.CreatedBy)
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 	if apiResp.JSON200.Description != nil {
 		data.Description = types.StringValue(*apiResp.JSON200.Description)
 	}
@@ -270,6 +288,7 @@ func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -279,6 +298,12 @@ func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	// Call API
 	apiResp, err := r.client.GetTeamWithResponse(ctx, data.ID.ValueString())
 	if err != nil {
@@ -305,6 +330,8 @@ func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.Name = types.StringValue(apiResp.JSON200.Name)
 	data.OrganizationID = types.StringValue(apiResp.JSON200.OrganizationId)
 	data.CreatedBy = types.StringValue(apiResp.JSON200.CreatedBy)
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 	if apiResp.JSON200.Description != nil {
 		data.Description = types.StringValue(*apiResp.JSON200.Description)
 	} else {
@@ -340,6 +367,8 @@ func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -396,6 +425,8 @@ func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	data.Name = types.StringValue(apiResp.JSON200.Name)
 	data.OrganizationID = types.StringValue(apiResp.JSON200.OrganizationId)
 	data.CreatedBy = types.StringValue(apiResp.JSON200.CreatedBy)
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 	if apiResp.JSON200.Description != nil {
 		data.Description = types.StringValue(*apiResp.JSON200.Description)
 	}
@@ -476,6 +507,7 @@ func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -502,6 +534,33 @@ func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	}
 }
 
+// ImportState accepts the team's ID or `team:<name>`. The list endpoint's
+// `name` filter is a substring search, so matches are re-checked exactly.
 func (r *TeamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importByNaturalKey(ctx, naturalKeyImport{
+		Prefix: "team",
+		Noun:   "team",
+		Lookup: func(ctx context.Context, name string) ([]string, error) {
+			var ids []string
+			limit, offset := 100, 0
+			for {
+				apiResp, err := r.client.GetTeamsWithResponse(ctx, &client.GetTeamsParams{Name: &name, Limit: &limit, Offset: &offset})
+				if err != nil {
+					return nil, err
+				}
+				if apiResp.JSON200 == nil {
+					return nil, fmt.Errorf("GetTeams: expected 200 OK, got status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+				}
+				for _, team := range apiResp.JSON200.Data {
+					if team.Name == name {
+						ids = append(ids, team.Id)
+					}
+				}
+				if !apiResp.JSON200.Pagination.HasNext {
+					return ids, nil
+				}
+				offset += limit
+			}
+		},
+	}, req, resp)
 }
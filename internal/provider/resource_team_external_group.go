@@ -15,20 +15,25 @@ import (
 )
 
 var _ resource.Resource = &TeamExternalGroupResource{}
+var _ resource.ResourceWithIdentity = &TeamExternalGroupResource{}
 var _ resource.ResourceWithImportState = &TeamExternalGroupResource{}
+var _ resource.ResourceWithModifyPlan = &TeamExternalGroupResource{}
 
 func NewTeamExternalGroupResource() resource.Resource {
 	return &TeamExternalGroupResource{}
 }
 
 type TeamExternalGroupResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type TeamExternalGroupModel struct {
 	ID              types.String `tfsdk:"id"`
 	TeamID          types.String `tfsdk:"team_id"`
 	ExternalGroupID types.String `tfsdk:"external_group_id"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
 }
 
 /* ---------------- Metadata ---------------- */
@@ -43,6 +48,13 @@ func (r *TeamExternalGroupResource) Metadata(
 
 /* ---------------- Configure ---------------- */
 
+func (r *TeamExternalGroupResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = compositeIdentitySchema(map[string]string{
+		"team_id":           "Team identifier.",
+		"external_group_id": "Identity-provider group mapped to the team.",
+	})
+}
+
 func (r *TeamExternalGroupResource) Configure(
 	ctx context.Context,
 	req resource.ConfigureRequest,
@@ -52,16 +64,22 @@ func (r *TeamExternalGroupResource) Configure(
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Provider Data",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got %T", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got %T", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *TeamExternalGroupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "team", SubResource: true}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 /* ---------------- Schema ---------------- */
@@ -93,6 +111,7 @@ func (r *TeamExternalGroupResource) Schema(
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"organization_id": organizationIDSchemaAttribute(),
 		},
 	}
 }
@@ -136,6 +155,7 @@ func (r *TeamExternalGroupResource) Create(
 	)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 /* ---------------- Read ---------------- */
@@ -151,6 +171,12 @@ func (r *TeamExternalGroupResource) Read(
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	apiResp, err := r.client.GetTeamExternalGroupsWithResponse(
 		ctx,
 		data.TeamID.ValueString(),
@@ -167,6 +193,8 @@ func (r *TeamExternalGroupResource) Read(
 		if g.Id == mappingID {
 			data.ExternalGroupID = types.StringValue(g.GroupIdentifier)
 			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+			syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 			return
 		}
 	}
@@ -226,6 +254,38 @@ func (r *TeamExternalGroupResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
+	// Identity carries the external group itself, not the backend mapping
+	// ID the composite `id` needs, so look the mapping up on the team.
+	if importFromIdentity(ctx, req, resp) {
+		var teamID, groupID types.String
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("team_id"), &teamID)...)
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("external_group_id"), &groupID)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		apiResp, err := r.client.GetTeamExternalGroupsWithResponse(ctx, teamID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("API Error", fmt.Sprintf("Unable to read team external groups, got error: %s", err))
+			return
+		}
+		if apiResp.JSON200 == nil {
+			resp.Diagnostics.AddError("Unexpected API Response", fmt.Sprintf("Expected 200 OK, got status %d", apiResp.StatusCode()))
+			return
+		}
+		for _, g := range *apiResp.JSON200 {
+			if g.GroupIdentifier == groupID.ValueString() {
+				resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), teamID.ValueString()+"/"+g.Id)...)
+				return
+			}
+		}
+		resp.Diagnostics.AddError(
+			"Import Target Not Found",
+			fmt.Sprintf("Team %q has no external group mapping for %q.", teamID.ValueString(), groupID.ValueString()),
+		)
+		return
+	}
+
 	parts := strings.Split(req.ID, "/")
 	if len(parts) != 2 {
 		resp.Diagnostics.AddError(
@@ -22,13 +22,17 @@ import (
 
 var _ resource.Resource = &ToolInvocationPolicyResource{}
 var _ resource.ResourceWithImportState = &ToolInvocationPolicyResource{}
+var _ resource.ResourceWithIdentity = &ToolInvocationPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &ToolInvocationPolicyResource{}
+var _ resource.ResourceWithUpgradeState = &ToolInvocationPolicyResource{}
 
 func NewToolInvocationPolicyResource() resource.Resource {
 	return &ToolInvocationPolicyResource{}
 }
 
 type ToolInvocationPolicyResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type ToolInvocationPolicyResourceModel struct {
@@ -37,6 +41,10 @@ type ToolInvocationPolicyResourceModel struct {
 	Conditions []PolicyConditionModel `tfsdk:"conditions"`
 	Action     types.String           `tfsdk:"action"`
 	Reason     types.String           `tfsdk:"reason"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
 }
 
 // PolicyConditionModel mirrors the wire `{key, operator, value}` triple shared
@@ -53,6 +61,7 @@ func (r *ToolInvocationPolicyResource) Metadata(ctx context.Context, req resourc
 
 func (r *ToolInvocationPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Conditional tool-invocation policy — fires `action` when ALL of `conditions` match the tool-call arguments. `conditions` must be non-empty; for the unconditional default, use `archestra_tool_invocation_policy_default`.",
 
 		Attributes: map[string]schema.Attribute{
@@ -76,6 +85,7 @@ func (r *ToolInvocationPolicyResource) Schema(ctx context.Context, req resource.
 				Required:            true,
 				Validators: []validator.List{
 					listvalidator.SizeAtLeast(1),
+					policyConditionsValidator(),
 				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -108,23 +118,37 @@ func (r *ToolInvocationPolicyResource) Schema(ctx context.Context, req resource.
 				MarkdownDescription: "Optional reason describing why this policy exists.",
 				Optional:            true,
 			},
+			"organization_id": organizationIDSchemaAttribute(),
+			"created_at":      createdAtSchemaAttribute(),
+			"updated_at":      updatedAtSchemaAttribute(),
 		},
 	}
 }
 
+func (r *ToolInvocationPolicyResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("Tool invocation policy identifier.")
+}
+
 func (r *ToolInvocationPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData),
 		)
 		return
 	}
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *ToolInvocationPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "policy"}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
+	checkPlannedConditionKeys(ctx, r.client, req, resp)
 }
 
 func (r *ToolInvocationPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -162,6 +186,8 @@ func (r *ToolInvocationPolicyResource) Create(ctx context.Context, req resource.
 	data.ID = types.StringValue(apiResp.JSON200.Id.String())
 	data.ToolID = types.StringValue(apiResp.JSON200.ToolId.String())
 	data.Action = types.StringValue(string(apiResp.JSON200.Action))
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 	if apiResp.JSON200.Reason != nil {
 		data.Reason = types.StringValue(*apiResp.JSON200.Reason)
 	} else {
@@ -176,6 +202,7 @@ func (r *ToolInvocationPolicyResource) Create(ctx context.Context, req resource.
 		}
 	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *ToolInvocationPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -185,6 +212,12 @@ func (r *ToolInvocationPolicyResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	policyID, err := uuid.Parse(data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse policy ID: %s", err))
@@ -210,6 +243,8 @@ func (r *ToolInvocationPolicyResource) Read(ctx context.Context, req resource.Re
 
 	data.ToolID = types.StringValue(apiResp.JSON200.ToolId.String())
 	data.Action = types.StringValue(string(apiResp.JSON200.Action))
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 	if apiResp.JSON200.Reason != nil {
 		data.Reason = types.StringValue(*apiResp.JSON200.Reason)
 	} else {
@@ -225,6 +260,8 @@ func (r *ToolInvocationPolicyResource) Read(ctx context.Context, req resource.Re
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *ToolInvocationPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -246,6 +283,7 @@ func (r *ToolInvocationPolicyResource) Update(ctx context.Context, req resource.
 	}
 	if len(patch) == 0 {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 		return
 	}
 	LogPatch(ctx, "archestra_tool_invocation_policy Update", patch, toolInvocationPolicyAttrSpec)
@@ -278,6 +316,8 @@ func (r *ToolInvocationPolicyResource) Update(ctx context.Context, req resource.
 
 	data.ToolID = types.StringValue(apiResp.JSON200.ToolId.String())
 	data.Action = types.StringValue(string(apiResp.JSON200.Action))
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 	if apiResp.JSON200.Reason != nil {
 		data.Reason = types.StringValue(*apiResp.JSON200.Reason)
 	} else {
@@ -293,6 +333,7 @@ func (r *ToolInvocationPolicyResource) Update(ctx context.Context, req resource.
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *ToolInvocationPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -323,5 +364,5 @@ func (r *ToolInvocationPolicyResource) Delete(ctx context.Context, req resource.
 }
 
 func (r *ToolInvocationPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
@@ -20,7 +20,10 @@ import (
 
 var (
 	_ resource.Resource                = &ToolInvocationPolicyDefaultResource{}
+	_ resource.ResourceWithIdentity    = &ToolInvocationPolicyDefaultResource{}
 	_ resource.ResourceWithImportState = &ToolInvocationPolicyDefaultResource{}
+	_ resource.ResourceWithModifyPlan  = &ToolInvocationPolicyDefaultResource{}
+	_ resource.ResourceWithMoveState   = &ToolInvocationPolicyDefaultResource{}
 )
 
 func NewToolInvocationPolicyDefaultResource() resource.Resource {
@@ -28,22 +31,28 @@ func NewToolInvocationPolicyDefaultResource() resource.Resource {
 }
 
 type ToolInvocationPolicyDefaultResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type ToolInvocationPolicyDefaultResourceModel struct {
 	ID      types.String `tfsdk:"id"`
 	ToolIDs types.Set    `tfsdk:"tool_ids"`
 	Action  types.String `tfsdk:"action"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
 }
 
 func (r *ToolInvocationPolicyDefaultResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_tool_invocation_policy_default"
+	// Identity is the shared `action`, which an in-place Update may change.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r *ToolInvocationPolicyDefaultResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Sets the unconditional default invocation action for a set of tools (allow / allow-in-safe-context / require-approval / block). For conditional rules layered on top, use `archestra_tool_invocation_policy`.",
+		MarkdownDescription: "Sets the unconditional default invocation action for a set of tools (allow / allow-in-safe-context / require-approval / block). For conditional rules layered on top, use `archestra_tool_invocation_policy`.\n\n" +
+			"An `archestra_tool_invocation_policy` without conditions (as written by provider versions before `conditions` existed) can be re-homed here with a `moved` block; it becomes a default for its one tool. This applies only to state from those versions: the current `archestra_tool_invocation_policy` requires conditions, and a `moved` block from one is rejected.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -72,21 +81,40 @@ func (r *ToolInvocationPolicyDefaultResource) Schema(_ context.Context, _ resour
 					stringvalidator.OneOf("allow_when_context_is_untrusted", "block_when_context_is_untrusted", "block_always", "require_approval"),
 				},
 			},
+			"organization_id": organizationIDSchemaAttribute(),
 		},
 	}
 }
 
+func (r *ToolInvocationPolicyDefaultResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = compositeIdentitySchema(map[string]string{
+		"action": "Default invocation action the managed tools share.",
+	})
+}
+
+func (r *ToolInvocationPolicyDefaultResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		stateMoverFrom("archestra_tool_invocation_policy", movePolicyToDefault("archestra_tool_invocation_policy", upgradePolicyV0("argument_name"))),
+	}
+}
+
 func (r *ToolInvocationPolicyDefaultResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T", req.ProviderData))
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *ToolInvocationPolicyDefaultResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "policy", SubResource: true}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 func (r *ToolInvocationPolicyDefaultResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -105,6 +133,7 @@ func (r *ToolInvocationPolicyDefaultResource) Create(ctx context.Context, req re
 	}
 	plan.ID = types.StringValue(syntheticToolSetID(tools, plan.Action.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 // Read reconciles state's `tool_ids` against the live policies table.
@@ -121,6 +150,12 @@ func (r *ToolInvocationPolicyDefaultResource) Read(ctx context.Context, req reso
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	stateTools := parseUUIDSet(ctx, state.ToolIDs, &resp.Diagnostics, "tool_ids")
 	if resp.Diagnostics.HasError() {
 		return
@@ -160,6 +195,8 @@ func (r *ToolInvocationPolicyDefaultResource) Read(ctx context.Context, req reso
 	}
 	state.ToolIDs = keptSet
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *ToolInvocationPolicyDefaultResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -233,6 +270,7 @@ func (r *ToolInvocationPolicyDefaultResource) Update(ctx context.Context, req re
 	// lifetime" invariant when `tool_ids` or `action` changes in-place.
 	plan.ID = state.ID
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 // Delete removes the per-tool default policy rows this resource owns by
@@ -296,6 +334,13 @@ func (r *ToolInvocationPolicyDefaultResource) Delete(ctx context.Context, req re
 // listing policies and selecting those whose unconditional default
 // matches the imported action.
 func (r *ToolInvocationPolicyDefaultResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Identity import names the action alone; the synthetic ID is then the
+	// bare action, which Import accepts as well.
+	if importFromIdentity(ctx, req, resp) {
+		var identityAction types.String
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("action"), &identityAction)...)
+		req.ID = identityAction.ValueString()
+	}
 	action := req.ID
 	if i := strings.Index(action, ":"); i >= 0 {
 		action = action[:i]
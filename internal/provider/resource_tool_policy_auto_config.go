@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -18,7 +19,9 @@ import (
 )
 
 var (
-	_ resource.Resource = &ToolPolicyAutoConfigResource{}
+	_ resource.Resource               = &ToolPolicyAutoConfigResource{}
+	_ resource.ResourceWithIdentity   = &ToolPolicyAutoConfigResource{}
+	_ resource.ResourceWithModifyPlan = &ToolPolicyAutoConfigResource{}
 )
 
 func NewToolPolicyAutoConfigResource() resource.Resource {
@@ -26,13 +29,17 @@ func NewToolPolicyAutoConfigResource() resource.Resource {
 }
 
 type ToolPolicyAutoConfigResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type ToolPolicyAutoConfigResourceModel struct {
 	ID      types.String `tfsdk:"id"`
 	ToolIDs types.Set    `tfsdk:"tool_ids"`
 	Results types.List   `tfsdk:"results"`
+
+	OrganizationID types.String   `tfsdk:"organization_id"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
 }
 
 var toolPolicyAutoConfigResultObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
@@ -48,7 +55,7 @@ func (r *ToolPolicyAutoConfigResource) Metadata(_ context.Context, req resource.
 	resp.TypeName = req.ProviderTypeName + "_tool_policy_auto_config"
 }
 
-func (r *ToolPolicyAutoConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *ToolPolicyAutoConfigResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Runs the platform's LLM-driven policy auto-configuration over a set of tools. The backend analyses each tool's name, description, and parameters and writes a default invocation + trusted-data policy plus a reasoning string.\n\n" +
 			"Mirrors the frontend's *Configure with Subagent* button. Spends LLM tokens on every apply, so the resource is **one-shot**: changing `tool_ids` forces a full replacement (re-running the LLM); the `results` list is captured in state and never refreshed.\n\n" +
@@ -116,21 +123,36 @@ func (r *ToolPolicyAutoConfigResource) Schema(_ context.Context, _ resource.Sche
 					},
 				},
 			},
+			"organization_id": organizationIDSchemaAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			// Create is the only operation that calls the backend.
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{Create: true}),
 		},
 	}
 }
 
+func (r *ToolPolicyAutoConfigResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("Synthetic identifier derived from the tool set.")
+}
+
 func (r *ToolPolicyAutoConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T", req.ProviderData))
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *ToolPolicyAutoConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "policy", SubResource: true}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 func (r *ToolPolicyAutoConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -143,6 +165,13 @@ func (r *ToolPolicyAutoConfigResource) Create(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// The LLM analyses every tool in one request; large tool sets can
+	// outlast ARCHESTRA_HTTP_TIMEOUT, which `timeouts.create` replaces.
+	ctx, cancel := timeoutContext(ctx, plan.Timeouts.Create, &resp.Diagnostics)
+	defer cancel()
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	body := client.AutoConfigureAgentToolPoliciesJSONRequestBody{
 		ToolIds: tools,
@@ -195,19 +224,35 @@ func (r *ToolPolicyAutoConfigResource) Create(ctx context.Context, req resource.
 	plan.ID = types.StringValue(syntheticToolSetID(tools, "auto-config"))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *ToolPolicyAutoConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	// One-shot: state is the source of truth, never re-run the LLM.
 	var data ToolPolicyAutoConfigResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
-func (r *ToolPolicyAutoConfigResource) Update(_ context.Context, _ resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// All inputs are RequiresReplace — Update should never be called.
-	resp.Diagnostics.AddError("Update Not Supported",
-		"All `archestra_tool_policy_auto_config` inputs require replacement. This call should never have happened.")
+func (r *ToolPolicyAutoConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All inputs are RequiresReplace; only the `timeouts` block changes in
+	// place. Persist it onto the prior state without re-running the LLM.
+	var state, plan ToolPolicyAutoConfigResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Timeouts = plan.Timeouts
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *ToolPolicyAutoConfigResource) Delete(_ context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -0,0 +1,562 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+var (
+	_ resource.Resource                = &ToolPolicySetResource{}
+	_ resource.ResourceWithIdentity    = &ToolPolicySetResource{}
+	_ resource.ResourceWithImportState = &ToolPolicySetResource{}
+	_ resource.ResourceWithModifyPlan  = &ToolPolicySetResource{}
+)
+
+func NewToolPolicySetResource() resource.Resource {
+	return &ToolPolicySetResource{}
+}
+
+type ToolPolicySetResource struct {
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
+}
+
+type ToolPolicySetResourceModel struct {
+	ID                       types.String                          `tfsdk:"id"`
+	ToolIDs                  types.Set                             `tfsdk:"tool_ids"`
+	DefaultInvocationAction  types.String                          `tfsdk:"default_invocation_action"`
+	DefaultTrustedDataAction types.String                          `tfsdk:"default_trusted_data_action"`
+	InvocationPolicies       []ToolPolicySetInvocationPolicyModel  `tfsdk:"invocation_policies"`
+	TrustedDataPolicies      []ToolPolicySetTrustedDataPolicyModel `tfsdk:"trusted_data_policies"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+type ToolPolicySetInvocationPolicyModel struct {
+	ToolID     types.String           `tfsdk:"tool_id"`
+	Conditions []PolicyConditionModel `tfsdk:"conditions"`
+	Action     types.String           `tfsdk:"action"`
+	Reason     types.String           `tfsdk:"reason"`
+}
+
+type ToolPolicySetTrustedDataPolicyModel struct {
+	ToolID      types.String           `tfsdk:"tool_id"`
+	Description types.String           `tfsdk:"description"`
+	Conditions  []PolicyConditionModel `tfsdk:"conditions"`
+	Action      types.String           `tfsdk:"action"`
+}
+
+func (r *ToolPolicySetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_tool_policy_set"
+}
+
+func (r *ToolPolicySetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Authoritatively owns every tool invocation and trusted-data policy of a set of tools: the conditional rules and the unconditional defaults, in one resource.\n\n" +
+			"Read lists both policy tables and reports every row of the managed tools, so a rule added out-of-band (in the UI, or by another Terraform resource) shows up as a diff and the next apply deletes it. " +
+			"Do not combine this resource with `archestra_tool_invocation_policy`, `archestra_tool_invocation_policy_default`, `archestra_trusted_data_policy`, `archestra_trusted_data_policy_default` or `archestra_tool_policy_auto_config` on the same tools — they would fight over the same rows.\n\n" +
+			"```hcl\n" +
+			"resource \"archestra_tool_policy_set\" \"filesystem\" {\n" +
+			"  tool_ids = toset([for t in archestra_mcp_server_installation.filesystem.tools : t.id])\n\n" +
+			"  default_invocation_action   = \"block_when_context_is_untrusted\"\n" +
+			"  default_trusted_data_action = \"mark_as_untrusted\"\n\n" +
+			"  invocation_policies = [{\n" +
+			"    tool_id    = archestra_mcp_server_installation.filesystem.tool_id_by_name[\"filesystem__write_file\"]\n" +
+			"    conditions = [{ key = \"path\", operator = \"startsWith\", value = \"/etc/\" }]\n" +
+			"    action     = \"block_always\"\n" +
+			"  }]\n" +
+			"}\n" +
+			"```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Synthetic resource ID. Not a backend identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tool_ids": schema.SetAttribute{
+				MarkdownDescription: "Set of bare tool UUIDs whose policies this resource owns. Typically `[for t in archestra_mcp_server_installation.<n>.tools : t.id]`. Removing a tool deletes all of its rules and defaults.",
+				Required:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(uuidRegexp, "must be a bare tool UUID")),
+				},
+			},
+			"default_invocation_action": schema.StringAttribute{
+				MarkdownDescription: "Unconditional invocation action for every tool in `tool_ids` when no conditional rule matches. One of `allow_when_context_is_untrusted`, `block_when_context_is_untrusted`, `block_always`, `require_approval`. " +
+					"Null removes the tools' defaults. When the tools' defaults were changed out-of-band to disagree, refresh warns and reads this as null, so the next apply re-asserts it.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("allow_when_context_is_untrusted", "block_when_context_is_untrusted", "block_always", "require_approval"),
+				},
+			},
+			"default_trusted_data_action": schema.StringAttribute{
+				MarkdownDescription: "Unconditional trusted-data action for every tool in `tool_ids` when no conditional rule matches. One of `mark_as_trusted`, `mark_as_untrusted`, `block_always`, `sanitize_with_dual_llm`. " +
+					"Null removes the tools' defaults. When the tools' defaults were changed out-of-band to disagree, refresh warns and reads this as null, so the next apply re-asserts it.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("mark_as_trusted", "mark_as_untrusted", "block_always", "sanitize_with_dual_llm"),
+				},
+			},
+			"invocation_policies": schema.SetNestedAttribute{
+				MarkdownDescription: "Conditional tool invocation rules. Every `tool_id` must be in `tool_ids`; any other conditional rule of those tools is deleted on apply.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tool_id": schema.StringAttribute{
+							MarkdownDescription: "Bare tool UUID the rule applies to.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(uuidRegexp, "tool_id must be a bare tool UUID"),
+							},
+						},
+						"conditions": policySetConditionsSchemaAttribute("Conditions evaluated against tool-call arguments. ALL must match for `action` to fire.", "Argument name to match."),
+						"action": schema.StringAttribute{
+							MarkdownDescription: "Action to take when the rule matches. One of `allow_when_context_is_untrusted`, `block_when_context_is_untrusted`, `block_always`, `require_approval`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("allow_when_context_is_untrusted", "block_when_context_is_untrusted", "block_always", "require_approval"),
+							},
+						},
+						"reason": schema.StringAttribute{
+							MarkdownDescription: "Optional reason describing why this rule exists.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"trusted_data_policies": schema.SetNestedAttribute{
+				MarkdownDescription: "Conditional trusted-data rules. Every `tool_id` must be in `tool_ids`; any other conditional rule of those tools is deleted on apply.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"tool_id": schema.StringAttribute{
+							MarkdownDescription: "Bare tool UUID the rule applies to.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.RegexMatches(uuidRegexp, "tool_id must be a bare tool UUID"),
+							},
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Description of the rule.",
+							Required:            true,
+						},
+						"conditions": policySetConditionsSchemaAttribute("Conditions evaluated against the tool's result. ALL must match for `action` to fire.", "Attribute path to match (e.g., `payload.role`)."),
+						"action": schema.StringAttribute{
+							MarkdownDescription: "Action to take when the rule matches. One of `mark_as_trusted`, `mark_as_untrusted`, `block_always`, `sanitize_with_dual_llm`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("mark_as_trusted", "mark_as_untrusted", "block_always", "sanitize_with_dual_llm"),
+							},
+						},
+					},
+				},
+			},
+			"organization_id": organizationIDSchemaAttribute(),
+		},
+	}
+}
+
+func policySetConditionsSchemaAttribute(description, keyDescription string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: description,
+		Required:            true,
+		Validators: []validator.List{
+			listvalidator.SizeAtLeast(1),
+			policyConditionsValidator(),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"key": schema.StringAttribute{
+					MarkdownDescription: keyDescription,
+					Required:            true,
+				},
+				"operator": schema.StringAttribute{
+					MarkdownDescription: "Comparison operator. One of `equal`, `notEqual`, `contains`, `notContains`, `startsWith`, `endsWith`, `regex`.",
+					Required:            true,
+					Validators: []validator.String{
+						stringvalidator.OneOf("equal", "notEqual", "contains", "notContains", "startsWith", "endsWith", "regex"),
+					},
+				},
+				"value": schema.StringAttribute{
+					MarkdownDescription: "Value to compare against.",
+					Required:            true,
+				},
+			},
+		},
+	}
+}
+
+func (r *ToolPolicySetResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("Synthetic identifier; on import, the comma-separated tool UUIDs.")
+}
+
+func (r *ToolPolicySetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData))
+		return
+	}
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *ToolPolicySetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "policy", SubResource: true}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
+
+	// Rule tool IDs usually come from an installation created in the same
+	// apply; when they are not yet known, Create / Update check instead.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	var fields map[string]tftypes.Value
+	if err := req.Plan.Raw.As(&fields); err != nil {
+		return
+	}
+	for _, name := range []string{"tool_ids", "invocation_policies", "trusted_data_policies"} {
+		if !fields[name].IsFullyKnown() {
+			return
+		}
+	}
+	var plan ToolPolicySetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	checkPolicySetRuleTools(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.checkPlannedConditionKeys(ctx, req, resp)
+}
+
+// checkPlannedConditionKeys runs the key-path check of
+// archestra_tool_invocation_policy over the invocation rules that are new
+// or changed in this plan.
+func (r *ToolPolicySetResource) checkPlannedConditionKeys(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil {
+		return
+	}
+	var planned, prior types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("invocation_policies"), &planned)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("invocation_policies"), &prior)...)
+	}
+	if resp.Diagnostics.HasError() || planned.IsNull() {
+		return
+	}
+	var tools toolParameters
+	for _, elem := range planned.Elements() {
+		if !prior.IsNull() && slices.ContainsFunc(prior.Elements(), elem.Equal) {
+			continue
+		}
+		obj, ok := elem.(types.Object)
+		if !ok {
+			continue
+		}
+		var rule ToolPolicySetInvocationPolicyModel
+		resp.Diagnostics.Append(obj.As(ctx, &rule, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if tools == nil {
+			if tools = fetchToolParameters(ctx, r.client); tools == nil {
+				return
+			}
+		}
+		checkConditionKeys(tools, rule.ToolID.ValueString(), rule.Conditions, path.Root("invocation_policies").AtSetValue(elem).AtName("conditions"), &resp.Diagnostics)
+	}
+}
+
+func (r *ToolPolicySetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ToolPolicySetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tools := parseUUIDSet(ctx, plan.ToolIDs, &resp.Diagnostics, "tool_ids")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, plan, tools, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.ID = types.StringValue(syntheticToolSetID(tools, "policy_set"))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+// Read rebuilds the rules and defaults from every backend row of the
+// managed tools. `tool_ids` itself is never pruned: it defines ownership,
+// and a rule that vanished or appeared out-of-band surfaces as a diff on
+// the rule sets instead.
+func (r *ToolPolicySetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ToolPolicySetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
+	tools := parseUUIDSet(ctx, state.ToolIDs, &resp.Diagnostics, "tool_ids")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	priorInvocation := invocationRulesFromModel(state.InvocationPolicies, &resp.Diagnostics)
+	priorTrustedData := trustedDataRulesFromModel(state.TrustedDataPolicies, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	invocation, invocationDefault, invocationDiffer, err := readPolicySetTable(ctx, invocationPolicyTable(r.client), tools, priorInvocation)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", err.Error())
+		return
+	}
+	trustedData, trustedDataDefault, trustedDataDiffer, err := readPolicySetTable(ctx, trustedDataPolicyTable(r.client), tools, priorTrustedData)
+	if err != nil {
+		resp.Diagnostics.AddError("API Error", err.Error())
+		return
+	}
+	if invocationDiffer {
+		resp.Diagnostics.AddAttributeWarning(path.Root("default_invocation_action"), "Tool Defaults Differ",
+			"The managed tools no longer share one default invocation action; it was changed outside Terraform. The next apply sets the configured default on every tool.")
+	}
+	if trustedDataDiffer {
+		resp.Diagnostics.AddAttributeWarning(path.Root("default_trusted_data_action"), "Tool Defaults Differ",
+			"The managed tools no longer share one default trusted-data action; it was changed outside Terraform. The next apply sets the configured default on every tool.")
+	}
+
+	state.DefaultInvocationAction = invocationDefault
+	state.DefaultTrustedDataAction = trustedDataDefault
+	// An empty set stays as configured: null and `[]` both mean "no rules".
+	if len(invocation) > 0 || state.InvocationPolicies != nil {
+		state.InvocationPolicies = make([]ToolPolicySetInvocationPolicyModel, len(invocation))
+		for i, rule := range invocation {
+			state.InvocationPolicies[i] = ToolPolicySetInvocationPolicyModel{
+				ToolID:     types.StringValue(rule.ToolID.String()),
+				Conditions: policySetConditionsToModel(rule.Conditions),
+				Action:     types.StringValue(rule.Action),
+				Reason:     types.StringPointerValue(rule.Note),
+			}
+		}
+	}
+	if len(trustedData) > 0 || state.TrustedDataPolicies != nil {
+		state.TrustedDataPolicies = make([]ToolPolicySetTrustedDataPolicyModel, len(trustedData))
+		for i, rule := range trustedData {
+			state.TrustedDataPolicies[i] = ToolPolicySetTrustedDataPolicyModel{
+				ToolID:      types.StringValue(rule.ToolID.String()),
+				Description: types.StringPointerValue(rule.Note),
+				Conditions:  policySetConditionsToModel(rule.Conditions),
+				Action:      types.StringValue(rule.Action),
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+func (r *ToolPolicySetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state ToolPolicySetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	planTools := parseUUIDSet(ctx, plan.ToolIDs, &resp.Diagnostics, "tool_ids")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	stateTools := parseUUIDSet(ctx, state.ToolIDs, &resp.Diagnostics, "tool_ids")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	r.apply(ctx, plan, planTools, stateTools, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Preserve the ID from prior state; it must stay stable when
+	// `tool_ids` changes in place.
+	plan.ID = state.ID
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
+}
+
+// Delete removes every row of both tables for the managed tools, including
+// rules added out-of-band since the last refresh.
+func (r *ToolPolicySetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ToolPolicySetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tools := parseUUIDSet(ctx, state.ToolIDs, &resp.Diagnostics, "tool_ids")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	owned := make(map[openapi_types.UUID]struct{}, len(tools))
+	for _, t := range tools {
+		owned[t] = struct{}{}
+	}
+	for _, table := range []policySetTable{invocationPolicyTable(r.client), trustedDataPolicyTable(r.client)} {
+		if err := reconcilePolicySetTable(ctx, table, owned, nil, nil, ""); err != nil {
+			resp.Diagnostics.AddError("API Error", err.Error())
+			return
+		}
+	}
+}
+
+// ImportState takes the comma-separated tool UUIDs the set should own; Read
+// fills in their rules and defaults. The ID is kept verbatim so
+// ImportStateVerify can match it against prior state.
+func (r *ToolPolicySetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if importFromIdentity(ctx, req, resp) {
+		var identityID types.String
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("id"), &identityID)...)
+		req.ID = identityID.ValueString()
+	}
+	var tools []openapi_types.UUID
+	for _, part := range strings.Split(req.ID, ",") {
+		t, err := uuid.Parse(strings.TrimSpace(part))
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Import ID",
+				fmt.Sprintf("Expected comma-separated tool UUIDs, got %q: %s", req.ID, err),
+			)
+			return
+		}
+		tools = append(tools, t)
+	}
+	toolSet, d := uuidsToStringSet(tools)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(req.ID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tool_ids"), toolSet)...)
+}
+
+// apply reconciles both tables against plan. priorTools is nil on Create.
+func (r *ToolPolicySetResource) apply(ctx context.Context, plan ToolPolicySetResourceModel, tools, priorTools []openapi_types.UUID, diags *diag.Diagnostics) {
+	checkPolicySetRuleTools(ctx, plan, diags)
+	if diags.HasError() {
+		return
+	}
+	owned := make(map[openapi_types.UUID]struct{}, len(tools)+len(priorTools))
+	for _, t := range append(append([]openapi_types.UUID{}, tools...), priorTools...) {
+		owned[t] = struct{}{}
+	}
+
+	invocation := invocationRulesFromModel(plan.InvocationPolicies, diags)
+	trustedData := trustedDataRulesFromModel(plan.TrustedDataPolicies, diags)
+	if diags.HasError() {
+		return
+	}
+
+	if err := reconcilePolicySetTable(ctx, invocationPolicyTable(r.client), owned, tools, invocation, plan.DefaultInvocationAction.ValueString()); err != nil {
+		diags.AddError("API Error", err.Error())
+		return
+	}
+	if err := reconcilePolicySetTable(ctx, trustedDataPolicyTable(r.client), owned, tools, trustedData, plan.DefaultTrustedDataAction.ValueString()); err != nil {
+		diags.AddError("API Error", err.Error())
+	}
+}
+
+func invocationRulesFromModel(policies []ToolPolicySetInvocationPolicyModel, diags *diag.Diagnostics) []policySetRule {
+	rules := make([]policySetRule, len(policies))
+	for i, p := range policies {
+		toolID, err := uuid.Parse(p.ToolID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("invocation_policies"), "Invalid UUID in invocation_policies", fmt.Sprintf("%q: %s", p.ToolID.ValueString(), err))
+			return nil
+		}
+		rules[i] = policySetRule{
+			ToolID:     toolID,
+			Conditions: policySetConditionsFromModel(p.Conditions),
+			Action:     p.Action.ValueString(),
+			Note:       p.Reason.ValueStringPointer(),
+		}
+	}
+	return rules
+}
+
+func trustedDataRulesFromModel(policies []ToolPolicySetTrustedDataPolicyModel, diags *diag.Diagnostics) []policySetRule {
+	rules := make([]policySetRule, len(policies))
+	for i, p := range policies {
+		toolID, err := uuid.Parse(p.ToolID.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("trusted_data_policies"), "Invalid UUID in trusted_data_policies", fmt.Sprintf("%q: %s", p.ToolID.ValueString(), err))
+			return nil
+		}
+		rules[i] = policySetRule{
+			ToolID:     toolID,
+			Conditions: policySetConditionsFromModel(p.Conditions),
+			Action:     p.Action.ValueString(),
+			Note:       p.Description.ValueStringPointer(),
+		}
+	}
+	return rules
+}
+
+// checkPolicySetRuleTools rejects rules for tools outside `tool_ids`. The set
+// only reads back rows of its own tools, so such a rule would be written on
+// every apply and never show up in state.
+func checkPolicySetRuleTools(ctx context.Context, plan ToolPolicySetResourceModel, diags *diag.Diagnostics) {
+	var tools []string
+	diags.Append(plan.ToolIDs.ElementsAs(ctx, &tools, false)...)
+	if diags.HasError() {
+		return
+	}
+	owned := make(map[string]struct{}, len(tools))
+	for _, t := range tools {
+		owned[strings.ToLower(t)] = struct{}{}
+	}
+	for _, p := range plan.InvocationPolicies {
+		if _, ok := owned[strings.ToLower(p.ToolID.ValueString())]; !ok {
+			diags.AddAttributeError(path.Root("invocation_policies"), "Rule For Unmanaged Tool",
+				fmt.Sprintf("Invocation rule for tool %s, which is not in tool_ids. Add the tool to tool_ids or manage the rule elsewhere.", p.ToolID.ValueString()))
+		}
+	}
+	for _, p := range plan.TrustedDataPolicies {
+		if _, ok := owned[strings.ToLower(p.ToolID.ValueString())]; !ok {
+			diags.AddAttributeError(path.Root("trusted_data_policies"), "Rule For Unmanaged Tool",
+				fmt.Sprintf("Trusted-data rule for tool %s, which is not in tool_ids. Add the tool to tool_ids or manage the rule elsewhere.", p.ToolID.ValueString()))
+		}
+	}
+}
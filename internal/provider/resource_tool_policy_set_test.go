@@ -0,0 +1,372 @@
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	policySetToolA = "aaaaaaaa-0000-4000-8000-000000000001"
+	policySetToolB = "aaaaaaaa-0000-4000-8000-000000000002"
+	unmanagedTool  = "bbbbbbbb-0000-4000-8000-000000000001"
+)
+
+// policyTablesBackend keeps both policy tables in memory and implements the
+// list, create, delete and bulk-default endpoints over them.
+type policyTablesBackend struct {
+	mu     sync.Mutex
+	tables map[string][]map[string]any // "tool-invocation" | "trusted-data"
+}
+
+func (b *policyTablesBackend) add(table, toolID, action string, conditions []map[string]any, note map[string]any) {
+	row := map[string]any{
+		"id": uuid.NewString(), "toolId": toolID, "action": action, "conditions": conditions,
+		"createdAt": "2026-10-01T00:00:00Z", "updatedAt": "2026-10-01T00:00:00Z",
+	}
+	for k, v := range note {
+		row[k] = v
+	}
+	b.tables[table] = append(b.tables[table], row)
+}
+
+// summary renders a table as sorted "tool action conditions" lines.
+func (b *policyTablesBackend) summary(table string) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var out []string
+	for _, row := range b.tables[table] {
+		conds, _ := json.Marshal(row["conditions"])
+		out = append(out, row["toolId"].(string)+" "+row["action"].(string)+" "+string(conds))
+	}
+	sort.Strings(out)
+	return out
+}
+
+func newPolicyTablesBackend(t *testing.T) (*policyTablesBackend, string) {
+	t.Helper()
+	b := &policyTablesBackend{tables: map[string][]map[string]any{}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+
+		var table string
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/autonomy-policies/tool-invocation"), r.URL.Path == "/api/tool-invocation/bulk-default":
+			table = "tool-invocation"
+		case strings.HasPrefix(r.URL.Path, "/api/trusted-data-policies"):
+			table = "trusted-data"
+		}
+		rest := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		switch {
+		case table == "":
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			rows := b.tables[table]
+			if rows == nil {
+				rows = []map[string]any{}
+			}
+			_ = json.NewEncoder(w).Encode(rows)
+		case r.Method == http.MethodPost && rest == "bulk-default":
+			var body struct {
+				ToolIds []string `json:"toolIds"`
+				Action  string   `json:"action"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			for _, tool := range body.ToolIds {
+				found := false
+				for _, row := range b.tables[table] {
+					if row["toolId"] == tool && len(row["conditions"].([]map[string]any)) == 0 {
+						row["action"], found = body.Action, true
+					}
+				}
+				if !found {
+					b.add(table, tool, body.Action, []map[string]any{}, nil)
+				}
+			}
+			_, _ = w.Write([]byte(`{"updated":0,"created":0}`))
+		case r.Method == http.MethodPost:
+			raw, _ := io.ReadAll(r.Body)
+			var body map[string]any
+			_ = json.Unmarshal(raw, &body)
+			var conditions []map[string]any
+			for _, c := range body["conditions"].([]any) {
+				conditions = append(conditions, c.(map[string]any))
+			}
+			note := map[string]any{}
+			for _, k := range []string{"reason", "description"} {
+				if v, ok := body[k]; ok {
+					note[k] = v
+				}
+			}
+			b.add(table, body["toolId"].(string), body["action"].(string), conditions, note)
+			_ = json.NewEncoder(w).Encode(b.tables[table][len(b.tables[table])-1])
+		case r.Method == http.MethodDelete:
+			rows := b.tables[table]
+			for i, row := range rows {
+				if row["id"] == rest {
+					b.tables[table] = append(rows[:i:i], rows[i+1:]...)
+					_, _ = w.Write([]byte(`{"success":true}`))
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"message":"not found"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return b, server.URL
+}
+
+// TestToolPolicySetLifecycle drives archestra_tool_policy_set through
+// create, refresh and destroy against an in-memory backend seeded with
+// rules Terraform never wrote.
+func TestToolPolicySetLifecycle(t *testing.T) {
+	backend, url := newPolicyTablesBackend(t)
+	pathCondition := []map[string]any{{"key": "path", "operator": "startsWith", "value": "/etc/"}}
+	backend.add("tool-invocation", policySetToolA, "allow_when_context_is_untrusted", []map[string]any{{"key": "path", "operator": "equal", "value": "/tmp"}}, nil)
+	backend.add("tool-invocation", unmanagedTool, "block_always", pathCondition, nil)
+	backend.add("trusted-data", policySetToolB, "mark_as_trusted", []map[string]any{}, nil)
+
+	ps := newConfiguredProviderServer(t, url)
+	ctx := t.Context()
+	schemaResp, err := ps.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.ResourceSchemas["archestra_tool_policy_set"].ValueType().(tftypes.Object)
+
+	raw := `{"tool_ids":["` + policySetToolA + `","` + policySetToolB + `"],` +
+		`"default_invocation_action":"block_when_context_is_untrusted",` +
+		`"invocation_policies":[{"tool_id":"` + policySetToolA + `","action":"block_always",` +
+		`"conditions":[{"key":"path","operator":"startsWith","value":"/etc/"},{"key":"mode","operator":"equal","value":"w"}]}]}`
+	config := validatorConfig(t, objType, raw, nil)
+	planned := validatorConfig(t, objType, raw, []string{"id", "organization_id"})
+	null, err := tfprotov6.NewDynamicValue(objType, tftypes.NewValue(objType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := ps.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "archestra_tool_policy_set",
+		PriorState:   &null,
+		PlannedState: &planned,
+		Config:       &config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ApplyResourceChange (create)", created.Diagnostics)
+
+	wantInvocation := []string{
+		policySetToolA + ` block_always [{"key":"path","operator":"startsWith","value":"/etc/"},{"key":"mode","operator":"equal","value":"w"}]`,
+		policySetToolA + ` block_when_context_is_untrusted []`,
+		policySetToolB + ` block_when_context_is_untrusted []`,
+		unmanagedTool + ` block_always [{"key":"path","operator":"startsWith","value":"/etc/"}]`,
+	}
+	if got := backend.summary("tool-invocation"); strings.Join(got, "\n") != strings.Join(wantInvocation, "\n") {
+		t.Errorf("invocation rows after create:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(wantInvocation, "\n"))
+	}
+	if got := backend.summary("trusted-data"); len(got) != 0 {
+		t.Errorf("trusted-data rows after create = %q, want none", got)
+	}
+
+	// The backend hands the configured rule back with an empty reason and
+	// its conditions reordered, which must not read as a change.
+	for _, row := range backend.tables["tool-invocation"] {
+		if conds, ok := row["conditions"].([]map[string]any); ok && len(conds) == 2 {
+			row["conditions"] = []map[string]any{conds[1], conds[0]}
+			row["reason"] = ""
+		}
+	}
+	// An out-of-band rule and a changed default show up on refresh.
+	backend.add("tool-invocation", policySetToolB, "require_approval", pathCondition, map[string]any{"reason": "added in the UI"})
+	backend.add("trusted-data", policySetToolA, "mark_as_untrusted", []map[string]any{}, nil)
+	read, err := ps.ReadResource(ctx, &tfprotov6.ReadResourceRequest{TypeName: "archestra_tool_policy_set", CurrentState: created.NewState})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ReadResource", read.Diagnostics)
+	if len(read.Diagnostics) != 1 || read.Diagnostics[0].Summary != "Tool Defaults Differ" {
+		t.Errorf("ReadResource diagnostics = %+v, want one Tool Defaults Differ warning", read.Diagnostics)
+	}
+	got, err := read.NewState.Unmarshal(objType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]tftypes.Value
+	if err := got.As(&fields); err != nil {
+		t.Fatal(err)
+	}
+	var rules []tftypes.Value
+	if err := fields["invocation_policies"].As(&rules); err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 2 {
+		t.Errorf("invocation_policies after refresh has %d rules, want the configured one plus the out-of-band one", len(rules))
+	}
+	createdState, err := created.NewState.Unmarshal(objType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var createdFields map[string]tftypes.Value
+	if err := createdState.As(&createdFields); err != nil {
+		t.Fatal(err)
+	}
+	var configured []tftypes.Value
+	if err := createdFields["invocation_policies"].As(&configured); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.ContainsFunc(rules, configured[0].Equal) {
+		t.Errorf("configured rule %s not read back as written; got %v", configured[0], rules)
+	}
+	assertAttrEqual(t, got, tftypes.NewAttributePath().WithAttributeName("default_trusted_data_action"),
+		tftypes.NewValue(tftypes.String, nil))
+	assertAttrEqual(t, got, tftypes.NewAttributePath().WithAttributeName("default_invocation_action"),
+		tftypes.NewValue(tftypes.String, "block_when_context_is_untrusted"))
+
+	deleted, err := ps.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:     "archestra_tool_policy_set",
+		PriorState:   read.NewState,
+		PlannedState: &null,
+		Config:       &null,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ApplyResourceChange (delete)", deleted.Diagnostics)
+	if got := backend.summary("tool-invocation"); len(got) != 1 || !strings.HasPrefix(got[0], unmanagedTool) {
+		t.Errorf("invocation rows after destroy = %q, want only the unmanaged tool's rule", got)
+	}
+	if got := backend.summary("trusted-data"); len(got) != 0 {
+		t.Errorf("trusted-data rows after destroy = %q, want none", got)
+	}
+}
+
+func TestToolPolicySetRuleForUnmanagedTool(t *testing.T) {
+	ps := newConfiguredProviderServer(t, newFixtureBackend(t, nil).URL)
+	ctx := t.Context()
+	schemaResp, err := ps.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.ResourceSchemas["archestra_tool_policy_set"].ValueType().(tftypes.Object)
+	raw := `{"tool_ids":["` + policySetToolA + `"],"trusted_data_policies":[{"tool_id":"` + unmanagedTool + `",` +
+		`"description":"elsewhere","action":"mark_as_trusted","conditions":[{"key":"role","operator":"equal","value":"admin"}]}]}`
+	config := validatorConfig(t, objType, raw, nil)
+	proposed := validatorConfig(t, objType, raw, []string{"id"})
+	null, err := tfprotov6.NewDynamicValue(objType, tftypes.NewValue(objType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := ps.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "archestra_tool_policy_set",
+		PriorState:       &null,
+		ProposedNewState: &proposed,
+		Config:           &config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Rule For Unmanaged Tool" {
+		t.Fatalf("diagnostics = %+v, want one Rule For Unmanaged Tool error", resp.Diagnostics)
+	}
+}
+
+// TestToolPolicySetMalformedRuleToolID checks that a rule tool ID that is
+// not a UUID is reported on refresh rather than panicking the provider.
+func TestToolPolicySetMalformedRuleToolID(t *testing.T) {
+	ps := newConfiguredProviderServer(t, newFixtureBackend(t, nil).URL)
+	ctx := t.Context()
+	schemaResp, err := ps.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.ResourceSchemas["archestra_tool_policy_set"].ValueType().(tftypes.Object)
+	state := validatorConfig(t, objType, `{"id":"set","tool_ids":["`+policySetToolA+`"],`+
+		`"invocation_policies":[{"tool_id":"not-a-uuid","action":"block_always","conditions":[]}]}`, nil)
+
+	resp, err := ps.ReadResource(ctx, &tfprotov6.ReadResourceRequest{TypeName: "archestra_tool_policy_set", CurrentState: &state})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Invalid UUID in invocation_policies" {
+		t.Fatalf("diagnostics = %+v, want one Invalid UUID in invocation_policies error", resp.Diagnostics)
+	}
+}
+
+func TestPolicySetRuleKey(t *testing.T) {
+	empty := ""
+	a := policySetCondition{"path", "startsWith", "/etc/"}
+	b := policySetCondition{"mode", "equal", "w"}
+	rule := policySetRule{ToolID: uuid.MustParse(policySetToolA), Action: "block_always", Conditions: []policySetCondition{a, b}}
+	same := rule
+	same.Note = &empty
+	same.Conditions = []policySetCondition{b, a}
+	if rule.key() != same.key() {
+		t.Errorf("key differs for an empty note and reordered conditions:\n%s\n%s", rule.key(), same.key())
+	}
+	if rule.Conditions[0] != a {
+		t.Error("key reordered the rule's own conditions")
+	}
+	other := rule
+	other.Action = "require_approval"
+	if rule.key() == other.key() {
+		t.Error("key ignores the action")
+	}
+}
+
+func TestToolPolicySetUnknownConditionKey(t *testing.T) {
+	backend := newFixtureBackend(t, map[string]json.RawMessage{
+		"/api/tools": json.RawMessage(`[{"id":"` + policySetToolA + `","name":"filesystem__read_file","createdAt":"2026-10-01T00:00:00Z","updatedAt":"2026-10-01T00:00:00Z",` +
+			`"parameters":{"type":"object","properties":{"path":{"type":"string"}},"additionalProperties":false}}]`),
+	})
+	ps := newConfiguredProviderServer(t, backend.URL)
+	ctx := t.Context()
+	schemaResp, err := ps.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objType := schemaResp.ResourceSchemas["archestra_tool_policy_set"].ValueType().(tftypes.Object)
+	raw := `{"tool_ids":["` + policySetToolA + `"],"invocation_policies":[{"tool_id":"` + policySetToolA + `","action":"block_always",` +
+		`"conditions":[{"key":"path","operator":"startsWith","value":"/etc/"},{"key":"pth","operator":"equal","value":"/"}]}]}`
+	config := validatorConfig(t, objType, raw, nil)
+	proposed := validatorConfig(t, objType, raw, []string{"id"})
+	null, err := tfprotov6.NewDynamicValue(objType, tftypes.NewValue(objType, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := ps.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "archestra_tool_policy_set",
+		PriorState:       &null,
+		ProposedNewState: &proposed,
+		Config:           &config,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Unknown Condition Key" {
+		t.Fatalf("diagnostics = %+v, want one Unknown Condition Key error", resp.Diagnostics)
+	}
+	steps := resp.Diagnostics[0].Attribute.Steps()
+	if n := len(steps); n < 2 || steps[n-1] != tftypes.AttributeName("key") || steps[n-2] != tftypes.ElementKeyInt(1) {
+		t.Errorf("diagnostic at %s, want the second condition's key", resp.Diagnostics[0].Attribute)
+	}
+}
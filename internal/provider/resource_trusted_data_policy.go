@@ -23,13 +23,17 @@ import (
 
 var _ resource.Resource = &TrustedDataPolicyResource{}
 var _ resource.ResourceWithImportState = &TrustedDataPolicyResource{}
+var _ resource.ResourceWithIdentity = &TrustedDataPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &TrustedDataPolicyResource{}
+var _ resource.ResourceWithUpgradeState = &TrustedDataPolicyResource{}
 
 func NewTrustedDataPolicyResource() resource.Resource {
 	return &TrustedDataPolicyResource{}
 }
 
 type TrustedDataPolicyResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type TrustedDataPolicyResourceModel struct {
@@ -38,6 +42,10 @@ type TrustedDataPolicyResourceModel struct {
 	Description types.String           `tfsdk:"description"`
 	Conditions  []PolicyConditionModel `tfsdk:"conditions"`
 	Action      types.String           `tfsdk:"action"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
+	CreatedAt      types.String `tfsdk:"created_at"`
+	UpdatedAt      types.String `tfsdk:"updated_at"`
 }
 
 func (r *TrustedDataPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -46,6 +54,7 @@ func (r *TrustedDataPolicyResource) Metadata(ctx context.Context, req resource.M
 
 func (r *TrustedDataPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:             1,
 		MarkdownDescription: "Conditional trusted-data policy — fires `action` when ALL of `conditions` match the tool's *result*. `conditions` must be non-empty; for the unconditional default, use `archestra_trusted_data_policy_default`.",
 
 		Attributes: map[string]schema.Attribute{
@@ -73,6 +82,7 @@ func (r *TrustedDataPolicyResource) Schema(ctx context.Context, req resource.Sch
 				Required:            true,
 				Validators: []validator.List{
 					listvalidator.SizeAtLeast(1),
+					policyConditionsValidator(),
 				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -103,23 +113,36 @@ func (r *TrustedDataPolicyResource) Schema(ctx context.Context, req resource.Sch
 					stringvalidator.OneOf("mark_as_trusted", "mark_as_untrusted", "block_always", "sanitize_with_dual_llm"),
 				},
 			},
+			"organization_id": organizationIDSchemaAttribute(),
+			"created_at":      createdAtSchemaAttribute(),
+			"updated_at":      updatedAtSchemaAttribute(),
 		},
 	}
 }
 
+func (r *TrustedDataPolicyResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = idIdentitySchema("Trusted data policy identifier.")
+}
+
 func (r *TrustedDataPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T", req.ProviderData),
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData),
 		)
 		return
 	}
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *TrustedDataPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "policy"}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 func (r *TrustedDataPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -157,6 +180,8 @@ func (r *TrustedDataPolicyResource) Create(ctx context.Context, req resource.Cre
 	data.ID = types.StringValue(apiResp.JSON200.Id.String())
 	data.ToolID = types.StringValue(apiResp.JSON200.ToolId.String())
 	data.Action = types.StringValue(string(apiResp.JSON200.Action))
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 	if apiResp.JSON200.Description != nil {
 		data.Description = types.StringValue(*apiResp.JSON200.Description)
 	}
@@ -170,6 +195,7 @@ func (r *TrustedDataPolicyResource) Create(ctx context.Context, req resource.Cre
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *TrustedDataPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -179,6 +205,12 @@ func (r *TrustedDataPolicyResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	policyID, err := uuid.Parse(data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Invalid ID", fmt.Sprintf("Unable to parse policy ID: %s", err))
@@ -204,6 +236,8 @@ func (r *TrustedDataPolicyResource) Read(ctx context.Context, req resource.ReadR
 
 	data.ToolID = types.StringValue(apiResp.JSON200.ToolId.String())
 	data.Action = types.StringValue(string(apiResp.JSON200.Action))
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 	if apiResp.JSON200.Description != nil {
 		data.Description = types.StringValue(*apiResp.JSON200.Description)
 	} else {
@@ -219,6 +253,8 @@ func (r *TrustedDataPolicyResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *TrustedDataPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -240,6 +276,7 @@ func (r *TrustedDataPolicyResource) Update(ctx context.Context, req resource.Upd
 	}
 	if len(patch) == 0 {
 		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 		return
 	}
 	LogPatch(ctx, "archestra_trusted_data_policy Update", patch, trustedDataPolicyAttrSpec)
@@ -272,6 +309,8 @@ func (r *TrustedDataPolicyResource) Update(ctx context.Context, req resource.Upd
 
 	data.ToolID = types.StringValue(apiResp.JSON200.ToolId.String())
 	data.Action = types.StringValue(string(apiResp.JSON200.Action))
+	data.CreatedAt = timestampValue(apiResp.JSON200.CreatedAt)
+	data.UpdatedAt = timestampValue(apiResp.JSON200.UpdatedAt)
 	if apiResp.JSON200.Description != nil {
 		data.Description = types.StringValue(*apiResp.JSON200.Description)
 	} else {
@@ -287,6 +326,7 @@ func (r *TrustedDataPolicyResource) Update(ctx context.Context, req resource.Upd
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *TrustedDataPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -317,5 +357,5 @@ func (r *TrustedDataPolicyResource) Delete(ctx context.Context, req resource.Del
 }
 
 func (r *TrustedDataPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
 }
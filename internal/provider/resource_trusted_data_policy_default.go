@@ -20,7 +20,10 @@ import (
 
 var (
 	_ resource.Resource                = &TrustedDataPolicyDefaultResource{}
+	_ resource.ResourceWithIdentity    = &TrustedDataPolicyDefaultResource{}
 	_ resource.ResourceWithImportState = &TrustedDataPolicyDefaultResource{}
+	_ resource.ResourceWithModifyPlan  = &TrustedDataPolicyDefaultResource{}
+	_ resource.ResourceWithMoveState   = &TrustedDataPolicyDefaultResource{}
 )
 
 func NewTrustedDataPolicyDefaultResource() resource.Resource {
@@ -28,22 +31,28 @@ func NewTrustedDataPolicyDefaultResource() resource.Resource {
 }
 
 type TrustedDataPolicyDefaultResource struct {
-	client *client.ClientWithResponses
+	client       *client.ClientWithResponses
+	providerData *ArchestraProviderData
 }
 
 type TrustedDataPolicyDefaultResourceModel struct {
 	ID      types.String `tfsdk:"id"`
 	ToolIDs types.Set    `tfsdk:"tool_ids"`
 	Action  types.String `tfsdk:"action"`
+
+	OrganizationID types.String `tfsdk:"organization_id"`
 }
 
 func (r *TrustedDataPolicyDefaultResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_trusted_data_policy_default"
+	// Identity is the shared `action`, which an in-place Update may change.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 func (r *TrustedDataPolicyDefaultResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Sets the unconditional default trusted-data action for a set of tools (mark trusted / untrusted / sanitize / block). For conditional rules layered on top, use `archestra_trusted_data_policy`.",
+		MarkdownDescription: "Sets the unconditional default trusted-data action for a set of tools (mark trusted / untrusted / sanitize / block). For conditional rules layered on top, use `archestra_trusted_data_policy`.\n\n" +
+			"An `archestra_trusted_data_policy` without conditions (as written by provider versions before `conditions` existed) can be re-homed here with a `moved` block; it becomes a default for its one tool. This applies only to state from those versions: the current `archestra_trusted_data_policy` requires conditions, and a `moved` block from one is rejected.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -72,21 +81,40 @@ func (r *TrustedDataPolicyDefaultResource) Schema(_ context.Context, _ resource.
 					stringvalidator.OneOf("mark_as_trusted", "mark_as_untrusted", "block_always", "sanitize_with_dual_llm"),
 				},
 			},
+			"organization_id": organizationIDSchemaAttribute(),
 		},
 	}
 }
 
+func (r *TrustedDataPolicyDefaultResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = compositeIdentitySchema(map[string]string{
+		"action": "Default trusted-data action the managed tools share.",
+	})
+}
+
+func (r *TrustedDataPolicyDefaultResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		stateMoverFrom("archestra_trusted_data_policy", movePolicyToDefault("archestra_trusted_data_policy", upgradePolicyV0("attribute_path"))),
+	}
+}
+
 func (r *TrustedDataPolicyDefaultResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
-	c, ok := req.ProviderData.(*client.ClientWithResponses)
+	providerData, ok := req.ProviderData.(*ArchestraProviderData)
 	if !ok {
 		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.ClientWithResponses, got: %T", req.ProviderData))
+			fmt.Sprintf("Expected *ArchestraProviderData, got: %T", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *TrustedDataPolicyDefaultResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlannedPermissions(ctx, r.providerData, permissionRule{Resource: "policy", SubResource: true}, req, resp)
+	planOrganization(ctx, r.providerData, req, resp)
 }
 
 func (r *TrustedDataPolicyDefaultResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -105,6 +133,7 @@ func (r *TrustedDataPolicyDefaultResource) Create(ctx context.Context, req resou
 	}
 	plan.ID = types.StringValue(syntheticToolSetID(tools, plan.Action.ValueString()))
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 // Read reconciles state's `tool_ids` against the live trusted-data
@@ -118,6 +147,12 @@ func (r *TrustedDataPolicyDefaultResource) Read(ctx context.Context, req resourc
 		return
 	}
 
+	checkOrganization(ctx, r.providerData, req.State, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	syncIdentity(ctx, req.State, resp.Identity, &resp.Diagnostics)
+
 	stateTools := parseUUIDSet(ctx, state.ToolIDs, &resp.Diagnostics, "tool_ids")
 	if resp.Diagnostics.HasError() {
 		return
@@ -157,6 +192,8 @@ func (r *TrustedDataPolicyDefaultResource) Read(ctx context.Context, req resourc
 	}
 	state.ToolIDs = keptSet
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	stampOrganization(ctx, r.providerData, &resp.State, &resp.Diagnostics)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 func (r *TrustedDataPolicyDefaultResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -226,6 +263,7 @@ func (r *TrustedDataPolicyDefaultResource) Update(ctx context.Context, req resou
 	// resource_tool_invocation_policy_default.go.
 	plan.ID = state.ID
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	syncIdentity(ctx, resp.State, resp.Identity, &resp.Diagnostics)
 }
 
 // Delete removes the per-tool default trusted-data policy rows this
@@ -288,6 +326,13 @@ func (r *TrustedDataPolicyDefaultResource) Delete(ctx context.Context, req resou
 // listing policies and selecting those whose unconditional default
 // matches the imported action.
 func (r *TrustedDataPolicyDefaultResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Identity import names the action alone; the synthetic ID is then the
+	// bare action, which Import accepts as well.
+	if importFromIdentity(ctx, req, resp) {
+		var identityAction types.String
+		resp.Diagnostics.Append(req.Identity.GetAttribute(ctx, path.Root("action"), &identityAction)...)
+		req.ID = identityAction.ValueString()
+	}
 	action := req.ID
 	if i := strings.Index(action, ":"); i >= 0 {
 		action = action[:i]
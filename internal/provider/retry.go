@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -43,11 +44,17 @@ type RetryResult[T any] struct {
 // - If found is true, the value is returned immediately.
 // - If found is false and error is nil, the operation is retried.
 // - If error is non-nil, it's returned immediately without retrying.
+//
+// When ctx carries a deadline (a resource `timeouts` block, see
+// timeoutContext) it is the only stop condition and MaxRetries is ignored:
+// attempts continue, the last wait cut short to fit, until the deadline
+// passes, and the result is then not-found.
 func RetryUntilFound[T any](ctx context.Context, config RetryConfig, operation func() (T, bool, error)) (T, bool, error) {
 	var zero T
 	backoff := config.InitialBackoff
+	deadline, hasDeadline := ctx.Deadline()
 
-	for attempt := 0; attempt < config.MaxRetries; attempt++ {
+	for attempt := 0; ; attempt++ {
 		value, found, err := operation()
 		if err != nil {
 			return zero, false, err
@@ -57,24 +64,40 @@ func RetryUntilFound[T any](ctx context.Context, config RetryConfig, operation f
 		}
 
 		// Not found, wait and retry
-		if attempt < config.MaxRetries-1 {
+		wait := backoff
+		if hasDeadline {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return zero, false, nil
+			}
+			wait = min(wait, remaining)
+			tflog.Debug(ctx, fmt.Sprintf("%s not yet available, retrying in %v (attempt %d, until %s)",
+				config.Description, wait, attempt+1, deadline.Format(time.RFC3339)))
+		} else {
+			if attempt >= config.MaxRetries-1 {
+				return zero, false, nil
+			}
 			tflog.Debug(ctx, fmt.Sprintf("%s not yet available, retrying in %v (attempt %d/%d)",
-				config.Description, backoff, attempt+1, config.MaxRetries))
+				config.Description, wait, attempt+1, config.MaxRetries))
+		}
 
-			select {
-			case <-ctx.Done():
-				return zero, false, ctx.Err()
-			case <-time.After(backoff):
-				// Continue with next attempt
+		select {
+		case <-ctx.Done():
+			if hasDeadline && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return zero, false, nil
 			}
+			return zero, false, ctx.Err()
+		case <-time.After(wait):
+			// Continue with next attempt
+		}
+		if hasDeadline && ctx.Err() != nil {
+			return zero, false, nil
+		}
 
-			// Exponential backoff with cap
-			backoff *= 2
-			if backoff > config.MaxBackoff {
-				backoff = config.MaxBackoff
-			}
+		// Exponential backoff with cap
+		backoff *= 2
+		if backoff > config.MaxBackoff {
+			backoff = config.MaxBackoff
 		}
 	}
-
-	return zero, false, nil
 }
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRetryUntilFound(t *testing.T) {
+	t.Parallel()
+
+	config := RetryConfig{
+		MaxRetries:     3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Description:    "thing",
+	}
+
+	t.Run("found on a later attempt", func(t *testing.T) {
+		t.Parallel()
+		calls := 0
+		got, found, err := RetryUntilFound(t.Context(), config, func() (int, bool, error) {
+			calls++
+			return calls, calls == 2, nil
+		})
+		if err != nil || !found || got != 2 {
+			t.Errorf("got (%d, %v, %v), want (2, true, nil)", got, found, err)
+		}
+	})
+
+	t.Run("gives up after MaxRetries without a deadline", func(t *testing.T) {
+		t.Parallel()
+		calls := 0
+		_, found, err := RetryUntilFound(t.Context(), config, func() (int, bool, error) {
+			calls++
+			return 0, false, nil
+		})
+		if err != nil || found {
+			t.Errorf("got (%v, %v), want (false, nil)", found, err)
+		}
+		if calls != config.MaxRetries {
+			t.Errorf("calls = %d, want %d", calls, config.MaxRetries)
+		}
+	})
+
+	t.Run("context deadline replaces MaxRetries", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+		defer cancel()
+		calls := 0
+		_, found, err := RetryUntilFound(ctx, config, func() (int, bool, error) {
+			calls++
+			return calls, calls == 2*config.MaxRetries, nil
+		})
+		if err != nil || !found {
+			t.Errorf("got (%v, %v), want (true, nil)", found, err)
+		}
+	})
+
+	t.Run("MaxRetries=0 retries until found under a deadline", func(t *testing.T) {
+		t.Parallel()
+		none := config
+		none.MaxRetries = 0
+		ctx, cancel := context.WithTimeout(t.Context(), time.Minute)
+		defer cancel()
+		calls := 0
+		_, found, err := RetryUntilFound(ctx, none, func() (int, bool, error) {
+			calls++
+			return calls, calls == 5, nil
+		})
+		if err != nil || !found || calls != 5 {
+			t.Errorf("got (%v, %v) after %d calls, want (true, nil) after 5", found, err, calls)
+		}
+	})
+
+	t.Run("waits out the deadline when the backoff overruns it", func(t *testing.T) {
+		t.Parallel()
+		slow := config
+		slow.MaxRetries = 0
+		slow.InitialBackoff = time.Minute
+		slow.MaxBackoff = time.Minute
+		ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, found, err := RetryUntilFound(ctx, slow, func() (int, bool, error) {
+			return 0, false, nil
+		})
+		if err != nil || found {
+			t.Errorf("got (%v, %v), want (false, nil)", found, err)
+		}
+		if elapsed := time.Since(start); elapsed < 200*time.Millisecond || elapsed > 10*time.Second {
+			t.Errorf("returned after %v; expected at the 200ms deadline", elapsed)
+		}
+	})
+}
@@ -259,10 +259,15 @@ func syntheticAttrSpecTops(specs []AttrSpec) map[string]struct{} {
 
 // nestedSensitiveAttrSpecPaths returns dotted paths of every nested
 // AttrSpec entry marked `Sensitive: true`. Top-level entries are NOT
-// included — those are handled by attrSpecSensitiveTFNames.
+// included — those are handled by attrSpecSensitiveTFNames. Synthetic
+// subtrees are skipped, mirroring nestedSensitiveSchemaPaths: their
+// children only describe write-only attributes for injectWriteOnly.
 func nestedSensitiveAttrSpecPaths(specs []AttrSpec) []string {
 	var out []string
 	for _, s := range specs {
+		if s.Kind == Synthetic {
+			continue
+		}
 		walkAttrSpecForSensitive(s.TFName, s.Children, &out)
 	}
 	sort.Strings(out)
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// A resource with a prior schema version implements UpgradeState through
+// stateUpgraders, so reshaping a schema is a matter of bumping its Version
+// and appending one step; the first reshape of a resource also adds the
+// method. Steps rewrite the raw JSON state rather than decoding it against a
+// PriorSchema: old attributes are one map lookup away, and no frozen copy of
+// the previous schema has to be kept alive in the tree. Terraform calls the
+// upgrader for the stored version once, so the upgrader for version N runs
+// steps N..Version-1 back to back. The result is decoded against the current
+// schema with undefined attributes ignored: attributes a step leaves behind
+// are dropped, and attributes no step sets come out null.
+//
+// TestStateUpgraders checks that each resource's upgraders cover every
+// version below its schema Version, and that resources without them are
+// still on version 0; TestStateUpgradeFixtures replays the
+// recorded prior-version states in testdata/state_upgrades through the
+// upgrade and a Read against a fake backend.
+
+// stateUpgradeStep rewrites the raw state of schema version N into version
+// N+1 in place.
+type stateUpgradeStep func(state map[string]any) error
+
+// stateUpgraders builds a resource's UpgradeState map from its steps, where
+// steps[N] upgrades version N to N+1. The schema Version must equal
+// len(steps).
+func stateUpgraders(steps ...stateUpgradeStep) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader, len(steps))
+	for version := range steps {
+		pending := steps[version:]
+		upgraders[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				if req.RawState == nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", "Terraform sent no prior state to upgrade.")
+					return
+				}
+
+				var state map[string]any
+				if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Prior state (schema version %d) is not a JSON object: %s", version, err),
+					)
+					return
+				}
+				for i, step := range pending {
+					if err := step(state); err != nil {
+						resp.Diagnostics.AddError(
+							"Unable to Upgrade Resource State",
+							fmt.Sprintf("Upgrading state from schema version %d to %d failed: %s", version+i, version+i+1, err),
+						)
+						return
+					}
+				}
+
+				upgraded, err := json.Marshal(state)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+					return
+				}
+				raw, err := (&tfprotov6.RawState{JSON: upgraded}).UnmarshalWithOpts(
+					resp.State.Schema.Type().TerraformType(ctx),
+					tfprotov6.UnmarshalOpts{ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true}},
+				)
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Upgraded state does not match the current schema: %s", err),
+					)
+					return
+				}
+				resp.State.Raw = raw
+			},
+		}
+	}
+	return upgraders
+}
+
+// renameStateAttribute moves state[from] to state[to] unless to is already
+// set, which happens when the state was written after the rename but before
+// the schema Version was bumped.
+func renameStateAttribute(state map[string]any, from, to string) {
+	v, ok := state[from]
+	if !ok {
+		return
+	}
+	delete(state, from)
+	if state[to] == nil {
+		state[to] = v
+	}
+}
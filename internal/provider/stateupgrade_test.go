@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestStateUpgraders pins the scaffold: a resource past version 0
+// implements UpgradeState, with an upgrader for each version below its
+// schema Version and none at or above it.
+func TestStateUpgraders(t *testing.T) {
+	ctx := t.Context()
+	p := New("test")()
+	for _, newResource := range p.Resources(ctx) {
+		r := newResource()
+		var meta resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "archestra"}, &meta)
+		t.Run(meta.TypeName, func(t *testing.T) {
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			version := schemaResp.Schema.Version
+
+			upgradable, ok := r.(resource.ResourceWithUpgradeState)
+			if !ok {
+				if version != 0 {
+					t.Errorf("schema Version is %d but the resource does not implement resource.ResourceWithUpgradeState", version)
+				}
+				return
+			}
+
+			upgraders := upgradable.UpgradeState(ctx)
+			if int64(len(upgraders)) != version {
+				t.Errorf("schema Version is %d but there are %d upgraders", version, len(upgraders))
+			}
+			for v := int64(0); v < version; v++ {
+				if _, ok := upgraders[v]; !ok {
+					t.Errorf("no upgrader for version %d", v)
+				}
+			}
+		})
+	}
+}
+
+func TestUpgradePolicyV0(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "scalar condition",
+			state: `{"id":"p","profile_tool_id":"t","argument_name":"path","operator":"contains","value":"/etc","action":"block_always"}`,
+			want:  `{"id":"p","tool_id":"t","conditions":[{"key":"path","operator":"contains","value":"/etc"}],"action":"block_always"}`,
+		},
+		{
+			name:  "already redesigned",
+			state: `{"id":"p","tool_id":"t","conditions":[{"key":"a","operator":"equal","value":"b"}],"action":"block_always"}`,
+			want:  `{"id":"p","tool_id":"t","conditions":[{"key":"a","operator":"equal","value":"b"}],"action":"block_always"}`,
+		},
+		{
+			name:  "null scalar condition",
+			state: `{"id":"p","profile_tool_id":"t","argument_name":null,"operator":null,"value":null,"action":"block_always"}`,
+			want:  `{"id":"p","tool_id":"t","action":"block_always"}`,
+		},
+		{
+			name:    "operator missing",
+			state:   `{"id":"p","tool_id":"t","argument_name":"path","value":"/etc"}`,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var state map[string]any
+			if err := json.Unmarshal([]byte(tc.state), &state); err != nil {
+				t.Fatal(err)
+			}
+			err := upgradePolicyV0("argument_name")(state)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got state %v", state)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, _ := json.Marshal(state)
+			if !jsonEqual(t, got, []byte(tc.want)) {
+				t.Errorf("got %s\nwant %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUpgradeCatalogItemEnvironmentV0(t *testing.T) {
+	var state map[string]any
+	if err := json.Unmarshal([]byte(`{"local_config":{"command":"npx","environment":{"B":"2","A":"1"},"mounted_env_keys":["B"]}}`), &state); err != nil {
+		t.Fatal(err)
+	}
+	if err := upgradeCatalogItemEnvironmentV0(state); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ := json.Marshal(state)
+	want := `{"local_config":{"command":"npx","environment":[` +
+		`{"key":"A","type":"plain_text","value":"1","prompt_on_installation":false},` +
+		`{"key":"B","type":"plain_text","value":"2","prompt_on_installation":false,"mounted":true}]}}`
+	if !jsonEqual(t, got, []byte(want)) {
+		t.Errorf("got %s\nwant %s", got, want)
+	}
+}
+
+// stateUpgradeFixture is one recorded prior-version state under
+// testdata/state_upgrades/<resource type>/. Backend maps request paths to the
+// JSON the fake API answers GETs with; Want is the expected state after the
+// upgrade, with null attributes omitted.
+type stateUpgradeFixture struct {
+	Version int64                      `json:"version"`
+	State   json.RawMessage            `json:"state"`
+	Backend map[string]json.RawMessage `json:"backend"`
+	Want    json.RawMessage            `json:"want"`
+}
+
+// TestStateUpgradeFixtures upgrades each recorded state through the
+// provider server and refreshes the result against a fake backend serving
+// the same object. The upgraded state must match the fixture and survive
+// Read unchanged: a difference means the upgrade would show up as drift on
+// the first plan after upgrading the provider. The computed-only audit
+// timestamps are exempt: no prior version recorded them, and Read fills them
+// in without producing a diff.
+func TestStateUpgradeFixtures(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "state_upgrades", "*", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no fixtures under testdata/state_upgrades")
+	}
+
+	for _, path := range paths {
+		typeName := filepath.Base(filepath.Dir(path))
+		t.Run(typeName+"/"+strings.TrimSuffix(filepath.Base(path), ".json"), func(t *testing.T) {
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fixture stateUpgradeFixture
+			if err := json.Unmarshal(raw, &fixture); err != nil {
+				t.Fatalf("parse fixture: %v", err)
+			}
+
+			server := newFixtureBackend(t, fixture.Backend)
+			ps := newConfiguredProviderServer(t, server.URL)
+			ctx := t.Context()
+
+			schemaResp, err := ps.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			resourceSchema, ok := schemaResp.ResourceSchemas[typeName]
+			if !ok {
+				t.Fatalf("provider has no resource %q", typeName)
+			}
+			if fixture.Version >= resourceSchema.Version {
+				t.Fatalf("fixture version %d is not below schema version %d", fixture.Version, resourceSchema.Version)
+			}
+			stateType := resourceSchema.ValueType()
+
+			upgradeResp, err := ps.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: typeName,
+				Version:  fixture.Version,
+				RawState: &tfprotov6.RawState{JSON: fixture.State},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			failOnDiagnostics(t, "UpgradeResourceState", upgradeResp.Diagnostics)
+			upgraded, err := upgradeResp.UpgradedState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want, err := (&tfprotov6.RawState{JSON: fixture.Want}).Unmarshal(stateType)
+			if err != nil {
+				t.Fatalf("fixture want does not match the schema: %v", err)
+			}
+			assertStateEqual(t, "upgraded state", upgraded, want)
+
+			readResp, err := ps.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+				TypeName:     typeName,
+				CurrentState: upgradeResp.UpgradedState,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			failOnDiagnostics(t, "ReadResource", readResp.Diagnostics)
+			refreshed, err := readResp.NewState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertStateEqual(t, "state after Read",
+				withoutAuditTimestamps(t, refreshed),
+				withoutAuditTimestamps(t, upgraded))
+		})
+	}
+}
+
+// newFixtureBackend answers GETs on the fixture's paths and 404s the rest.
+func newFixtureBackend(t *testing.T, routes map[string]json.RawMessage) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if r.Method != http.MethodGet || !ok {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newConfiguredProviderServer serves the provider over protocol 6 and
+// configures it against baseURL with the credential handshake skipped.
+func newConfiguredProviderServer(t *testing.T, baseURL string) tfprotov6.ProviderServer {
+	t.Helper()
+	ps, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"base_url":                    tftypes.String,
+		"api_key":                     tftypes.String,
+		"skip_credentials_validation": tftypes.Bool,
+		"default_labels":              tftypes.Map{ElementType: tftypes.String},
+		"organization_id":             tftypes.String,
+	}}
+	config, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, map[string]tftypes.Value{
+		"base_url":                    tftypes.NewValue(tftypes.String, baseURL),
+		"api_key":                     tftypes.NewValue(tftypes.String, "arch_test"),
+		"skip_credentials_validation": tftypes.NewValue(tftypes.Bool, true),
+		"default_labels":              tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+		"organization_id":             tftypes.NewValue(tftypes.String, nil),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := ps.ConfigureProvider(t.Context(), &tfprotov6.ConfigureProviderRequest{Config: &config})
+	if err != nil {
+		t.Fatal(err)
+	}
+	failOnDiagnostics(t, "ConfigureProvider", resp.Diagnostics)
+	return ps
+}
+
+func failOnDiagnostics(t *testing.T, rpc string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s: %s: %s", rpc, d.Summary, d.Detail)
+		}
+	}
+}
+
+func assertStateEqual(t *testing.T, what string, got, want tftypes.Value) {
+	t.Helper()
+	if got.Equal(want) {
+		return
+	}
+	diffs, err := got.Diff(want)
+	if err != nil {
+		t.Fatalf("%s differs and cannot be diffed: %v", what, err)
+	}
+	for _, d := range diffs {
+		t.Errorf("%s: %s: got %v, want %v", what, d.Path, d.Value1, d.Value2)
+	}
+}
+
+// withoutAuditTimestamps nulls the top-level created_at and updated_at
+// attributes of v.
+func withoutAuditTimestamps(t *testing.T, v tftypes.Value) tftypes.Value {
+	t.Helper()
+	out, err := tftypes.Transform(v, func(p *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		steps := p.Steps()
+		if len(steps) != 1 {
+			return v, nil
+		}
+		if name, ok := steps[0].(tftypes.AttributeName); ok && (name == "created_at" || name == "updated_at") {
+			return tftypes.NewValue(v.Type(), nil), nil
+		}
+		return v, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var va, vb any
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatal(err)
+	}
+	ga, _ := json.Marshal(va)
+	gb, _ := json.Marshal(vb)
+	return string(ga) == string(gb)
+}
@@ -21,11 +21,9 @@ func (r *TeamResource) AttrSpecs() []AttrSpec { return teamAttrSpec }
 
 func (r *TeamResource) APIShape() any { return client.GetTeamResponse{} }
 
-// KnownIntentionallySkipped: createdAt/updatedAt are audit timestamps;
-// could be exposed as Computed-only later if users ask. organizationId /
-// createdBy are top-level computed-only fields already covered via the
-// schema (organizationId↔organization_id, createdBy↔created_by — the
-// snake-case roundtrip works directly).
+// KnownIntentionallySkipped: empty. organizationId, createdBy and the
+// createdAt/updatedAt audit timestamps are computed-only attributes
+// covered via the schema (the snake-case roundtrip works directly).
 func (r *TeamResource) KnownIntentionallySkipped() []string {
-	return []string{"createdAt", "updatedAt"}
+	return nil
 }
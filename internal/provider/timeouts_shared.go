@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// Long-running resources (MCP server installation, catalog items, agent tool
+// assignment, policy auto-config) expose the standard `timeouts` block. A
+// configured timeout becomes a context deadline for the whole operation:
+// waitForServerTools and RetryUntilFound wait until that deadline instead of
+// their built-in budgets, and the HTTP transport lets each request run until
+// it instead of ARCHESTRA_HTTP_TIMEOUT (see deadlineTransport). An unset
+// timeout leaves the context untouched, so behaviour without a block is
+// unchanged.
+
+// timeoutsBlock is the `timeouts` block schema. Resources whose inputs all
+// force replacement pass update=false; their Update only persists a
+// changed `timeouts` block.
+func timeoutsBlock(ctx context.Context, update bool) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Read:   true,
+		Update: update,
+		Delete: true,
+	})
+}
+
+// timeoutContext bounds ctx by the timeout that get (one of the
+// timeouts.Value accessors, e.g. plan.Timeouts.Create) returns. Without a
+// configured timeout ctx is returned as is. The caller must call cancel.
+func timeoutContext(
+	ctx context.Context,
+	get func(context.Context, time.Duration) (time.Duration, diag.Diagnostics),
+	diags *diag.Diagnostics,
+) (context.Context, context.CancelFunc) {
+	timeout, d := get(ctx, 0)
+	diags.Append(d...)
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimeoutContext(t *testing.T) {
+	t.Parallel()
+
+	attrTypes := map[string]attr.Type{"create": types.StringType}
+	value := func(create attr.Value) timeouts.Value {
+		return timeouts.Value{Object: types.ObjectValueMust(attrTypes, map[string]attr.Value{"create": create})}
+	}
+
+	t.Run("unset leaves the context alone", func(t *testing.T) {
+		t.Parallel()
+		var diags diag.Diagnostics
+		ctx, cancel := timeoutContext(t.Context(), value(types.StringNull()).Create, &diags)
+		defer cancel()
+		if _, ok := ctx.Deadline(); ok || diags.HasError() {
+			t.Errorf("deadline set = %v, diags = %v; want no deadline", ok, diags)
+		}
+	})
+
+	t.Run("configured timeout sets a deadline", func(t *testing.T) {
+		t.Parallel()
+		var diags diag.Diagnostics
+		ctx, cancel := timeoutContext(t.Context(), value(types.StringValue("45m")).Create, &diags)
+		defer cancel()
+		deadline, ok := ctx.Deadline()
+		if !ok || diags.HasError() {
+			t.Fatalf("deadline set = %v, diags = %v; want a deadline", ok, diags)
+		}
+		if left := time.Until(deadline); left < 44*time.Minute || left > 45*time.Minute {
+			t.Errorf("deadline in %v, want ~45m", left)
+		}
+	})
+
+	t.Run("block absent from state", func(t *testing.T) {
+		t.Parallel()
+		var diags diag.Diagnostics
+		ctx, cancel := timeoutContext(t.Context(), timeouts.Value{}.Read, &diags)
+		defer cancel()
+		if _, ok := ctx.Deadline(); ok || diags.HasError() {
+			t.Errorf("deadline set = %v, diags = %v; want no deadline", ok, diags)
+		}
+	})
+}
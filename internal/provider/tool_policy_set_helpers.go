@@ -0,0 +1,331 @@
+package provider
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/archestra-ai/archestra/terraform-provider-archestra/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// archestra_tool_policy_set owns every row of both policy tables whose tool
+// is in its `tool_ids`. Rows carry no marker of who wrote them, so the set
+// compares rows by content rather than by ID: a backend row matching no
+// configured rule is one somebody added out-of-band, and apply deletes it.
+// Both tables share a wire shape, so the comparison runs over
+// policySetRule and a policySetTable adapter per table.
+
+// policySetRule is one conditional rule of either table. Note carries the
+// invocation `reason` or the trusted-data `description`.
+type policySetRule struct {
+	ToolID     openapi_types.UUID   `json:"toolId"`
+	Conditions []policySetCondition `json:"conditions"`
+	Action     string               `json:"action"`
+	Note       *string              `json:"note"`
+}
+
+type policySetCondition struct {
+	Key      string `json:"key"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// key identifies a rule by content. The backend returns an empty note for
+// one never set and may reorder conditions, so neither difference is part
+// of the key: an empty note counts as none, and conditions are sorted by
+// (key, operator, value).
+func (p policySetRule) key() string {
+	if p.Note != nil && *p.Note == "" {
+		p.Note = nil
+	}
+	p.Conditions = slices.Clone(p.Conditions)
+	slices.SortFunc(p.Conditions, func(a, b policySetCondition) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(a.Operator, b.Operator), cmp.Compare(a.Value, b.Value))
+	})
+	b, _ := json.Marshal(p)
+	return string(b)
+}
+
+// policySetRow is a backend row. Rows without conditions are the per-tool
+// defaults the bulk-upsert endpoints write.
+type policySetRow struct {
+	ID openapi_types.UUID
+	policySetRule
+}
+
+// policySetTable adapts one policy table's endpoints to the shape
+// reconcilePolicySetTable works with.
+type policySetTable struct {
+	name          string
+	noteField     string
+	list          func(ctx context.Context) ([]policySetRow, error)
+	create        func(ctx context.Context, body []byte) (status int, respBody []byte, err error)
+	delete        func(ctx context.Context, id openapi_types.UUID) (status int, respBody []byte, ok bool, err error)
+	upsertDefault func(ctx context.Context, tools []openapi_types.UUID, action string) error
+}
+
+func invocationPolicyTable(c *client.ClientWithResponses) policySetTable {
+	return policySetTable{
+		name:      "tool invocation policy",
+		noteField: "reason",
+		list: func(ctx context.Context) ([]policySetRow, error) {
+			apiResp, err := c.GetToolInvocationPoliciesWithResponse(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("unable to list tool invocation policies: %w", err)
+			}
+			if apiResp.JSON200 == nil {
+				return nil, fmt.Errorf("list tool invocation policies returned status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+			}
+			rows := make([]policySetRow, 0, len(*apiResp.JSON200))
+			for _, p := range *apiResp.JSON200 {
+				row := policySetRow{ID: p.Id, policySetRule: policySetRule{ToolID: p.ToolId, Action: string(p.Action), Note: p.Reason}}
+				for _, cond := range p.Conditions {
+					row.Conditions = append(row.Conditions, policySetCondition{Key: cond.Key, Operator: string(cond.Operator), Value: cond.Value})
+				}
+				rows = append(rows, row)
+			}
+			return rows, nil
+		},
+		create: func(ctx context.Context, body []byte) (int, []byte, error) {
+			apiResp, err := c.CreateToolInvocationPolicyWithBodyWithResponse(ctx, "application/json", bytes.NewReader(body))
+			if err != nil {
+				return 0, nil, err
+			}
+			return apiResp.StatusCode(), apiResp.Body, nil
+		},
+		delete: func(ctx context.Context, id openapi_types.UUID) (int, []byte, bool, error) {
+			apiResp, err := c.DeleteToolInvocationPolicyWithResponse(ctx, id)
+			if err != nil {
+				return 0, nil, false, err
+			}
+			return apiResp.StatusCode(), apiResp.Body, apiResp.JSON200 != nil, nil
+		},
+		upsertDefault: func(ctx context.Context, tools []openapi_types.UUID, action string) error {
+			apiResp, err := c.BulkUpsertDefaultCallPolicyWithResponse(ctx, client.BulkUpsertDefaultCallPolicyJSONRequestBody{
+				Action:  client.BulkUpsertDefaultCallPolicyJSONBodyAction(action),
+				ToolIds: tools,
+			})
+			if err != nil {
+				return err
+			}
+			if apiResp.JSON200 == nil {
+				return fmt.Errorf("bulk-upsert-default-call-policy returned status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+			}
+			return nil
+		},
+	}
+}
+
+func trustedDataPolicyTable(c *client.ClientWithResponses) policySetTable {
+	return policySetTable{
+		name:      "trusted data policy",
+		noteField: "description",
+		list: func(ctx context.Context) ([]policySetRow, error) {
+			apiResp, err := c.GetTrustedDataPoliciesWithResponse(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("unable to list trusted data policies: %w", err)
+			}
+			if apiResp.JSON200 == nil {
+				return nil, fmt.Errorf("list trusted data policies returned status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+			}
+			rows := make([]policySetRow, 0, len(*apiResp.JSON200))
+			for _, p := range *apiResp.JSON200 {
+				row := policySetRow{ID: p.Id, policySetRule: policySetRule{ToolID: p.ToolId, Action: string(p.Action), Note: p.Description}}
+				for _, cond := range p.Conditions {
+					row.Conditions = append(row.Conditions, policySetCondition{Key: cond.Key, Operator: string(cond.Operator), Value: cond.Value})
+				}
+				rows = append(rows, row)
+			}
+			return rows, nil
+		},
+		create: func(ctx context.Context, body []byte) (int, []byte, error) {
+			apiResp, err := c.CreateTrustedDataPolicyWithBodyWithResponse(ctx, "application/json", bytes.NewReader(body))
+			if err != nil {
+				return 0, nil, err
+			}
+			return apiResp.StatusCode(), apiResp.Body, nil
+		},
+		delete: func(ctx context.Context, id openapi_types.UUID) (int, []byte, bool, error) {
+			apiResp, err := c.DeleteTrustedDataPolicyWithResponse(ctx, id)
+			if err != nil {
+				return 0, nil, false, err
+			}
+			return apiResp.StatusCode(), apiResp.Body, apiResp.JSON200 != nil, nil
+		},
+		upsertDefault: func(ctx context.Context, tools []openapi_types.UUID, action string) error {
+			apiResp, err := c.BulkUpsertDefaultResultPolicyWithResponse(ctx, client.BulkUpsertDefaultResultPolicyJSONRequestBody{
+				Action:  client.BulkUpsertDefaultResultPolicyJSONBodyAction(action),
+				ToolIds: tools,
+			})
+			if err != nil {
+				return err
+			}
+			if apiResp.JSON200 == nil {
+				return fmt.Errorf("bulk-upsert-default-result-policy returned status %d: %s", apiResp.StatusCode(), string(apiResp.Body))
+			}
+			return nil
+		},
+	}
+}
+
+// reconcilePolicySetTable makes the rows of table whose tool is in owned
+// match want plus, when defaultAction is non-empty, one unconditional row
+// per tool in tools. owned is the union of the prior and planned tool sets,
+// so tools leaving the set lose every row. Missing rules are created before
+// stale rows are deleted: a tool briefly carries both rather than neither.
+func reconcilePolicySetTable(ctx context.Context, table policySetTable, owned map[openapi_types.UUID]struct{}, tools []openapi_types.UUID, want []policySetRule, defaultAction string) error {
+	rows, err := table.list(ctx)
+	if err != nil {
+		return err
+	}
+
+	planned := make(map[openapi_types.UUID]struct{}, len(tools))
+	for _, t := range tools {
+		planned[t] = struct{}{}
+	}
+	existing := map[string][]policySetRow{}
+	var stale []openapi_types.UUID
+	for _, row := range rows {
+		if _, ok := owned[row.ToolID]; !ok {
+			continue
+		}
+		if len(row.Conditions) == 0 {
+			// The bulk upsert below overwrites the default of every
+			// planned tool in place; only the rest go.
+			if _, ok := planned[row.ToolID]; !ok || defaultAction == "" {
+				stale = append(stale, row.ID)
+			}
+			continue
+		}
+		existing[row.key()] = append(existing[row.key()], row)
+	}
+
+	for _, rule := range want {
+		if matches := existing[rule.key()]; len(matches) > 0 {
+			existing[rule.key()] = matches[1:]
+			continue
+		}
+		payload := map[string]any{
+			"toolId":     rule.ToolID,
+			"conditions": rule.Conditions,
+			"action":     rule.Action,
+		}
+		if rule.Note != nil {
+			payload[table.noteField] = *rule.Note
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("unable to marshal %s: %w", table.name, err)
+		}
+		status, respBody, err := table.create(ctx, body)
+		if err != nil {
+			return fmt.Errorf("unable to create %s for tool %s: %w", table.name, rule.ToolID, err)
+		}
+		if status != 200 {
+			return fmt.Errorf("create %s for tool %s returned status %d: %s", table.name, rule.ToolID, status, string(respBody))
+		}
+	}
+	// Whatever is left matched no configured rule, including duplicates of
+	// one that did.
+	for _, matches := range existing {
+		for _, row := range matches {
+			stale = append(stale, row.ID)
+		}
+	}
+
+	if defaultAction != "" && len(tools) > 0 {
+		if err := table.upsertDefault(ctx, tools, defaultAction); err != nil {
+			return fmt.Errorf("unable to set default %s: %w", table.name, err)
+		}
+	}
+
+	for _, id := range stale {
+		status, respBody, ok, err := table.delete(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to delete %s %s: %w", table.name, id, err)
+		}
+		// Tolerate 404 — row already gone.
+		if !ok && status != 404 {
+			return fmt.Errorf("delete %s %s returned status %d: %s", table.name, id, status, string(respBody))
+		}
+	}
+	return nil
+}
+
+// readPolicySetTable returns the conditional rules of table for tools, and
+// the default action they share (null when no tool has one). A backend row
+// matching a rule of prior by key is returned as written in prior, so an
+// empty note or reordered conditions don't show up as a diff.
+// defaultsDiffer reports that some tools lack a default or they disagree;
+// the default is null then, so a configured one is re-asserted by the next
+// apply.
+func readPolicySetTable(ctx context.Context, table policySetTable, tools []openapi_types.UUID, prior []policySetRule) (rules []policySetRule, defaultAction types.String, defaultsDiffer bool, err error) {
+	rows, err := table.list(ctx)
+	if err != nil {
+		return nil, types.StringNull(), false, err
+	}
+	managed := make(map[openapi_types.UUID]struct{}, len(tools))
+	for _, t := range tools {
+		managed[t] = struct{}{}
+	}
+	asWritten := make(map[string]policySetRule, len(prior))
+	for _, rule := range prior {
+		asWritten[rule.key()] = rule
+	}
+
+	seen := map[string]struct{}{}
+	defaults := map[openapi_types.UUID]string{}
+	for _, row := range rows {
+		if _, ok := managed[row.ToolID]; !ok {
+			continue
+		}
+		if len(row.Conditions) == 0 {
+			defaults[row.ToolID] = row.Action
+			continue
+		}
+		// Duplicate rows collapse into one set element; apply deletes the
+		// extras whenever it next runs.
+		key := row.key()
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		if rule, ok := asWritten[key]; ok {
+			rules = append(rules, rule)
+			continue
+		}
+		rules = append(rules, row.policySetRule)
+	}
+
+	if len(defaults) == 0 {
+		return rules, types.StringNull(), false, nil
+	}
+	shared := defaults[tools[0]]
+	for _, t := range tools {
+		if action, ok := defaults[t]; !ok || action != shared {
+			return rules, types.StringNull(), true, nil
+		}
+	}
+	return rules, types.StringValue(shared), false, nil
+}
+
+func policySetConditionsFromModel(conditions []PolicyConditionModel) []policySetCondition {
+	out := make([]policySetCondition, len(conditions))
+	for i, c := range conditions {
+		out[i] = policySetCondition{Key: c.Key.ValueString(), Operator: c.Operator.ValueString(), Value: c.Value.ValueString()}
+	}
+	return out
+}
+
+func policySetConditionsToModel(conditions []policySetCondition) []PolicyConditionModel {
+	out := make([]PolicyConditionModel, len(conditions))
+	for i, c := range conditions {
+		out[i] = PolicyConditionModel{Key: types.StringValue(c.Key), Operator: types.StringValue(c.Operator), Value: types.StringValue(c.Value)}
+	}
+	return out
+}
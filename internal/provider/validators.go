@@ -23,8 +23,7 @@ var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{
 // enforces "must be an object".
 type jsonObject struct{}
 
-// jsonObjectValidator returns the jsonObject validator. Kept package-internal
-// because the only consumer is resource_identity_provider.go.
+// jsonObjectValidator returns the jsonObject validator.
 func jsonObjectValidator() validator.String {
 	return jsonObject{}
 }
@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Secrets that must never reach state get a write-only `<name>_wo` variant
+// next to the Sensitive `<name>`, plus a `<name>_wo_version` number the user
+// bumps to rotate. Terraform (1.11+) keeps write-only values out of plan and
+// state entirely — they only exist in config during the apply — so the
+// merge-patch diff cannot see them on its own.
+//
+// injectWriteOnly bridges that: before MergePatch it copies each write-only
+// value from config into the plan, and into the prior state as well when the
+// version did not change. A version bump therefore shows up as a diff and the
+// secret is sent; an unchanged version leaves plan and prior equal and the
+// secret stays home — unless an AtomicObject holding it is re-sent anyway for
+// another field, in which case the object goes out whole, secret included,
+// exactly as with the Sensitive attribute.
+//
+// Read never sets a write-only attribute. Where the backend echoes the
+// secret (OIDC and catalog OAuth client secrets), Read keeps the plain
+// attribute null while `<name>_wo_version` is set, so the echo does not
+// surface as drift.
+
+// writeOnlyAttribute is the schema of `<secret>_wo`. path.MatchRelative
+// keeps the built-in validators inside the enclosing object, so the same
+// helper serves nested secrets; extra validators are appended.
+func writeOnlyAttribute(secret, description string, extra ...validator.String) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: fmt.Sprintf("%s Write-only variant of `%s`: never stored in state. Requires Terraform 1.11+ and `%s_wo_version`; bump the version to send a new value.",
+			description, secret, secret),
+		Optional:  true,
+		Sensitive: true,
+		WriteOnly: true,
+		Validators: append([]validator.String{
+			stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName(secret)),
+			stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName(secret + "_wo_version")),
+		}, extra...),
+	}
+}
+
+// writeOnlyVersionAttribute is the schema of `<secret>_wo_version`.
+func writeOnlyVersionAttribute(secret string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: fmt.Sprintf("Version of `%s_wo`. The secret is sent on create and whenever this value changes; it is otherwise never re-sent.", secret),
+		Optional:            true,
+		Validators: []validator.Int64{
+			int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName(secret + "_wo")),
+		},
+	}
+}
+
+// injectWriteOnly returns plan and prior with every write-only attribute
+// described by attrs (entries with WriteOnlyVersion, at any depth) filled in
+// from config, following the rules above. Elements of lists are matched by
+// index, so reordering a list re-sends its secrets. config, plan and prior
+// share the resource's schema type; prior may be null (Create).
+func injectWriteOnly(config, plan, prior tftypes.Value, attrs []AttrSpec) (tftypes.Value, tftypes.Value, error) {
+	if !hasWriteOnly(attrs) || !config.IsKnown() || config.IsNull() || !plan.IsKnown() || plan.IsNull() {
+		return plan, prior, nil
+	}
+
+	var configFields, planFields map[string]tftypes.Value
+	if err := config.As(&configFields); err != nil {
+		return plan, prior, fmt.Errorf("decode config: %w", err)
+	}
+	if err := plan.As(&planFields); err != nil {
+		return plan, prior, fmt.Errorf("decode plan: %w", err)
+	}
+	priorSet := prior.IsKnown() && !prior.IsNull()
+	priorFields := map[string]tftypes.Value{}
+	if priorSet {
+		if err := prior.As(&priorFields); err != nil {
+			return plan, prior, fmt.Errorf("decode prior: %w", err)
+		}
+	}
+
+	for _, spec := range attrs {
+		configV, ok := configFields[spec.TFName]
+		if !ok || !configV.IsKnown() || configV.IsNull() {
+			continue
+		}
+
+		if spec.WriteOnlyVersion != "" {
+			planFields[spec.TFName] = configV
+			if priorSet && sameVersion(planFields, priorFields, spec.WriteOnlyVersion) {
+				priorFields[spec.TFName] = configV
+			}
+			continue
+		}
+
+		if !hasWriteOnly(spec.Children) {
+			continue
+		}
+		planV := planFields[spec.TFName]
+		priorV, ok := priorFields[spec.TFName]
+		if !ok {
+			priorV = tftypes.NewValue(planV.Type(), nil)
+		}
+		newPlan, newPrior, err := injectWriteOnlyNested(configV, planV, priorV, spec.Children)
+		if err != nil {
+			return plan, prior, fmt.Errorf("%s: %w", spec.TFName, err)
+		}
+		planFields[spec.TFName] = newPlan
+		if priorSet {
+			priorFields[spec.TFName] = newPrior
+		}
+	}
+
+	plan = tftypes.NewValue(plan.Type(), planFields)
+	if priorSet {
+		prior = tftypes.NewValue(prior.Type(), priorFields)
+	}
+	return plan, prior, nil
+}
+
+// injectWriteOnlyNested applies injectWriteOnly to a nested object, or to
+// each object element of a list or map.
+func injectWriteOnlyNested(config, plan, prior tftypes.Value, children []AttrSpec) (tftypes.Value, tftypes.Value, error) {
+	if !config.IsKnown() || config.IsNull() || !plan.IsKnown() || plan.IsNull() {
+		return plan, prior, nil
+	}
+
+	switch t := plan.Type(); {
+	case t.Is(tftypes.Object{}):
+		return injectWriteOnly(config, plan, prior, children)
+
+	case t.Is(tftypes.List{}):
+		var configElems, planElems, priorElems []tftypes.Value
+		if err := config.As(&configElems); err != nil {
+			return plan, prior, err
+		}
+		if err := plan.As(&planElems); err != nil {
+			return plan, prior, err
+		}
+		priorSet := prior.IsKnown() && !prior.IsNull()
+		if priorSet {
+			if err := prior.As(&priorElems); err != nil {
+				return plan, prior, err
+			}
+		}
+		for i := range planElems {
+			if i >= len(configElems) {
+				break
+			}
+			priorElem := tftypes.NewValue(planElems[i].Type(), nil)
+			if i < len(priorElems) {
+				priorElem = priorElems[i]
+			}
+			newPlan, newPrior, err := injectWriteOnly(configElems[i], planElems[i], priorElem, children)
+			if err != nil {
+				return plan, prior, fmt.Errorf("[%d]: %w", i, err)
+			}
+			planElems[i] = newPlan
+			if i < len(priorElems) {
+				priorElems[i] = newPrior
+			}
+		}
+		plan = tftypes.NewValue(plan.Type(), planElems)
+		if priorSet {
+			prior = tftypes.NewValue(prior.Type(), priorElems)
+		}
+		return plan, prior, nil
+
+	case t.Is(tftypes.Map{}):
+		var configElems, planElems, priorElems map[string]tftypes.Value
+		if err := config.As(&configElems); err != nil {
+			return plan, prior, err
+		}
+		if err := plan.As(&planElems); err != nil {
+			return plan, prior, err
+		}
+		priorSet := prior.IsKnown() && !prior.IsNull()
+		if priorSet {
+			if err := prior.As(&priorElems); err != nil {
+				return plan, prior, err
+			}
+		}
+		for k, planElem := range planElems {
+			configElem, ok := configElems[k]
+			if !ok {
+				continue
+			}
+			priorElem, hasPrior := priorElems[k]
+			if !hasPrior {
+				priorElem = tftypes.NewValue(planElem.Type(), nil)
+			}
+			newPlan, newPrior, err := injectWriteOnly(configElem, planElem, priorElem, children)
+			if err != nil {
+				return plan, prior, fmt.Errorf("[%q]: %w", k, err)
+			}
+			planElems[k] = newPlan
+			if hasPrior {
+				priorElems[k] = newPrior
+			}
+		}
+		plan = tftypes.NewValue(plan.Type(), planElems)
+		if priorSet {
+			prior = tftypes.NewValue(prior.Type(), priorElems)
+		}
+		return plan, prior, nil
+	}
+
+	// Sets cannot hold write-only attributes.
+	return plan, prior, nil
+}
+
+// sameVersion reports whether the `*_wo_version` attribute is unchanged
+// between plan and prior.
+func sameVersion(planFields, priorFields map[string]tftypes.Value, version string) bool {
+	planV, ok := planFields[version]
+	if !ok {
+		return false
+	}
+	priorV, ok := priorFields[version]
+	return ok && planV.Equal(priorV)
+}
+
+// hasWriteOnly reports whether attrs declares a write-only attribute at any
+// depth.
+func hasWriteOnly(attrs []AttrSpec) bool {
+	for _, spec := range attrs {
+		if spec.WriteOnlyVersion != "" || hasWriteOnly(spec.Children) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestInjectWriteOnly_TopLevel(t *testing.T) {
+	t.Parallel()
+
+	attrs := map[string]tftypes.Type{
+		"api_key":            tftypes.String,
+		"api_key_wo":         tftypes.String,
+		"api_key_wo_version": tftypes.Number,
+	}
+	spec := []AttrSpec{
+		{TFName: "api_key", JSONName: "apiKey", Kind: Scalar, Sensitive: true},
+		{TFName: "api_key_wo", JSONName: "apiKey", Kind: Scalar, Sensitive: true, WriteOnlyVersion: "api_key_wo_version"},
+		{TFName: "api_key_wo_version", Kind: Synthetic},
+	}
+	// val builds an object as Terraform presents it: the write-only value
+	// only ever appears in config.
+	val := func(apiKey, wo any, version any) tftypes.Value {
+		return objVal(attrs, map[string]tftypes.Value{
+			"api_key":            tftypes.NewValue(tftypes.String, apiKey),
+			"api_key_wo":         tftypes.NewValue(tftypes.String, wo),
+			"api_key_wo_version": tftypes.NewValue(tftypes.Number, version),
+		})
+	}
+
+	tests := []struct {
+		name   string
+		config tftypes.Value
+		plan   tftypes.Value
+		prior  tftypes.Value
+		want   map[string]any
+	}{
+		{
+			name:   "create sends the secret",
+			config: val(nil, "sk-1", 1),
+			plan:   val(nil, nil, 1),
+			prior:  tftypes.NewValue(objType(attrs), nil),
+			want:   map[string]any{"apiKey": "sk-1"},
+		},
+		{
+			name:   "unchanged version sends nothing",
+			config: val(nil, "sk-2", 1),
+			plan:   val(nil, nil, 1),
+			prior:  val(nil, nil, 1),
+			want:   map[string]any{},
+		},
+		{
+			name:   "bumped version sends the secret",
+			config: val(nil, "sk-2", 2),
+			plan:   val(nil, nil, 2),
+			prior:  val(nil, nil, 1),
+			want:   map[string]any{"apiKey": "sk-2"},
+		},
+		{
+			name:   "switching from the sensitive attribute does not clear the secret",
+			config: val(nil, "sk-1", nil),
+			plan:   val(nil, nil, nil),
+			prior:  val("sk-1", nil, nil),
+			want:   map[string]any{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, prior, err := injectWriteOnly(tc.config, tc.plan, tc.prior, spec)
+			if err != nil {
+				t.Fatalf("injectWriteOnly: %s", err)
+			}
+			var diags diag.Diagnostics
+			got := MergePatch(t.Context(), plan, prior, spec, &diags)
+			if diags.HasError() {
+				t.Fatalf("MergePatch: %v", diags)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("patch = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInjectWriteOnly_ListOfObjects(t *testing.T) {
+	t.Parallel()
+
+	elemAttrs := map[string]tftypes.Type{
+		"server":              tftypes.String,
+		"password":            tftypes.String,
+		"password_wo":         tftypes.String,
+		"password_wo_version": tftypes.Number,
+	}
+	elemT := objType(elemAttrs)
+	attrs := map[string]tftypes.Type{"secrets": tftypes.List{ElementType: elemT}}
+	spec := []AttrSpec{
+		{TFName: "secrets", JSONName: "secrets", Kind: List, Children: []AttrSpec{
+			{TFName: "server", JSONName: "server", Kind: Scalar},
+			{TFName: "password", JSONName: "password", Kind: Scalar, Sensitive: true},
+			{TFName: "password_wo", JSONName: "password", Kind: Scalar, Sensitive: true, WriteOnlyVersion: "password_wo_version"},
+			{TFName: "password_wo_version", Kind: Synthetic},
+		}},
+	}
+	elem := func(server string, wo any, version any) tftypes.Value {
+		return objVal(elemAttrs, map[string]tftypes.Value{
+			"server":              tftypes.NewValue(tftypes.String, server),
+			"password":            tftypes.NewValue(tftypes.String, nil),
+			"password_wo":         tftypes.NewValue(tftypes.String, wo),
+			"password_wo_version": tftypes.NewValue(tftypes.Number, version),
+		})
+	}
+	val := func(elems ...tftypes.Value) tftypes.Value {
+		return objVal(attrs, map[string]tftypes.Value{"secrets": tftypes.NewValue(tftypes.List{ElementType: elemT}, elems)})
+	}
+
+	tests := []struct {
+		name   string
+		config tftypes.Value
+		plan   tftypes.Value
+		prior  tftypes.Value
+		want   map[string]any
+	}{
+		{
+			name:   "unchanged versions send nothing",
+			config: val(elem("a", "p1", 1)),
+			plan:   val(elem("a", nil, 1)),
+			prior:  val(elem("a", nil, 1)),
+			want:   map[string]any{},
+		},
+		{
+			// The list is replaced wholesale, so an untouched element still
+			// carries its secret — the backend would drop it otherwise.
+			name:   "other field change re-sends the whole list with secrets",
+			config: val(elem("b", "p1", 1)),
+			plan:   val(elem("b", nil, 1)),
+			prior:  val(elem("a", nil, 1)),
+			want: map[string]any{"secrets": []any{
+				map[string]any{"server": "b", "password": "p1"},
+			}},
+		},
+		{
+			name:   "new element sends its secret",
+			config: val(elem("a", "p1", 1), elem("c", "p2", 1)),
+			plan:   val(elem("a", nil, 1), elem("c", nil, 1)),
+			prior:  val(elem("a", nil, 1)),
+			want: map[string]any{"secrets": []any{
+				map[string]any{"server": "a", "password": "p1"},
+				map[string]any{"server": "c", "password": "p2"},
+			}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plan, prior, err := injectWriteOnly(tc.config, tc.plan, tc.prior, spec)
+			if err != nil {
+				t.Fatalf("injectWriteOnly: %s", err)
+			}
+			var diags diag.Diagnostics
+			got := MergePatch(t.Context(), plan, prior, spec, &diags)
+			if diags.HasError() {
+				t.Fatalf("MergePatch: %v", diags)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("patch = %#v, want %#v", got, tc.want)
+			}
+		})
+	}
+}